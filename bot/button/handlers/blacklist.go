@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/guild/emoji"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type BlacklistHandler struct{}
+
+func (h *BlacklistHandler) Matcher() matcher.Matcher {
+	return &matcher.FuncMatcher{
+		Func: func(customId string) bool {
+			return strings.HasPrefix(customId, "blacklist_")
+		},
+	}
+}
+
+func (h *BlacklistHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+var blacklistPattern = regexp.MustCompile(`blacklist_(\d+)`)
+
+func (h *BlacklistHandler) Execute(ctx *context.ButtonContext) {
+	groups := blacklistPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	page, err := strconv.Atoi(groups[1])
+	if err != nil {
+		return
+	}
+
+	if page < 0 {
+		return
+	}
+
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		return
+	}
+
+	msgEmbed, isBlank := logic.BuildBlacklistMessage(ctx, page)
+
+	// Don't replace the last page with a blank one
+	if isBlank && page > 0 {
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds: []*embed.Embed{msgEmbed},
+		Components: []component.Component{
+			component.BuildActionRow(
+				component.BuildButton(component.Button{
+					CustomId: fmt.Sprintf("blacklist_%d", page-1),
+					Style:    component.ButtonStylePrimary,
+					Emoji: &emoji.Emoji{
+						Name: "◀️",
+					},
+					Disabled: page <= 0,
+				}),
+				component.BuildButton(component.Button{
+					CustomId: fmt.Sprintf("blacklist_%d", page+1),
+					Style:    component.ButtonStylePrimary,
+					Emoji: &emoji.Emoji{
+						Name: "▶️",
+					},
+					Disabled: isBlank,
+				}),
+			),
+		},
+	})
+}
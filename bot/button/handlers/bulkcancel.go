@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type BulkCancelHandler struct{}
+
+func (h *BulkCancelHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "bulk_cancel_")
+	})
+}
+
+func (h *BulkCancelHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+var bulkCancelPattern = regexp.MustCompile(`bulk_cancel_(\d+)`)
+
+func (h *BulkCancelHandler) Execute(ctx *context.ButtonContext) {
+	groups := bulkCancelPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	// Errors are impossible
+	jobId, _ := strconv.Atoi(groups[1])
+
+	job, ok, err := dbclient.Tables.BulkTicketJobs.Get(jobId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok || job.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkExpired)
+		return
+	}
+
+	if job.UserId != ctx.UserId() {
+		permissionLevel, err := ctx.UserPermissionLevel()
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if permissionLevel < permission.Admin {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+			return
+		}
+	}
+
+	if _, err := dbclient.Tables.BulkTicketJobs.Cancel(job.Id); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// A running job will replace the preview with its summary once it sees that it has been cancelled
+	if job.Status == tables.BulkJobRunning {
+		ctx.Reply(customisation.Orange, i18n.TitleBulk, i18n.MessageBulkCancelling)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(logic.BuildBulkProgressEmbed(ctx, 0, len(job.TicketIds), logic.BulkResult{Cancelled: true})),
+		Components: []component.Component{},
+	})
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	cmdregistry "github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type BulkConfirmHandler struct{}
+
+func (h *BulkConfirmHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "bulk_confirm_")
+	})
+}
+
+func (h *BulkConfirmHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+var bulkConfirmPattern = regexp.MustCompile(`bulk_confirm_(\d+)`)
+
+func (h *BulkConfirmHandler) Execute(ctx *context.ButtonContext) {
+	groups := bulkConfirmPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	// Errors are impossible
+	jobId, _ := strconv.Atoi(groups[1])
+
+	job, ok, err := dbclient.Tables.BulkTicketJobs.Get(jobId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok || job.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkExpired)
+		return
+	}
+
+	// Only the user who previewed the job knows what it will do
+	if job.UserId != ctx.UserId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return
+	}
+
+	started, err := dbclient.Tables.BulkTicketJobs.Start(job.Id, time.Now().Add(-logic.BulkPreviewTimeout))
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !started {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkExpired)
+		return
+	}
+
+	startedAt := time.Now()
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(logic.BuildBulkProgressEmbed(ctx, 0, len(job.TicketIds), logic.BulkResult{})),
+		Components: utils.Slice(logic.BuildBulkCancelButton(ctx, job.Id)),
+	})
+
+	// Jobs can take several minutes, which shouldn't be counted towards the handler's execution time
+	go func() {
+		ticketContext := func(ticket database.Ticket) cmdregistry.CommandContext {
+			// Replies from the actions themselves are discarded, as only the summary is shown
+			ticketCtx := context.NewAutoCloseContext(ctx.Worker(), ticket.GuildId, *ticket.ChannelId, ctx.UserId(), ctx.PremiumTier())
+			return &ticketCtx
+		}
+
+		// The interaction token expires after 15 minutes, after which the response can no longer be edited, so any
+		// further progress is posted to the channel instead
+		var fallbackMessageId uint64
+		onProgress := func(done int, result logic.BulkResult) {
+			progressEmbed := logic.BuildBulkProgressEmbed(ctx, done, len(job.TicketIds), result)
+
+			if time.Since(startedAt) >= logic.BulkPreviewTimeout {
+				if fallbackMessageId == 0 {
+					msg, err := ctx.Worker().CreateMessageEmbed(ctx.ChannelId(), progressEmbed)
+					if err != nil {
+						ctx.HandleWarning(err)
+						return
+					}
+
+					fallbackMessageId = msg.Id
+				} else if _, err := ctx.Worker().EditMessage(ctx.ChannelId(), fallbackMessageId, rest.EditMessageData{
+					Embeds: utils.Slice(progressEmbed),
+				}); err != nil {
+					ctx.HandleWarning(err)
+				}
+
+				return
+			}
+
+			var components []component.Component
+			if done < len(job.TicketIds) && !result.Cancelled {
+				components = utils.Slice(logic.BuildBulkCancelButton(ctx, job.Id))
+			} else {
+				components = []component.Component{}
+			}
+
+			ctx.Edit(command.MessageResponse{
+				Embeds:     utils.Slice(progressEmbed),
+				Components: components,
+			})
+		}
+
+		if _, err := logic.RunBulkJob(ctx, job, ticketContext, onProgress); err != nil {
+			ctx.HandleError(err)
+		}
+	}()
+}
@@ -1,15 +1,20 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/TicketsBot/common/sentry"
 	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker/bot/button/registry"
 	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
 	"github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
 	"strings"
 )
 
@@ -53,6 +58,7 @@ func (h *FormHandler) Execute(ctx *context.ModalContext) {
 
 		if blacklisted {
 			ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+			logic.NotifyBlacklisted(ctx)
 			return
 		}
 
@@ -63,38 +69,87 @@ func (h *FormHandler) Execute(ctx *context.ModalContext) {
 		}
 
 		formAnswers := make(map[database.FormInput]string)
+		rawAnswers := make(map[string]string) // Kept so that the form can be restored if validation fails
+		var questions []database.FormInput    // Ordered as they appear in the modal
 		for _, actionRow := range data.Components {
 			for _, input := range actionRow.Components {
+				rawAnswers[input.CustomId] = input.Value
+
 				questionData, ok := inputs[input.CustomId]
 				if ok { // If form has changed, we can skip
 					formAnswers[questionData] = input.Value
+					questions = append(questions, questionData)
 				}
 			}
 		}
 
+		inputIds := make([]int, len(questions))
+		for i, question := range questions {
+			inputIds[i] = question.Id
+		}
+
+		validations, err := dbclient.Tables.FormInputValidation.GetAllForInputs(inputIds)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
 		// Validate user input
-		for question, answer := range formAnswers {
-			if !question.Required {
-				continue
-			}
+		for _, question := range questions {
+			answer := formAnswers[question]
+
+			if question.Required {
+				// Check that users have not just pressed newline or space
+				isValid := false
+				for _, c := range answer {
+					if c != rune(' ') && c != rune('\n') {
+						isValid = true
+						break
+					}
+				}
 
-			// Check that users have not just pressed newline or space
-			isValid := false
-			for _, c := range answer {
-				if c != rune(' ') && c != rune('\n') {
-					isValid = true
-					break
+				if !isValid {
+					rejectFormSubmission(ctx, panel, rawAnswers, i18n.MessageFormMissingInput, question.Label)
+					return
 				}
 			}
 
-			if !isValid {
-				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFormMissingInput, question.Label)
-				return
+			if validation, ok := validations[question.Id]; ok {
+				if validationError := logic.ValidateFormAnswer(question.Label, validation, answer); validationError != nil {
+					rejectFormSubmission(ctx, panel, rawAnswers, validationError.MessageId, validationError.Format...)
+					return
+				}
 			}
 		}
 
+		if err := redis.DeleteFormAnswers(ctx.GuildId(), ctx.UserId(), panel.PanelId); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+
 		_, _ = logic.OpenTicket(ctx, &panel, panel.Title, formAnswers)
 
 		return
 	}
 }
+
+// Tell the user which answer was rejected, and offer to reopen the form with their previous answers filled in
+func rejectFormSubmission(ctx *context.ModalContext, panel database.Panel, answers map[string]string, messageId i18n.MessageId, format ...interface{}) {
+	if err := redis.StoreFormAnswers(ctx.GuildId(), ctx.UserId(), panel.PanelId, answers); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	errorEmbed := utils.BuildEmbed(ctx, customisation.Red, i18n.Error, messageId, nil, format...)
+	components := []component.Component{
+		component.BuildActionRow(
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.MessageFormRetry),
+				CustomId: fmt.Sprintf("form_retry_%s", panel.CustomId),
+				Style:    component.ButtonStylePrimary,
+				Emoji:    utils.BuildEmoji("✏️"),
+			}),
+		),
+	}
+
+	_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponseWithComponents(errorEmbed, components))
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/i18n"
+	"strings"
+)
+
+type FormRetryHandler struct{}
+
+func (h *FormRetryHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "form_retry_")
+	})
+}
+
+func (h *FormRetryHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed),
+	}
+}
+
+func (h *FormRetryHandler) Execute(ctx *context.ButtonContext) {
+	customId := strings.TrimPrefix(ctx.InteractionData.CustomId, "form_retry_")
+
+	panel, ok, err := dbclient.Client.Panel.GetByCustomId(ctx.GuildId(), customId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok || panel.GuildId != ctx.GuildId() {
+		return
+	}
+
+	// blacklist check
+	blacklisted, err := ctx.IsBlacklisted()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if blacklisted {
+		ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+		logic.NotifyBlacklisted(ctx)
+		return
+	}
+
+	// The form may have been removed from the panel since the user last submitted it
+	if panel.FormId == nil {
+		ctx.HandleError(errors.New("Form not found"))
+		return
+	}
+
+	form, ok, err := dbclient.Client.Forms.Get(*panel.FormId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.HandleError(errors.New("Form not found"))
+		return
+	}
+
+	inputs, err := dbclient.Client.FormInput.GetInputs(form.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// If the answers have expired, the user will simply be shown a blank form
+	answers, err := redis.GetFormAnswers(ctx.GuildId(), ctx.UserId(), panel.PanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	modal, err := buildForm(panel, form, inputs, answers)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Modal(modal)
+}
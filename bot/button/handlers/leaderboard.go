@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"strings"
+)
+
+type LeaderboardHandler struct{}
+
+func (h *LeaderboardHandler) Matcher() matcher.Matcher {
+	return &matcher.FuncMatcher{
+		Func: func(customId string) bool {
+			return strings.HasPrefix(customId, "leaderboard_")
+		},
+	}
+}
+
+func (h *LeaderboardHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+func (h *LeaderboardHandler) Execute(ctx *context.ButtonContext) {
+	options, page, ok := logic.ParseLeaderboardCustomId(ctx.InteractionData.CustomId)
+	if !ok {
+		return
+	}
+
+	msgEmbed, hasNext, err := logic.BuildLeaderboardMessage(ctx, options, page)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     []*embed.Embed{msgEmbed},
+		Components: logic.BuildLeaderboardComponents(options, page, hasNext),
+	})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ModmailGuildHandler struct{}
+
+func (h *ModmailGuildHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "modmail_guild_")
+	})
+}
+
+func (h *ModmailGuildHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.DMsAllowed, registry.CanEdit),
+	}
+}
+
+var modmailGuildPattern = regexp.MustCompile(`modmail_guild_(\d+)`)
+
+func (h *ModmailGuildHandler) Execute(ctx *context.SelectMenuContext) {
+	groups := modmailGuildPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 || len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	// Error is impossible
+	messageId, _ := strconv.ParseUint(groups[1], 10, 64)
+
+	guildId, err := strconv.ParseUint(ctx.InteractionData.Values[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	canUse, err := logic.CanUseModmail(ctx.Worker(), guildId, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !canUse {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailUnavailable)
+		return
+	}
+
+	panels, err := dbclient.Client.Panel.GetByGuild(guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(panels) == 0 {
+		openModmailTicket(ctx, guildId, messageId, nil)
+		return
+	}
+
+	// The user still hasn't picked a panel, so there is no guild language to use
+	promptEmbed := utils.BuildEmbedRaw(
+		customisation.GetDefaultColour(customisation.Green),
+		i18n.GetMessage(i18n.English, i18n.TitleModmail),
+		i18n.GetMessage(i18n.English, i18n.MessageModmailSelectPanel),
+		nil,
+		ctx.PremiumTier(),
+	)
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     []*embed.Embed{promptEmbed},
+		Components: []component.Component{logic.BuildModmailPanelSelect(guildId, panels, messageId)},
+	})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ModmailPanelHandler struct{}
+
+func (h *ModmailPanelHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "modmail_panel_")
+	})
+}
+
+func (h *ModmailPanelHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.DMsAllowed, registry.CanEdit),
+	}
+}
+
+var modmailPanelPattern = regexp.MustCompile(`modmail_panel_(\d+)_(\d+)`)
+
+func (h *ModmailPanelHandler) Execute(ctx *context.SelectMenuContext) {
+	groups := modmailPanelPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 3 || len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	// Errors are impossible
+	guildId, _ := strconv.ParseUint(groups[1], 10, 64)
+	messageId, _ := strconv.ParseUint(groups[2], 10, 64)
+
+	panelId, err := strconv.Atoi(ctx.InteractionData.Values[0])
+	if err != nil {
+		return
+	}
+
+	canUse, err := logic.CanUseModmail(ctx.Worker(), guildId, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !canUse {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailUnavailable)
+		return
+	}
+
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != guildId {
+		return
+	}
+
+	openModmailTicket(ctx, guildId, messageId, &panel)
+}
+
+func openModmailTicket(ctx *context.SelectMenuContext, guildId, messageId uint64, panel *database.Panel) {
+	// Only one modmail ticket may be open at a time, as DMs can't be told apart otherwise. The session is reserved
+	// before the ticket is opened, so that selecting a panel twice in quick succession can't open two tickets.
+	reserved, err := dbclient.Tables.ModmailSessions.Reserve(ctx.Worker().BotId, ctx.UserId(), guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !reserved {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageModmailAlreadyOpen)
+		return
+	}
+
+	// Let the user try again if the ticket isn't opened
+	opened := false
+	defer func() {
+		if !opened {
+			if err := dbclient.Tables.ModmailSessions.Release(ctx.Worker().BotId, ctx.UserId()); err != nil {
+				ctx.HandleError(err)
+			}
+		}
+	}()
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(guildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Replies from the panel context are sent to the user's DMs, in the guild's language
+	panelCtx := context.NewPanelContext(ctx.Worker(), guildId, ctx.ChannelId(), ctx.UserId(), premiumTier)
+
+	blacklisted, err := panelCtx.IsBlacklisted()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if blacklisted {
+		ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+		return
+	}
+
+	msg, err := ctx.Worker().GetChannelMessage(ctx.ChannelId(), messageId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ticket, err := logic.OpenModmailTicket(&panelCtx, panel, msg.Content)
+	if err != nil || ticket.Id == 0 { // Already handled
+		return
+	}
+
+	// Once the ticket is open, keep the reservation even if it can't be linked to the ticket, so that it still blocks
+	// another ticket from being opened until it times out
+	opened = true
+	if err := dbclient.Tables.ModmailSessions.SetTicket(ctx.Worker().BotId, ctx.UserId(), ticket.Id); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := logic.RelayModmailUserMessage(&panelCtx, ticket, msg); err != nil {
+		ctx.HandleError(err)
+	}
+
+	// Let staff know how to reply, as messages in the channel are kept from the user by default
+	if ticket.ChannelId != nil {
+		hintEmbed := utils.BuildEmbed(&panelCtx, customisation.Green, i18n.TitleModmail, i18n.MessageModmailStaffHint, nil, logic.ModmailReplyPrefix)
+		if _, err := ctx.Worker().CreateMessageEmbed(*ticket.ChannelId, hintEmbed); err != nil {
+			ctx.HandleError(err)
+		}
+	}
+
+	guild, err := ctx.Worker().GetGuild(guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	openedEmbed := utils.BuildEmbed(&panelCtx, customisation.Green, i18n.TitleModmail, i18n.MessageModmailOpened, nil, guild.Name)
+	ctx.Edit(command.MessageResponse{
+		Embeds:     []*embed.Embed{openedEmbed},
+		Components: []component.Component{},
+	})
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/button"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	cmdregistry "github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type MoveMessagesHandler struct{}
+
+func (h *MoveMessagesHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "move_messages_")
+	})
+}
+
+func (h *MoveMessagesHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+var (
+	moveMessagesPattern      = regexp.MustCompile(`move_messages_(\d+)_(\d+)`)
+	moveMessagesValuePattern = regexp.MustCompile(`^(copy|move)_(\d+|range)$`)
+)
+
+// moveMessagesContext is implemented by both the select menu context, and the context of the range modal
+type moveMessagesContext interface {
+	cmdregistry.CommandContext
+	Edit(data command.MessageResponse)
+}
+
+func (h *MoveMessagesHandler) Execute(ctx *context.SelectMenuContext) {
+	groups := moveMessagesPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 3 || len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	values := moveMessagesValuePattern.FindStringSubmatch(ctx.InteractionData.Values[0])
+	if len(values) < 3 {
+		return
+	}
+
+	// Errors are impossible
+	ticketId, _ := strconv.Atoi(groups[1])
+	messageId, _ := strconv.ParseUint(groups[2], 10, 64)
+	deleteOriginals := values[1] == "move"
+
+	var count int
+	if values[2] != "range" {
+		count, _ = strconv.Atoi(values[2])
+		if !isMoveMessagesCount(count) {
+			return
+		}
+	}
+
+	ticket, ok := getMoveMessagesTicket(ctx, ticketId)
+	if !ok {
+		return
+	}
+
+	// The first message of the range is asked for, and the messages are moved once the modal is submitted
+	if values[2] == "range" {
+		ctx.Modal(buildMoveMessagesRangeModal(ctx.GuildId(), values[1], ticket.Id, messageId))
+		return
+	}
+
+	// Reposting messages one by one can take far longer than we have to respond, so acknowledge the selection first
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(utils.BuildEmbed(ctx, customisation.Green, i18n.Ticket, i18n.MessageMoveMessagesInProgress, nil, count)),
+		Components: []component.Component{},
+	})
+
+	msgs, err := logic.FetchMessagesUpTo(ctx.Worker(), ctx.ChannelId(), messageId, count)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	moveMessages(ctx, ticket, msgs, deleteOriginals)
+}
+
+// getMoveMessagesTicket returns false, having replied with an error, if the user can't move messages into the ticket
+func getMoveMessagesTicket(ctx cmdregistry.CommandContext, ticketId int) (database.Ticket, bool) {
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, false
+	}
+
+	if permissionLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return database.Ticket{}, false
+	}
+
+	ticket, err := dbclient.Client.Tickets.Get(ticketId, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, false
+	}
+
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMoveMessagesTicketClosed)
+		return database.Ticket{}, false
+	}
+
+	return ticket, true
+}
+
+// moveMessages copies the messages into the ticket, and if deleteOriginals is set, deletes the messages that were
+// copied. Messages that were skipped, as they had nothing to copy, are left alone.
+func moveMessages(ctx moveMessagesContext, ticket database.Ticket, msgs []message.Message, deleteOriginals bool) {
+	copied, err := logic.CopyMessagesToTicket(ctx, ticket, msgs)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	var resultEmbed *embed.Embed
+	if deleteOriginals && len(copied) > 0 {
+		// Only delete the originals once every message has made it into the ticket
+		if err := logic.DeleteMessages(ctx.Worker(), ctx.ChannelId(), copied); err != nil {
+			resultEmbed = utils.BuildEmbed(ctx, customisation.Orange, i18n.Ticket, i18n.MessageMoveMessagesDeleteFailed, nil, len(copied), *ticket.ChannelId)
+		} else {
+			resultEmbed = utils.BuildEmbed(ctx, customisation.Green, i18n.Ticket, i18n.MessageMoveMessagesMoved, nil, len(copied), *ticket.ChannelId)
+		}
+	} else {
+		resultEmbed = utils.BuildEmbed(ctx, customisation.Green, i18n.Ticket, i18n.MessageMoveMessagesCopied, nil, len(copied), *ticket.ChannelId)
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(resultEmbed),
+		Components: []component.Component{},
+	})
+}
+
+func buildMoveMessagesRangeModal(guildId uint64, mode string, ticketId int, messageId uint64) button.ResponseModal {
+	return button.ResponseModal{
+		Data: interaction.ModalResponseData{
+			CustomId: fmt.Sprintf("move_messages_range_%s_%d_%d", mode, ticketId, messageId),
+			Title:    i18n.TitleMoveMessagesRange.GetFromGuild(guildId),
+			Components: []component.Component{
+				component.BuildActionRow(component.BuildInputText(component.InputText{
+					Style:       component.TextStyleShort,
+					CustomId:    "first",
+					Label:       i18n.MessageMoveMessagesRangeLabel.GetFromGuild(guildId),
+					Placeholder: utils.Ptr(i18n.MessageMoveMessagesRangePlaceholder.GetFromGuild(guildId)),
+					MinLength:   utils.Ptr(uint32(1)),
+					MaxLength:   utils.Ptr(uint32(200)),
+				})),
+			},
+		},
+	}
+}
+
+func isMoveMessagesCount(count int) bool {
+	for _, allowed := range logic.MoveMessagesCounts {
+		if count == allowed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest/request"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type MoveMessagesRangeSubmitHandler struct{}
+
+func (h *MoveMessagesRangeSubmitHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "move_messages_range_")
+	})
+}
+
+func (h *MoveMessagesRangeSubmitHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+var moveMessagesRangePattern = regexp.MustCompile(`move_messages_range_(copy|move)_(\d+)_(\d+)`)
+
+func (h *MoveMessagesRangeSubmitHandler) Execute(ctx *context.ModalContext) {
+	data := ctx.Interaction.Data
+
+	groups := moveMessagesRangePattern.FindStringSubmatch(data.CustomId)
+	if len(groups) < 4 {
+		return
+	}
+
+	// Errors are impossible
+	deleteOriginals := groups[1] == "move"
+	ticketId, _ := strconv.Atoi(groups[2])
+	lastId, _ := strconv.ParseUint(groups[3], 10, 64)
+
+	var firstRaw string
+	for _, actionRow := range data.Components {
+		for _, input := range actionRow.Components {
+			if input.CustomId == "first" {
+				firstRaw = input.Value
+			}
+		}
+	}
+
+	// Message IDs are snowflakes, so the first message of the range must have the smaller ID
+	firstId, ok := logic.ParseMessageReference(firstRaw)
+	if !ok || firstId > lastId {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMoveMessagesRangeInvalid)
+		return
+	}
+
+	ticket, ok := getMoveMessagesTicket(ctx, ticketId)
+	if !ok {
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(utils.BuildEmbed(ctx, customisation.Green, i18n.Ticket, i18n.MessageMoveMessagesRangeInProgress, nil)),
+		Components: []component.Component{},
+	})
+
+	msgs, err := logic.FetchMessagesBetween(ctx.Worker(), ctx.ChannelId(), firstId, lastId)
+	if err != nil {
+		if errors.Is(err, logic.ErrTooManyMessages) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMoveMessagesRangeTooLarge, logic.MaxMoveMessagesRange)
+		} else if restError, ok := err.(request.RestError); ok && restError.StatusCode == 404 {
+			// The message is in another channel, or has been deleted
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageMoveMessagesRangeInvalid)
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	moveMessages(ctx, ticket, msgs, deleteOriginals)
+}
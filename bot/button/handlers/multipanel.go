@@ -54,12 +54,21 @@ func (h *MultiPanelHandler) Execute(ctx *context.SelectMenuContext) {
 
 		if blacklisted {
 			ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+			logic.NotifyBlacklisted(ctx)
 			return
 		}
 
 		if panel.FormId == nil {
 			_, _ = logic.OpenTicket(ctx, &panel, panel.Title, nil)
 		} else {
+			// Don't make the user fill out the form if the ticket will be refused anyway
+			if _, allowed, err := logic.CheckBusinessHours(ctx, &panel); err != nil {
+				ctx.HandleError(err)
+				return
+			} else if !allowed {
+				return
+			}
+
 			form, ok, err := dbclient.Client.Forms.Get(*panel.FormId)
 			if err != nil {
 				ctx.HandleError(err)
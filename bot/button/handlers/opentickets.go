@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"strings"
+)
+
+type OpenTicketsHandler struct{}
+
+func (h *OpenTicketsHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "open_tickets_page_")
+	})
+}
+
+func (h *OpenTicketsHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+func (h *OpenTicketsHandler) Execute(ctx *context.ButtonContext) {
+	options, page, ok := logic.ParseOpenTicketsCustomId(ctx.InteractionData.CustomId)
+	if !ok || page < 0 {
+		return
+	}
+
+	if options.Mine {
+		options.UserId = utils.Ptr(ctx.UserId())
+	} else {
+		permissionLevel, err := ctx.UserPermissionLevel()
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if permissionLevel < permission.Support {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+			return
+		}
+	}
+
+	msgEmbed, components, err := logic.BuildOpenTicketsMessage(ctx, options, page)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(msgEmbed),
+		Components: components,
+	})
+}
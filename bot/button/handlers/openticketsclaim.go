@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type OpenTicketsClaimHandler struct{}
+
+func (h *OpenTicketsClaimHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "open_tickets_claim_")
+	})
+}
+
+func (h *OpenTicketsClaimHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed),
+	}
+}
+
+var openTicketsClaimPattern = regexp.MustCompile(`open_tickets_claim_(\d+)`)
+
+func (h *OpenTicketsClaimHandler) Execute(ctx *context.ButtonContext) {
+	groups := openTicketsClaimPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	// Errors are impossible
+	ticketId, _ := strconv.Atoi(groups[1])
+
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimNoPermission)
+		return
+	}
+
+	ticket, err := dbclient.Client.Tickets.Get(ticketId, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenTicketsNotOpen)
+		return
+	}
+
+	// The ticket may have been claimed since the list was sent
+	claimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if claimer != 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenTicketsAlreadyClaimed, claimer)
+		return
+	}
+
+	ch, err := ctx.Worker().GetChannel(*ticket.ChannelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ch.Type == channel.ChannelTypeGuildPrivateThread {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimThread)
+		return
+	}
+
+	if err := logic.ClaimTicket(ctx, ticket, ctx.UserId()); err != nil {
+		var limitErr logic.ClaimLimitError
+		if errors.As(err, &limitErr) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimLimitReached, limitErr.Limit)
+			return
+		}
+
+		ctx.HandleError(err)
+		return
+	}
+
+	claimedEmbed := utils.BuildEmbed(ctx, customisation.Green, i18n.TitleClaimed, i18n.MessageClaimed, nil, fmt.Sprintf("<@%d>", ctx.UserId()))
+	if _, err := ctx.Worker().CreateMessageEmbed(*ticket.ChannelId, claimedEmbed); err != nil {
+		ctx.HandleWarning(err)
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleClaimed, i18n.MessageOpenTicketsClaimed, ticket.Id, *ticket.ChannelId)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type OpenTicketsCloseHandler struct{}
+
+func (h *OpenTicketsCloseHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "open_tickets_close_")
+	})
+}
+
+func (h *OpenTicketsCloseHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed),
+	}
+}
+
+var openTicketsClosePattern = regexp.MustCompile(`open_tickets_close_(\d+)`)
+
+func (h *OpenTicketsCloseHandler) Execute(ctx *context.ButtonContext) {
+	groups := openTicketsClosePattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	// Errors are impossible
+	ticketId, _ := strconv.Atoi(groups[1])
+
+	ticket, err := dbclient.Client.Tickets.Get(ticketId, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenTicketsNotOpen)
+		return
+	}
+
+	if !utils.CanClose(ctx, ticket) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseNoPermission)
+		return
+	}
+
+	// The button is pressed outside of the ticket channel, so close the ticket as if from inside it. Replies from
+	// CloseTicket are discarded, so check whether the ticket was actually closed.
+	closeCtx := context.NewAutoCloseContext(ctx.Worker(), ticket.GuildId, *ticket.ChannelId, ctx.UserId(), ctx.PremiumTier())
+	logic.CloseTicket(&closeCtx, nil)
+
+	ticket, err = dbclient.Client.Tickets.Get(ticket.Id, ticket.GuildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Open {
+		ctx.HandleError(fmt.Errorf("ticket %d was not closed", ticket.Id))
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.Ticket, i18n.MessageOpenTicketsClosed, ticket.Id)
+}
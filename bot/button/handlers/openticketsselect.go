@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"strconv"
+)
+
+type OpenTicketsSelectHandler struct{}
+
+func (h *OpenTicketsSelectHandler) Matcher() matcher.Matcher {
+	return &matcher.SimpleMatcher{
+		CustomId: "open_tickets_select",
+	}
+}
+
+func (h *OpenTicketsSelectHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed),
+	}
+}
+
+func (h *OpenTicketsSelectHandler) Execute(ctx *context.SelectMenuContext) {
+	if len(ctx.InteractionData.Values) == 0 {
+		return
+	}
+
+	ticketId, err := strconv.Atoi(ctx.InteractionData.Values[0])
+	if err != nil {
+		return
+	}
+
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return
+	}
+
+	ticket, err := dbclient.Client.Tickets.Get(ticketId, ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenTicketsNotOpen)
+		return
+	}
+
+	claimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	summaryEmbed := utils.BuildEmbed(ctx, customisation.Green, i18n.Ticket, i18n.MessageOpenTicketsSummary, nil, ticket.Id, *ticket.ChannelId, ticket.UserId)
+
+	_, _ = ctx.ReplyWith(command.MessageResponse{
+		Embeds: utils.Slice(summaryEmbed),
+		Flags:  message.SumFlags(message.FlagEphemeral),
+		Components: utils.Slice(component.BuildActionRow(
+			component.BuildButton(component.Button{
+				Label: ctx.GetMessage(i18n.MessageOpenTicketsJumpButton),
+				Style: component.ButtonStyleLink,
+				Url:   utils.Ptr(fmt.Sprintf("https://discord.com/channels/%d/%d", ticket.GuildId, *ticket.ChannelId)),
+			}),
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.MessageOpenTicketsClaimButton),
+				CustomId: fmt.Sprintf("open_tickets_claim_%d", ticket.Id),
+				Style:    component.ButtonStyleSuccess,
+				Disabled: claimer != 0,
+			}),
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.MessageOpenTicketsCloseButton),
+				CustomId: fmt.Sprintf("open_tickets_close_%d", ticket.Id),
+				Style:    component.ButtonStyleDanger,
+			}),
+		)),
+	})
+}
@@ -52,12 +52,21 @@ func (h *PanelHandler) Execute(ctx *context.ButtonContext) {
 
 		if blacklisted {
 			ctx.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+			logic.NotifyBlacklisted(ctx)
 			return
 		}
 
 		if panel.FormId == nil {
 			_, _ = logic.OpenTicket(ctx, &panel, panel.Title, nil)
 		} else {
+			// Don't make the user fill out the form if the ticket will be refused anyway
+			if _, allowed, err := logic.CheckBusinessHours(ctx, &panel); err != nil {
+				ctx.HandleError(err)
+				return
+			} else if !allowed {
+				return
+			}
+
 			form, ok, err := dbclient.Client.Forms.Get(*panel.FormId)
 			if err != nil {
 				ctx.HandleError(err)
@@ -78,7 +87,12 @@ func (h *PanelHandler) Execute(ctx *context.ButtonContext) {
 			if len(inputs) == 0 { // Don't open a blank form
 				_, _ = logic.OpenTicket(ctx, &panel, panel.Title, nil)
 			} else {
-				modal := buildForm(panel, form, inputs)
+				modal, err := buildForm(panel, form, inputs, nil)
+				if err != nil {
+					ctx.HandleError(err)
+					return
+				}
+
 				ctx.Modal(modal)
 			}
 		}
@@ -87,7 +101,17 @@ func (h *PanelHandler) Execute(ctx *context.ButtonContext) {
 	}
 }
 
-func buildForm(panel database.Panel, form database.Form, inputs []database.FormInput) button.ResponseModal {
+func buildForm(panel database.Panel, form database.Form, inputs []database.FormInput, answers map[string]string) (button.ResponseModal, error) {
+	inputIds := make([]int, len(inputs))
+	for i, input := range inputs {
+		inputIds[i] = input.Id
+	}
+
+	validations, err := dbclient.Tables.FormInputValidation.GetAllForInputs(inputIds)
+	if err != nil {
+		return button.ResponseModal{}, err
+	}
+
 	components := make([]component.Component, len(inputs))
 	for i, input := range inputs {
 		style := component.TextStyleTypes(input.Style) // wrap
@@ -99,14 +123,33 @@ func buildForm(panel database.Panel, form database.Form, inputs []database.FormI
 			maxLength = 1024 // Max embed field value
 		}
 
+		// Let Discord enforce length rules client side where possible, we still check them on submit
+		var minLength *uint32
+		if validation, ok := validations[input.Id]; ok {
+			if validation.MinLength != nil && *validation.MinLength > 0 && uint32(*validation.MinLength) <= maxLength {
+				minLength = utils.Ptr(uint32(*validation.MinLength))
+			}
+
+			if validation.MaxLength != nil && *validation.MaxLength > 0 && uint32(*validation.MaxLength) < maxLength {
+				maxLength = uint32(*validation.MaxLength)
+			}
+		}
+
+		// Restore the user's previous answer if their last submission was rejected
+		var value *string
+		if answer, ok := answers[input.CustomId]; ok && answer != "" {
+			value = utils.Ptr(answer)
+		}
+
 		components[i] = component.BuildActionRow(component.BuildInputText(component.InputText{
 			Style:       component.TextStyleTypes(input.Style),
 			CustomId:    input.CustomId,
 			Label:       input.Label,
 			Placeholder: input.Placeholder,
-			MinLength:   nil,
+			MinLength:   minLength,
 			MaxLength:   &maxLength,
 			Required:    utils.Ptr(input.Required),
+			Value:       value,
 		}))
 	}
 
@@ -116,5 +159,5 @@ func buildForm(panel database.Panel, form database.Form, inputs []database.FormI
 			Title:      form.Title,
 			Components: components,
 		},
-	}
+	}, nil
 }
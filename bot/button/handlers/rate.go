@@ -1,12 +1,19 @@
 package handlers
 
 import (
+	"fmt"
+	"github.com/TicketsBot/worker/bot/button"
 	"github.com/TicketsBot/worker/bot/button/registry"
 	"github.com/TicketsBot/worker/bot/button/registry/matcher"
 	"github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
 	"regexp"
 	"strconv"
 	"strings"
@@ -69,5 +76,48 @@ func (h *RateHandler) Execute(ctx *context.ButtonContext) {
 		return
 	}
 
-	ctx.Reply(customisation.Green, i18n.Success, i18n.MessageFeedbackSuccess)
+	questions, err := dbclient.Tables.RatingQuestions.GetByGuild(guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// The rating is posted straight away, in case the user dismisses the modal. The answers to any follow-up questions
+	// are added to the message once the modal is submitted.
+	if len(questions) > 0 {
+		ctx.Modal(buildRatingFeedbackModal(guildId, ticketId, rating, questions))
+	} else {
+		ctx.Reply(customisation.Green, i18n.Success, i18n.MessageFeedbackSuccess)
+	}
+
+	logic.HandleRatingFeedback(ctx, guildId, ticket, rating, nil, nil)
+}
+
+func buildRatingFeedbackModal(guildId uint64, ticketId int, rating uint8, questions []tables.RatingQuestion) button.ResponseModal {
+	components := make([]component.Component, len(questions))
+	for i, question := range questions {
+		input := component.InputText{
+			Style:     component.TextStyleParagraph,
+			CustomId:  fmt.Sprintf("question_%d", question.Id),
+			Label:     question.Label,
+			MaxLength: utils.Ptr(uint32(1024)),
+			Required:  utils.Ptr(question.Required),
+		}
+
+		if question.Type == tables.RatingQuestionTypeYesNo {
+			input.Style = component.TextStyleShort
+			input.Placeholder = utils.Ptr(i18n.MessageRatingFeedbackYesNoPlaceholder.GetFromGuild(guildId))
+			input.MaxLength = utils.Ptr(uint32(3))
+		}
+
+		components[i] = component.BuildActionRow(component.BuildInputText(input))
+	}
+
+	return button.ResponseModal{
+		Data: interaction.ModalResponseData{
+			CustomId:   fmt.Sprintf("rate_feedback_%d_%d_%d", guildId, ticketId, rating),
+			Title:      i18n.TitleRatingFeedback.GetFromGuild(guildId),
+			Components: components,
+		},
+	}
 }
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type RateFeedbackSubmitHandler struct{}
+
+func (h *RateFeedbackSubmitHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "rate_feedback_")
+	})
+}
+
+func (h *RateFeedbackSubmitHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.DMsAllowed),
+	}
+}
+
+var rateFeedbackPattern = regexp.MustCompile(`rate_feedback_(\d+)_(\d+)_([1-5])`)
+var rateFeedbackQuestionPattern = regexp.MustCompile(`question_(\d+)`)
+
+func (h *RateFeedbackSubmitHandler) Execute(ctx *context.ModalContext) {
+	data := ctx.Interaction.Data
+
+	groups := rateFeedbackPattern.FindStringSubmatch(data.CustomId)
+	if len(groups) < 4 {
+		return
+	}
+
+	// Errors are impossible
+	guildId, _ := strconv.ParseUint(groups[1], 10, 64)
+	ticketId, _ := strconv.Atoi(groups[2])
+	ratingRaw, _ := strconv.Atoi(groups[3])
+	rating := uint8(ratingRaw)
+
+	ticket, err := dbclient.Client.Tickets.Get(ticketId, guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.UserId != ctx.InteractionUser().Id || ticket.GuildId != guildId || ticket.Id != ticketId {
+		return
+	}
+
+	questions, err := dbclient.Tables.RatingQuestions.GetByGuild(guildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	questionsById := make(map[int]tables.RatingQuestion)
+	for _, question := range questions {
+		questionsById[question.Id] = question
+	}
+
+	answers := make(map[int]string)
+	for _, actionRow := range data.Components {
+		for _, input := range actionRow.Components {
+			groups := rateFeedbackQuestionPattern.FindStringSubmatch(input.CustomId)
+			if len(groups) < 2 {
+				continue
+			}
+
+			questionId, _ := strconv.Atoi(groups[1])
+
+			// If the question has since been deleted, we can skip
+			question, ok := questionsById[questionId]
+			if !ok {
+				continue
+			}
+
+			answer := strings.TrimSpace(input.Value)
+
+			// This must be malicious
+			if len(answer) > 1024 {
+				return
+			}
+
+			if answer == "" {
+				if question.Required {
+					ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFormMissingInput, question.Label)
+					return
+				}
+
+				continue
+			}
+
+			if question.Type == tables.RatingQuestionTypeYesNo {
+				if answer, ok = logic.ParseYesNoAnswer(answer); !ok {
+					ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRatingFeedbackInvalidYesNo, question.Label)
+					return
+				}
+			}
+
+			answers[questionId] = answer
+		}
+	}
+
+	if err := dbclient.Tables.RatingAnswers.Set(guildId, ticketId, answers); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.Success, i18n.MessageFeedbackSuccess)
+	logic.AddRatingFeedbackAnswers(ctx, guildId, ticket, rating, questions, answers)
+}
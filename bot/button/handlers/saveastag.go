@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strings"
+)
+
+type SaveAsTagHandler struct{}
+
+func (h *SaveAsTagHandler) Matcher() matcher.Matcher {
+	return &matcher.FuncMatcher{
+		Func: func(customId string) bool {
+			return strings.HasPrefix(customId, "save_tag_")
+		},
+	}
+}
+
+func (h *SaveAsTagHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed),
+	}
+}
+
+var saveAsTagPattern = regexp.MustCompile(`save_tag_(\d+)_(\d+)`)
+
+func (h *SaveAsTagHandler) Execute(ctx *context.ButtonContext) {
+	groups := saveAsTagPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 3 {
+		return
+	}
+
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		return
+	}
+
+	ctx.Modal(button.ResponseModal{
+		Data: interaction.ModalResponseData{
+			CustomId: fmt.Sprintf("save_tag_submit_%s_%s", groups[1], groups[2]),
+			Title:    i18n.TitleSaveAsTag.GetFromGuild(ctx.GuildId()),
+			Components: []component.Component{
+				component.BuildActionRow(component.BuildInputText(component.InputText{
+					Style:       component.TextStyleShort,
+					CustomId:    "id",
+					Label:       i18n.MessageSaveAsTagIdLabel.GetFromGuild(ctx.GuildId()),
+					Placeholder: utils.Ptr(i18n.MessageSaveAsTagIdPlaceholder.GetFromGuild(ctx.GuildId())),
+					MinLength:   utils.Ptr(uint32(1)),
+					MaxLength:   utils.Ptr(uint32(16)),
+				})),
+			},
+		},
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type SaveAsTagSubmitHandler struct{}
+
+func (h *SaveAsTagSubmitHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "save_tag_submit_")
+	})
+}
+
+func (h *SaveAsTagSubmitHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed),
+	}
+}
+
+var saveAsTagSubmitPattern = regexp.MustCompile(`save_tag_submit_(\d+)_(\d+)`)
+
+func (h *SaveAsTagSubmitHandler) Execute(ctx *context.ModalContext) {
+	data := ctx.Interaction.Data
+
+	groups := saveAsTagSubmitPattern.FindStringSubmatch(data.CustomId)
+	if len(groups) < 3 {
+		return
+	}
+
+	// Errors are impossible
+	channelId, _ := strconv.ParseUint(groups[1], 10, 64)
+	messageId, _ := strconv.ParseUint(groups[2], 10, 64)
+
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if permissionLevel < permission.Support {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNoPermission)
+		return
+	}
+
+	var tagId string
+	for _, actionRow := range data.Components {
+		for _, input := range actionRow.Components {
+			if input.CustomId == "id" {
+				tagId = strings.TrimSpace(input.Value)
+			}
+		}
+	}
+
+	if tagId == "" || len(tagId) > 16 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagCreateTooLong)
+		return
+	}
+
+	// Verify the message is from this guild
+	ch, err := ctx.Worker().GetChannel(channelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ch.GuildId != ctx.GuildId() {
+		return
+	}
+
+	msg, err := ctx.Worker().GetChannelMessage(channelId, messageId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	tag, ok := logic.TagFromMessage(ctx.GuildId(), tagId, msg)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSaveAsTagEmpty)
+		return
+	}
+
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Limit of 200 tags, overwriting an existing tag is an edit
+	if !exists {
+		count, err := dbclient.Client.Tag.GetTagCount(ctx.GuildId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if count >= 200 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagCreateLimit, 200)
+			return
+		}
+	}
+
+	version, err := logic.SaveTag(tag, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if exists {
+		ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageSaveAsTagUpdated, tagId, version)
+	} else {
+		ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagCreateSuccess, tagId)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"strings"
+)
+
+type TicketSearchHandler struct{}
+
+func (h *TicketSearchHandler) Matcher() matcher.Matcher {
+	return &matcher.FuncMatcher{
+		Func: func(customId string) bool {
+			return strings.HasPrefix(customId, "ticket_search_")
+		},
+	}
+}
+
+func (h *TicketSearchHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.CanEdit),
+	}
+}
+
+func (h *TicketSearchHandler) Execute(ctx *context.ButtonContext) {
+	searchId, page, ok := logic.ParseTicketSearchCustomId(ctx.InteractionData.CustomId)
+	if !ok {
+		return
+	}
+
+	search, ok, err := dbclient.Tables.TicketSearches.Get(searchId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Results are ephemeral, so only the user who searched should be able to page through them
+	if !ok || search.GuildId != ctx.GuildId() || search.UserId != ctx.UserId() {
+		return
+	}
+
+	msgEmbed, hasNext, err := logic.BuildTicketSearchMessage(ctx, search.Filter, page)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     []*embed.Embed{msgEmbed},
+		Components: logic.BuildTicketSearchComponents(searchId, page, hasNext),
+	})
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type TransferAcceptHandler struct{}
+
+func (h *TransferAcceptHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "transfer_accept_")
+	})
+}
+
+func (h *TransferAcceptHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.DMsAllowed, registry.CanEdit),
+	}
+}
+
+var transferAcceptPattern = regexp.MustCompile(`transfer_accept_(\d+)`)
+
+func (h *TransferAcceptHandler) Execute(ctx *context.ButtonContext) {
+	groups := transferAcceptPattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	// Errors are impossible
+	requestId, _ := strconv.Atoi(groups[1])
+
+	request, ticket, guildCtx, ok := getRespondableTransferRequest(ctx, requestId)
+	if !ok {
+		return
+	}
+
+	userId := ctx.UserId()
+
+	resolved, err := dbclient.Tables.TransferRequests.Resolve(request.Id, tables.TransferRequestAccepted, &userId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !resolved {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferNoLongerPending)
+		return
+	}
+
+	if err := logic.ClaimTicket(guildCtx, ticket, userId); err != nil {
+		// Let someone else accept the request instead
+		if err := dbclient.Tables.TransferRequests.Reopen(request.Id); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		var limitErr logic.ClaimLimitError
+		if errors.As(err, &limitErr) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimLimitReached, limitErr.Limit)
+			return
+		}
+
+		ctx.HandleError(err)
+		return
+	}
+
+	request.Status = tables.TransferRequestAccepted
+	request.ResolvedBy = &userId
+	editTransferRequest(ctx, guildCtx, request)
+
+	if err := logic.SendTransferNotice(guildCtx, ticket, customisation.Green, i18n.TitleClaimed, i18n.MessageTransferAccepted, userId, request.RequesterId); err != nil {
+		ctx.HandleWarning(err)
+	}
+}
+
+// getRespondableTransferRequest returns the request if it is still pending, its ticket is open and the user is able to
+// respond to it, replying with the reason otherwise. As the request may have been sent in DMs, the returned context is
+// for the ticket's guild and channel.
+func getRespondableTransferRequest(ctx *context.ButtonContext, requestId int) (tables.TransferRequest, database.Ticket, *context.AutoCloseContext, bool) {
+	request, ok, err := dbclient.Tables.TransferRequests.Get(requestId)
+	if err != nil {
+		ctx.HandleError(err)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	if !ok {
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	if request.Status != tables.TransferRequestPending {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferNoLongerPending)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	member, err := ctx.Worker().GetGuildMember(request.GuildId, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	canRespond, err := logic.CanAcceptTransfer(ctx.Worker(), request, member)
+	if err != nil {
+		ctx.HandleError(err)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	if !canRespond {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferNotTarget)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	ticket, err := dbclient.Client.Tickets.Get(request.TicketId, request.GuildId)
+	if err != nil {
+		ctx.HandleError(err)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+		if _, err := dbclient.Tables.TransferRequests.Resolve(request.Id, tables.TransferRequestCancelled, nil); err != nil {
+			ctx.HandleError(err)
+			return tables.TransferRequest{}, database.Ticket{}, nil, false
+		}
+
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferTicketClosed)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(request.GuildId, true, ctx.Worker().Token, ctx.Worker().RateLimiter)
+	if err != nil {
+		ctx.HandleError(err)
+		return tables.TransferRequest{}, database.Ticket{}, nil, false
+	}
+
+	guildCtx := context.NewAutoCloseContext(ctx.Worker(), request.GuildId, *ticket.ChannelId, ctx.UserId(), premiumTier)
+	return request, ticket, &guildCtx, true
+}
+
+// editTransferRequest replaces the buttons of the request with its outcome
+func editTransferRequest(ctx *context.ButtonContext, guildCtx *context.AutoCloseContext, request tables.TransferRequest) {
+	msgEmbed, err := logic.BuildTransferRequestEmbed(guildCtx, request)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Edit(command.MessageResponse{
+		Embeds:     utils.Slice(msgEmbed),
+		Components: []component.Component{},
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"github.com/TicketsBot/worker/bot/button/registry"
+	"github.com/TicketsBot/worker/bot/button/registry/matcher"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type TransferDeclineHandler struct{}
+
+func (h *TransferDeclineHandler) Matcher() matcher.Matcher {
+	return matcher.NewFuncMatcher(func(customId string) bool {
+		return strings.HasPrefix(customId, "transfer_decline_")
+	})
+}
+
+func (h *TransferDeclineHandler) Properties() registry.Properties {
+	return registry.Properties{
+		Flags: registry.SumFlags(registry.GuildAllowed, registry.DMsAllowed, registry.CanEdit),
+	}
+}
+
+var transferDeclinePattern = regexp.MustCompile(`transfer_decline_(\d+)`)
+
+func (h *TransferDeclineHandler) Execute(ctx *context.ButtonContext) {
+	groups := transferDeclinePattern.FindStringSubmatch(ctx.InteractionData.CustomId)
+	if len(groups) < 2 {
+		return
+	}
+
+	// Errors are impossible
+	requestId, _ := strconv.Atoi(groups[1])
+
+	request, ticket, guildCtx, ok := getRespondableTransferRequest(ctx, requestId)
+	if !ok {
+		return
+	}
+
+	userId := ctx.UserId()
+
+	// For team requests, a decline from anyone on the team declines it for the whole team
+	resolved, err := dbclient.Tables.TransferRequests.Resolve(request.Id, tables.TransferRequestDeclined, &userId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !resolved {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferNoLongerPending)
+		return
+	}
+
+	request.Status = tables.TransferRequestDeclined
+	request.ResolvedBy = &userId
+	editTransferRequest(ctx, guildCtx, request)
+
+	if err := logic.SendTransferNotice(guildCtx, ticket, customisation.Red, i18n.TitleTransferRequest, i18n.MessageTransferDeclined, userId, request.RequesterId); err != nil {
+		ctx.HandleWarning(err)
+	}
+}
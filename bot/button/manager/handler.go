@@ -11,14 +11,25 @@ import (
 	cmdregistry "github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/errorcontext"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/interaction"
 	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/sirupsen/logrus"
+	"time"
 )
 
-// Returns whether the handler may edit the message
-func HandleInteraction(manager *ComponentInteractionManager, worker *worker.Context, data interaction.MessageComponentInteraction, responseCh chan button.Response) bool {
+// Returns whether the handler may edit the message. done is called once the handler has finished executing, or
+// straight away if it isn't run.
+func HandleInteraction(manager *ComponentInteractionManager, worker *worker.Context, data interaction.MessageComponentInteraction, responseCh chan button.Response, done func()) bool {
+	started := false
+	defer func() {
+		if !started {
+			done()
+		}
+	}()
+
 	// Safety checks
 	if data.GuildId.Value != 0 && data.Member == nil {
 		return false
@@ -28,6 +39,11 @@ func HandleInteraction(manager *ComponentInteractionManager, worker *worker.Cont
 		return false
 	}
 
+	worker.AddLogFields(logrus.Fields{
+		"interaction_id": data.Id,
+		"component_type": data.Data.Type(),
+	})
+
 	premiumTier, err := getPremiumTier(worker, data.GuildId.Value)
 	if err != nil {
 		sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{
@@ -47,7 +63,10 @@ func HandleInteraction(manager *ComponentInteractionManager, worker *worker.Cont
 		ctx := context.NewButtonContext(worker, data, premiumTier, responseCh)
 		shouldExecute, canEdit := doPropertiesChecks(data.GuildId.Value, ctx, handler.Properties())
 		if shouldExecute {
-			go handler.Execute(ctx)
+			started = true
+			go executeWithMetrics("button", handler, done, func() {
+				handler.Execute(ctx)
+			})
 		}
 
 		return canEdit
@@ -60,7 +79,10 @@ func HandleInteraction(manager *ComponentInteractionManager, worker *worker.Cont
 		ctx := context.NewSelectMenuContext(worker, data, premiumTier, responseCh)
 		shouldExecute, canEdit := doPropertiesChecks(data.GuildId.Value, ctx, handler.Properties())
 		if shouldExecute {
-			go handler.Execute(ctx)
+			started = true
+			go executeWithMetrics("select_menu", handler, done, func() {
+				handler.Execute(ctx)
+			})
 		}
 
 		return canEdit
@@ -73,6 +95,18 @@ func HandleInteraction(manager *ComponentInteractionManager, worker *worker.Cont
 	}
 }
 
+// executeWithMetrics runs the handler, recording how long it took under the handler's type name, and then calls done
+func executeWithMetrics(componentType string, handler interface{}, done func(), f func()) {
+	defer done()
+
+	start := time.Now()
+	f()
+	metrics.Timing(metrics.ComponentDuration, time.Since(start), metrics.Tags{
+		"type":    componentType,
+		"handler": fmt.Sprintf("%T", handler),
+	})
+}
+
 func getPremiumTier(worker *worker.Context, guildId uint64) (premium.PremiumTier, error) {
 	// Psuedo premium if DM command
 	if guildId == 0 {
@@ -51,24 +51,43 @@ func (m *ComponentInteractionManager) RegisterCommands() {
 	m.buttonRegistry = append(m.buttonRegistry,
 		new(handlers.AddAdminHandler),
 		new(handlers.AddSupportHandler),
+		new(handlers.BlacklistHandler),
+		new(handlers.BulkCancelHandler),
+		new(handlers.BulkConfirmHandler),
 		new(handlers.CloseHandler),
 		new(handlers.CloseWithReasonModalHandler),
 		new(handlers.ClaimHandler),
 		new(handlers.CloseConfirmHandler),
 		new(handlers.CloseRequestAcceptHandler),
 		new(handlers.CloseRequestDenyHandler),
+		new(handlers.FormRetryHandler),
+		new(handlers.LeaderboardHandler),
+		new(handlers.OpenTicketsHandler),
+		new(handlers.OpenTicketsClaimHandler),
+		new(handlers.OpenTicketsCloseHandler),
 		new(handlers.PanelHandler),
 		new(handlers.RateHandler),
+		new(handlers.SaveAsTagHandler),
+		new(handlers.TicketSearchHandler),
+		new(handlers.TransferAcceptHandler),
+		new(handlers.TransferDeclineHandler),
 		new(handlers.ViewStaffHandler),
 	)
 
 	m.selectRegistry = append(m.selectRegistry,
 		new(handlers.MultiPanelHandler),
+		new(handlers.ModmailGuildHandler),
+		new(handlers.ModmailPanelHandler),
+		new(handlers.MoveMessagesHandler),
+		new(handlers.OpenTicketsSelectHandler),
 	)
 
 	m.modalRegistry = append(m.modalRegistry,
 		new(handlers.FormHandler),
 		new(handlers.CloseWithReasonSubmitHandler),
+		new(handlers.MoveMessagesRangeSubmitHandler),
+		new(handlers.RateFeedbackSubmitHandler),
+		new(handlers.SaveAsTagSubmitHandler),
 	)
 
 	for _, handler := range m.buttonRegistry {
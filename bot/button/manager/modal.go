@@ -7,9 +7,17 @@ import (
 	"github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/errorcontext"
 	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/sirupsen/logrus"
 )
 
-func HandleModalInteraction(manager *ComponentInteractionManager, worker *worker.Context, data interaction.ModalSubmitInteraction, responseCh chan button.Response) bool {
+func HandleModalInteraction(manager *ComponentInteractionManager, worker *worker.Context, data interaction.ModalSubmitInteraction, responseCh chan button.Response, done func()) bool {
+	started := false
+	defer func() {
+		if !started {
+			done()
+		}
+	}()
+
 	// Safety checks
 	if data.GuildId.Value != 0 && data.Member == nil {
 		return false
@@ -19,6 +27,11 @@ func HandleModalInteraction(manager *ComponentInteractionManager, worker *worker
 		return false
 	}
 
+	worker.AddLogFields(logrus.Fields{
+		"interaction_id": data.Id,
+		"custom_id":      data.Data.CustomId,
+	})
+
 	premiumTier, err := getPremiumTier(worker, data.GuildId.Value)
 	if err != nil {
 		sentry.ErrorWithContext(err, errorcontext.WorkerErrorContext{
@@ -36,7 +49,10 @@ func HandleModalInteraction(manager *ComponentInteractionManager, worker *worker
 	ctx := context.NewModalContext(worker, data, premiumTier, responseCh)
 	shouldExecute, canEdit := doPropertiesChecks(data.GuildId.Value, ctx, handler.Properties())
 	if shouldExecute {
-		go handler.Execute(ctx)
+		started = true
+		go executeWithMetrics("modal", handler, done, func() {
+			handler.Execute(ctx)
+		})
 	}
 
 	return canEdit
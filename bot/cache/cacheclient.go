@@ -3,12 +3,12 @@ package cache
 import (
 	"context"
 	"fmt"
+	"github.com/TicketsBot/worker/bot/logging"
 	"github.com/TicketsBot/worker/config"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/logrusadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/rxdn/gdl/cache"
-	"github.com/sirupsen/logrus"
 )
 
 var Client *cache.PgCache
@@ -29,7 +29,7 @@ func Connect() (client cache.PgCache, err error) {
 
 	// TODO: Sentry
 	cfg.ConnConfig.LogLevel = pgx.LogLevelWarn
-	cfg.ConnConfig.Logger = logrusadapter.NewLogger(logrus.New())
+	cfg.ConnConfig.Logger = logrusadapter.NewLogger(logging.For("bot/cache"))
 	cfg.ConnConfig.PreferSimpleProtocol = true
 
 	pool, err := pgxpool.ConnectConfig(context.Background(), cfg)
@@ -95,6 +95,7 @@ func (ctx *SlashCommandContext) ToErrorContext() errorcontext.WorkerErrorContext
 		Guild:   ctx.GuildId(),
 		User:    ctx.Interaction.Member.User.Id,
 		Channel: ctx.ChannelId(),
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
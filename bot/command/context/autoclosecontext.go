@@ -71,6 +71,7 @@ func (ctx *AutoCloseContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.guildId,
 		User:    ctx.userId,
 		Channel: ctx.channelId,
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
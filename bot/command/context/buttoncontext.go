@@ -88,6 +88,7 @@ func (ctx *ButtonContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.GuildId(),
 		User:    ctx.UserId(),
 		Channel: ctx.ChannelId(),
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
@@ -79,6 +79,7 @@ func (ctx *DashboardContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.guildId,
 		User:    ctx.userId,
 		Channel: ctx.channelId,
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
@@ -77,6 +77,7 @@ func (ctx *MessageContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.GuildId(),
 		User:    ctx.Author.Id,
 		Channel: ctx.ChannelId(),
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
@@ -86,6 +86,7 @@ func (ctx *ModalContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.GuildId(),
 		User:    ctx.UserId(),
 		Channel: ctx.ChannelId(),
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
@@ -81,6 +81,7 @@ func (ctx *PanelContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.guildId,
 		User:    ctx.userId,
 		Channel: ctx.channelId,
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
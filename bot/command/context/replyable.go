@@ -8,7 +8,9 @@ import (
 	"github.com/TicketsBot/worker/bot/command"
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logging"
 	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/permissionwrapper"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
@@ -17,6 +19,7 @@ import (
 	"github.com/rxdn/gdl/objects/interaction/component"
 	"github.com/rxdn/gdl/permission"
 	"github.com/rxdn/gdl/rest/request"
+	"github.com/sirupsen/logrus"
 	"strings"
 )
 
@@ -102,8 +105,26 @@ func (r *Replyable) ReplyPlainPermanent(content string) {
 	_, _ = r.ctx.ReplyWith(command.NewTextMessageResponse(content))
 }
 
+// Logger returns a logger carrying the fields of the request, as well as the guild, channel and user
+func (r *Replyable) Logger() *logrus.Entry {
+	return logging.WithCaller(1, r.logFields())
+}
+
+func (r *Replyable) logFields() logrus.Fields {
+	fields := r.ctx.Worker().LogFields()
+	fields["guild_id"] = r.ctx.GuildId()
+	fields["channel_id"] = r.ctx.ChannelId()
+	fields["user_id"] = r.ctx.UserId()
+
+	return fields
+}
+
 func (r *Replyable) HandleError(err error) {
 	eventId := sentry.ErrorWithContext(err, r.ctx.ToErrorContext())
+	metrics.LogError(err)
+
+	// Attribute the error to the package that is handling it
+	logging.WithCaller(1, r.logFields()).WithError(err).WithField("sentry_event_id", eventId).Error("Error handling request")
 
 	// We should show the invite link if the user is staff (or if we failed to resolve their permission level, show it)
 	permLevel, resolveError := r.ctx.UserPermissionLevel()
@@ -115,6 +136,7 @@ func (r *Replyable) HandleError(err error) {
 
 func (r *Replyable) HandleWarning(err error) {
 	eventId := sentry.LogWithContext(err, r.ctx.ToErrorContext())
+	logging.WithCaller(1, r.logFields()).WithError(err).WithField("sentry_event_id", eventId).Warn("Warning handling request")
 
 	// We should show the invite link if the user is staff (or if we failed to resolve their permission level, show it)
 	permLevel, resolveError := r.ctx.UserPermissionLevel()
@@ -88,6 +88,7 @@ func (ctx *SelectMenuContext) ToErrorContext() errorcontext.WorkerErrorContext {
 		Guild:   ctx.GuildId(),
 		User:    ctx.UserId(),
 		Channel: ctx.ChannelId(),
+		TraceId: ctx.worker.TraceId(),
 	}
 }
 
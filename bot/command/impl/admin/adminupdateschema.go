@@ -30,5 +30,11 @@ func (c AdminUpdateSchemaCommand) GetExecutor() interface{} {
 
 func (AdminUpdateSchemaCommand) Execute(ctx registry.CommandContext) {
 	dbclient.Client.CreateTables(dbclient.Pool)
+
+	if err := dbclient.Tables.CreateTables(dbclient.Pool); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
 	ctx.Accept()
 }
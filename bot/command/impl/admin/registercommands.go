@@ -64,7 +64,7 @@ func (c RegisterCommandsCommand) Execute(ctx registry.CommandContext, global *bo
 			return
 		}
 
-		fmt.Printf("Registered %s\n", properties.Name)
+		ctx.Logger().Infof("Registered %s", properties.Name)
 	}
 
 	ctx.Accept()
@@ -116,8 +116,14 @@ func BuildOption(cmd registry.Command) interaction.ApplicationCommandOption {
 
 	options := append(required, optional...)
 
+	// Subcommands that have subcommands of their own must be registered as groups
+	optionType := interaction.OptionTypeSubCommand
+	if len(properties.Children) > 0 {
+		optionType = interaction.OptionTypeSubCommandGroup
+	}
+
 	return interaction.ApplicationCommandOption{
-		Type:        interaction.OptionTypeSubCommand,
+		Type:        optionType,
 		Name:        properties.Name,
 		Description: i18n.GetMessage(i18n.English, properties.Description),
 		Default:     false,
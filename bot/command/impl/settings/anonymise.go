@@ -0,0 +1,87 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type AnonymiseCommand struct {
+}
+
+func (AnonymiseCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "anonymise",
+		Description:     i18n.HelpAnonymise,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		Aliases:         []string{"anonymize"},
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to change the setting for", interaction.OptionTypeInteger, i18n.MessageAnonymiseInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredArgument("enabled", "Whether staff messages in this panel's tickets are reposted under the team's name", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("name", "The name to post staff messages under. Defaults to the server's name", interaction.OptionTypeString, i18n.MessageAnonymiseInvalidName),
+			command.NewOptionalArgument("avatar", "Link to the avatar to post staff messages with. Defaults to the bot's avatar", interaction.OptionTypeString, i18n.MessageAnonymiseInvalidAvatar),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c AnonymiseCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (AnonymiseCommand) Execute(ctx registry.CommandContext, panelId int, enabled bool, name, avatarUrl *string) {
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify panel is from same guild
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAnonymiseInvalidPanel)
+		ctx.Reject()
+		return
+	}
+
+	// Webhook usernames are limited to 80 characters
+	if name != nil && (len(*name) == 0 || len(*name) > 80) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAnonymiseInvalidName)
+		ctx.Reject()
+		return
+	}
+
+	if avatarUrl != nil && (!strings.HasPrefix(*avatarUrl, "https://") || len(*avatarUrl) > 1024) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageAnonymiseInvalidAvatar)
+		ctx.Reject()
+		return
+	}
+
+	settings := tables.PanelAnonymiseSettings{
+		Enabled:   enabled,
+		TeamName:  name,
+		AvatarUrl: avatarUrl,
+	}
+
+	if err := dbclient.Tables.PanelAnonymise.Set(panel.PanelId, settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if enabled {
+		ctx.Reply(customisation.Green, i18n.TitleAnonymise, i18n.MessageAnonymiseEnabled, panel.Title)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleAnonymise, i18n.MessageAnonymiseDisabled, panel.Title)
+	}
+
+	ctx.Accept()
+}
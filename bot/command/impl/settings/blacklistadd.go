@@ -0,0 +1,211 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+	"time"
+)
+
+type BlacklistAddCommand struct {
+}
+
+// Longer blacklists should be permanent
+const maxBlacklistDuration = time.Hour * 24 * 365
+
+func (BlacklistAddCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "add",
+		Description:     i18n.HelpBlacklistAdd,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("user_or_role", "User or role to blacklist", interaction.OptionTypeMentionable, i18n.MessageBlacklistNoMembers),
+			command.NewOptionalArgument("duration", "How long to blacklist for, e.g. 12h, 7d or 2w. Permanent if not specified", interaction.OptionTypeString, i18n.MessageBlacklistInvalidDuration),
+			command.NewOptionalArgument("reason", "The reason for the blacklist, which is sent to the user", interaction.OptionTypeString, "infallible"),
+		),
+	}
+}
+
+func (c BlacklistAddCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BlacklistAddCommand) Execute(ctx registry.CommandContext, id uint64, durationRaw *string, reason *string) {
+	usageEmbed := embed.EmbedField{
+		Name:   "Usage",
+		Value:  "`/blacklist add @User [duration] [reason]`\n`/blacklist add @Role [duration] [reason]`",
+		Inline: false,
+	}
+
+	var expiresAt *time.Time
+	if durationRaw != nil {
+		duration, err := utils.ParseDuration(*durationRaw)
+		if err != nil || duration <= 0 || duration > maxBlacklistDuration {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistInvalidDuration, utils.ToSlice(usageEmbed))
+			ctx.Reject()
+			return
+		}
+
+		expiresAt = utils.Ptr(time.Now().Add(duration))
+	}
+
+	if reason != nil {
+		reason = utils.Ptr(utils.StringMax(*reason, 512))
+	}
+
+	mentionableType, valid := context.DetermineMentionableType(ctx, id)
+	if !valid {
+		ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistNoMembers, utils.ToSlice(usageEmbed))
+		ctx.Reject()
+		return
+	}
+
+	entry := tables.BlacklistEntry{
+		GuildId:   ctx.GuildId(),
+		TargetId:  id,
+		IsRole:    mentionableType == context.MentionableTypeRole,
+		Reason:    reason,
+		AddedBy:   utils.Ptr(ctx.UserId()),
+		ExpiresAt: expiresAt,
+	}
+
+	if mentionableType == context.MentionableTypeUser {
+		member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if ctx.UserId() == id {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistSelf, utils.ToSlice(usageEmbed))
+			ctx.Reject()
+			return
+		}
+
+		permLevel, err := permission.GetPermissionLevel(utils.ToRetriever(ctx.Worker()), member, ctx.GuildId())
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if permLevel > permission.Everyone {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed))
+			ctx.Reject()
+			return
+		}
+
+		isBlacklisted, err := dbclient.Client.Blacklist.IsBlacklisted(ctx.GuildId(), id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		// If the user is already blacklisted, only the reason and expiry are updated
+		if !isBlacklisted {
+			// Limit of 250 *users*
+			count, err := dbclient.Client.Blacklist.GetBlacklistedCount(ctx.GuildId())
+			if err != nil {
+				ctx.HandleError(err)
+				return
+			}
+
+			if count >= 250 {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistLimit, 250)
+				return
+			}
+
+			if err := dbclient.Client.Blacklist.Add(ctx.GuildId(), member.User.Id); err != nil {
+				ctx.HandleError(err)
+				return
+			}
+		}
+
+		if err := dbclient.Tables.BlacklistEntries.Set(entry); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if expiresAt == nil {
+			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAdd, member.User.Id)
+		} else {
+			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAddTemporary, member.User.Id, message.BuildTimestamp(*expiresAt, message.TimestampStyleShortDateTime))
+		}
+	} else if mentionableType == context.MentionableTypeRole {
+		// Check if role is staff
+		isSupport, err := dbclient.Client.RolePermissions.IsSupport(id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if isSupport {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed))
+			ctx.Reject()
+			return
+		}
+
+		// Check if staff is part of any team
+		isSupport, err = dbclient.Client.SupportTeamRoles.IsSupport(ctx.GuildId(), id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if isSupport {
+			ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistStaff, utils.ToSlice(usageEmbed))
+			ctx.Reject()
+			return
+		}
+
+		isBlacklisted, err := dbclient.Client.RoleBlacklist.IsBlacklisted(ctx.GuildId(), id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !isBlacklisted {
+			// Limit of 50 *roles*
+			count, err := dbclient.Tables.BlacklistEntries.GetBlacklistedRoleCount(ctx.GuildId())
+			if err != nil {
+				ctx.HandleError(err)
+				return
+			}
+
+			if count >= 50 {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistRoleLimit, 50)
+				return
+			}
+
+			if err := dbclient.Client.RoleBlacklist.Add(ctx.GuildId(), id); err != nil {
+				ctx.HandleError(err)
+				return
+			}
+		}
+
+		if err := dbclient.Tables.BlacklistEntries.Set(entry); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if expiresAt == nil {
+			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAddRole, id)
+		} else {
+			ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistAddRoleTemporary, id, message.BuildTimestamp(*expiresAt, message.TimestampStyleShortDateTime))
+		}
+	} else {
+		ctx.HandleError(fmt.Errorf("infallible"))
+		return
+	}
+}
@@ -0,0 +1,62 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/guild/emoji"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
+)
+
+type BlacklistListCommand struct {
+}
+
+func (BlacklistListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "list",
+		Description:     i18n.HelpBlacklistList,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Settings,
+	}
+}
+
+func (c BlacklistListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BlacklistListCommand) Execute(ctx registry.CommandContext) {
+	msgEmbed, isBlank := logic.BuildBlacklistMessage(ctx, 0)
+
+	res := command.MessageResponse{
+		Embeds: []*embed.Embed{msgEmbed},
+		Flags:  message.SumFlags(message.FlagEphemeral),
+		Components: []component.Component{
+			component.BuildActionRow(
+				component.BuildButton(component.Button{
+					CustomId: "disabled",
+					Style:    component.ButtonStylePrimary,
+					Emoji: &emoji.Emoji{
+						Name: "◀️",
+					},
+					Disabled: true,
+				}),
+				component.BuildButton(component.Button{
+					CustomId: "blacklist_1",
+					Style:    component.ButtonStylePrimary,
+					Emoji: &emoji.Emoji{
+						Name: "▶️",
+					},
+					Disabled: isBlank,
+				}),
+			),
+		},
+	}
+
+	_, _ = ctx.ReplyWith(res)
+}
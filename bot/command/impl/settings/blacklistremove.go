@@ -0,0 +1,101 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type BlacklistRemoveCommand struct {
+}
+
+func (BlacklistRemoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "remove",
+		Description:     i18n.HelpBlacklistRemove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("user_or_role", "User or role to unblacklist", interaction.OptionTypeMentionable, i18n.MessageBlacklistNoMembers),
+		),
+	}
+}
+
+func (c BlacklistRemoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BlacklistRemoveCommand) Execute(ctx registry.CommandContext, id uint64) {
+	usageEmbed := embed.EmbedField{
+		Name:   "Usage",
+		Value:  "`/blacklist remove @User`\n`/blacklist remove @Role`",
+		Inline: false,
+	}
+
+	mentionableType, valid := context.DetermineMentionableType(ctx, id)
+	if !valid {
+		ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageBlacklistNoMembers, utils.ToSlice(usageEmbed))
+		ctx.Reject()
+		return
+	}
+
+	if mentionableType == context.MentionableTypeUser {
+		isBlacklisted, err := dbclient.Client.Blacklist.IsBlacklisted(ctx.GuildId(), id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !isBlacklisted {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistNotBlacklisted)
+			return
+		}
+
+		if err := dbclient.Client.Blacklist.Remove(ctx.GuildId(), id); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if err := dbclient.Tables.BlacklistEntries.Delete(ctx.GuildId(), id); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemove, id)
+	} else if mentionableType == context.MentionableTypeRole {
+		isBlacklisted, err := dbclient.Client.RoleBlacklist.IsBlacklisted(ctx.GuildId(), id)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !isBlacklisted {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBlacklistNotBlacklisted)
+			return
+		}
+
+		if err := dbclient.Client.RoleBlacklist.Remove(ctx.GuildId(), id); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if err := dbclient.Tables.BlacklistEntries.Delete(ctx.GuildId(), id); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleBlacklist, i18n.MessageBlacklistRemoveRole, id)
+	} else {
+		ctx.HandleError(fmt.Errorf("infallible"))
+		return
+	}
+}
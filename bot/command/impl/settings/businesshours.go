@@ -0,0 +1,208 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxListedHolidays = 10
+
+var outOfHoursModeNames = map[tables.OutOfHoursMode]string{
+	tables.OutOfHoursModeRefuse: "refuse",
+	tables.OutOfHoursModeAccept: "accept",
+}
+
+var outOfHoursModeDescriptions = map[tables.OutOfHoursMode]string{
+	tables.OutOfHoursModeRefuse: "refused",
+	tables.OutOfHoursModeAccept: "accepted",
+}
+
+type BusinessHoursCommand struct {
+}
+
+func (BusinessHoursCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "businesshours",
+		Description:     i18n.HelpBusinessHours,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			BusinessHoursScheduleCommand{},
+			BusinessHoursHoursCommand{},
+			BusinessHoursHolidayCommand{},
+			BusinessHoursViewCommand{},
+		},
+	}
+}
+
+func (c BusinessHoursCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BusinessHoursCommand) Execute(ctx registry.CommandContext) {
+	msg := "Select a subcommand:\n"
+
+	children := BusinessHoursCommand{}.Properties().Children
+	for _, child := range children {
+		msg += fmt.Sprintf("`/businesshours %s` - %s\n", child.Properties().Name, i18n.GetMessageFromGuild(ctx.GuildId(), child.Properties().Description))
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+
+	ctx.ReplyRaw(customisation.Red, ctx.GetMessage(i18n.Error), msg)
+}
+
+// getBusinessHoursPanel returns false, having replied with an error, if the panel does not belong to the guild
+func getBusinessHoursPanel(ctx registry.CommandContext, panelId int) (panelTitle string, ok bool) {
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return "", false
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidPanel)
+		ctx.Reject()
+		return "", false
+	}
+
+	return panel.Title, true
+}
+
+// replyBusinessHours shows the panel's full schedule, so that the effect of a change can be seen at once
+func replyBusinessHours(ctx registry.CommandContext, panelId int, panelTitle string) {
+	schedule, ok, err := dbclient.Tables.PanelSchedules.Get(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	openingHours, err := dbclient.Tables.PanelOpeningHours.GetByPanel(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	holidays, err := dbclient.Tables.PanelHolidays.GetByPanel(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	status := "Disabled"
+	if ok {
+		status = fmt.Sprintf("Enabled in `%s`, tickets opened out of hours are %s", schedule.Timezone, outOfHoursModeDescriptions[schedule.Mode])
+	}
+
+	days := make(map[time.Weekday][]string)
+	for _, hours := range openingHours {
+		days[hours.Weekday] = append(days[hours.Weekday], fmt.Sprintf("%s - %s", formatClockTime(hours.OpenMinute), formatClockTime(hours.CloseMinute)))
+	}
+
+	// Weeks start on Monday
+	var lines []string
+	for i := 1; i <= 7; i++ {
+		weekday := time.Weekday(i % 7)
+
+		hours := "Closed"
+		if len(days[weekday]) > 0 {
+			hours = strings.Join(days[weekday], ", ")
+		}
+
+		lines = append(lines, fmt.Sprintf("**%s:** %s", weekday.String(), hours))
+	}
+
+	// Holidays are stored as dates, so include today's by comparing against yesterday
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	var upcoming []string
+	for _, date := range holidays {
+		if date.After(yesterday) && len(upcoming) < maxListedHolidays {
+			upcoming = append(upcoming, fmt.Sprintf("`%s`", date.Format("2006-01-02")))
+		}
+	}
+
+	holidayList := "None"
+	if len(upcoming) > 0 {
+		holidayList = strings.Join(upcoming, ", ")
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleBusinessHours, i18n.MessageBusinessHoursView, panelTitle, status, strings.Join(lines, "\n"), holidayList)
+}
+
+// parseClockTime parses a HH:MM time into minutes since midnight. 24:00 is accepted, to mean the end of the day.
+func parseClockTime(s string) (int, bool) {
+	split := strings.Split(strings.TrimSpace(s), ":")
+	if len(split) != 2 {
+		return 0, false
+	}
+
+	hours, err := strconv.Atoi(split[0])
+	if err != nil {
+		return 0, false
+	}
+
+	minutes, err := strconv.Atoi(split[1])
+	if err != nil {
+		return 0, false
+	}
+
+	if hours < 0 || minutes < 0 || minutes >= 60 || hours*60+minutes > 1440 {
+		return 0, false
+	}
+
+	return hours*60 + minutes, true
+}
+
+func formatClockTime(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for i := 0; i < 7; i++ {
+		if strings.EqualFold(time.Weekday(i).String(), strings.TrimSpace(name)) {
+			return time.Weekday(i), true
+		}
+	}
+
+	return 0, false
+}
+
+func weekdayAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for i := 1; i <= 7; i++ {
+		name := time.Weekday(i % 7).String()
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  name,
+				Value: strings.ToLower(name),
+			})
+		}
+	}
+
+	return
+}
+
+func outOfHoursModeAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, mode := range []tables.OutOfHoursMode{tables.OutOfHoursModeRefuse, tables.OutOfHoursModeAccept} {
+		name := outOfHoursModeNames[mode]
+		if strings.HasPrefix(name, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  name,
+				Value: name,
+			})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,66 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+	"time"
+)
+
+type BusinessHoursHolidayCommand struct {
+}
+
+func (BusinessHoursHolidayCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "holiday",
+		Description:     i18n.HelpBusinessHoursHoliday,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to add the holiday to", interaction.OptionTypeInteger, i18n.MessageBusinessHoursInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredArgument("date", "The date that the panel is closed, as YYYY-MM-DD", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidDate),
+			command.NewOptionalArgument("remove", "Remove the holiday instead of adding it", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c BusinessHoursHolidayCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BusinessHoursHolidayCommand) Execute(ctx registry.CommandContext, panelId int, dateStr string, remove *bool) {
+	panelTitle, ok := getBusinessHoursPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(dateStr))
+	if err != nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidDate)
+		ctx.Reject()
+		return
+	}
+
+	if remove != nil && *remove {
+		err = dbclient.Tables.PanelHolidays.Delete(panelId, date)
+	} else {
+		err = dbclient.Tables.PanelHolidays.Add(panelId, date)
+	}
+
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	replyBusinessHours(ctx, panelId, panelTitle)
+	ctx.Accept()
+}
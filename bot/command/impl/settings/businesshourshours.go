@@ -0,0 +1,105 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type BusinessHoursHoursCommand struct {
+}
+
+func (BusinessHoursHoursCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "hours",
+		Description:     i18n.HelpBusinessHoursHours,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to set the opening hours of", interaction.OptionTypeInteger, i18n.MessageBusinessHoursInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredAutocompleteableArgument("day", "The day of the week that the hours start on", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidDay, weekdayAutoCompleteHandler),
+			command.NewRequiredArgument("open", "The time that the panel opens, as HH:MM, or \"closed\" to close the panel all day", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidTime),
+			command.NewOptionalArgument("close", "The time that the panel closes, as HH:MM. An earlier time than open closes the next day", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidTime),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c BusinessHoursHoursCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BusinessHoursHoursCommand) Execute(ctx registry.CommandContext, panelId int, dayName, open string, closeTime *string) {
+	panelTitle, ok := getBusinessHoursPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	weekday, ok := parseWeekday(dayName)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidDay)
+		ctx.Reject()
+		return
+	}
+
+	if strings.EqualFold(strings.TrimSpace(open), "closed") {
+		if err := dbclient.Tables.PanelOpeningHours.DeleteDay(panelId, weekday); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		replyBusinessHours(ctx, panelId, panelTitle)
+		ctx.Accept()
+		return
+	}
+
+	if closeTime == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidTime)
+		ctx.Reject()
+		return
+	}
+
+	openMinute, ok := parseClockTime(open)
+	if !ok || openMinute == 1440 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidTime)
+		ctx.Reject()
+		return
+	}
+
+	closeMinute, ok := parseClockTime(*closeTime)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidTime)
+		ctx.Reject()
+		return
+	}
+
+	if closeMinute == openMinute {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursSameTime)
+		ctx.Reject()
+		return
+	}
+
+	hours := tables.OpeningHours{
+		PanelId:     panelId,
+		Weekday:     weekday,
+		OpenMinute:  openMinute,
+		CloseMinute: closeMinute,
+	}
+
+	if err := dbclient.Tables.PanelOpeningHours.SetDay(hours); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	replyBusinessHours(ctx, panelId, panelTitle)
+	ctx.Accept()
+}
@@ -0,0 +1,116 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+	"time"
+)
+
+const maxTimezoneLength = 64
+
+type BusinessHoursScheduleCommand struct {
+}
+
+func (BusinessHoursScheduleCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "schedule",
+		Description:     i18n.HelpBusinessHoursSchedule,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to configure the business hours of", interaction.OptionTypeInteger, i18n.MessageBusinessHoursInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("timezone", "The timezone that the opening hours are in, e.g. Europe/London", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidTimezone),
+			command.NewOptionalAutocompleteableArgument("mode", "Whether tickets can be opened out of hours: refuse or accept", interaction.OptionTypeString, i18n.MessageBusinessHoursInvalidMode, outOfHoursModeAutoCompleteHandler),
+			command.NewOptionalArgument("disable", "Turn business hours off for the panel, keeping its opening hours", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c BusinessHoursScheduleCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BusinessHoursScheduleCommand) Execute(ctx registry.CommandContext, panelId int, timezone, modeName *string, disable *bool) {
+	panelTitle, ok := getBusinessHoursPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	if disable != nil && *disable {
+		if err := dbclient.Tables.PanelSchedules.Delete(panelId); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		replyBusinessHours(ctx, panelId, panelTitle)
+		ctx.Accept()
+		return
+	}
+
+	schedule, ok, err := dbclient.Tables.PanelSchedules.Get(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		schedule = tables.PanelSchedule{
+			PanelId:  panelId,
+			Timezone: "UTC",
+			Mode:     tables.OutOfHoursModeRefuse,
+		}
+	}
+
+	if timezone != nil {
+		name := strings.TrimSpace(*timezone)
+
+		// LoadLocation treats "" as UTC and "Local" as the worker's own timezone, neither of which should be stored
+		if _, err := time.LoadLocation(name); err != nil || name == "" || name == "Local" || len(name) > maxTimezoneLength {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidTimezone)
+			ctx.Reject()
+			return
+		}
+
+		schedule.Timezone = name
+	}
+
+	if modeName != nil {
+		mode, ok := parseOutOfHoursMode(*modeName)
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBusinessHoursInvalidMode)
+			ctx.Reject()
+			return
+		}
+
+		schedule.Mode = mode
+	}
+
+	if err := dbclient.Tables.PanelSchedules.Set(schedule); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	replyBusinessHours(ctx, panelId, panelTitle)
+	ctx.Accept()
+}
+
+func parseOutOfHoursMode(name string) (tables.OutOfHoursMode, bool) {
+	for mode, modeName := range outOfHoursModeNames {
+		if strings.EqualFold(modeName, strings.TrimSpace(name)) {
+			return mode, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type BusinessHoursViewCommand struct {
+}
+
+func (BusinessHoursViewCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "view",
+		Description:     i18n.HelpBusinessHoursView,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to view the business hours of", interaction.OptionTypeInteger, i18n.MessageBusinessHoursInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c BusinessHoursViewCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (BusinessHoursViewCommand) Execute(ctx registry.CommandContext, panelId int) {
+	panelTitle, ok := getBusinessHoursPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	replyBusinessHours(ctx, panelId, panelTitle)
+}
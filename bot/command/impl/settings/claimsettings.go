@@ -0,0 +1,95 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+const (
+	maxClaimLimit        = 100
+	maxClaimTimeoutHours = 24 * 30
+)
+
+type ClaimSettingsCommand struct {
+}
+
+func (ClaimSettingsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "claimsettings",
+		Description:     i18n.HelpClaimSettings,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("max_claims", "How many open tickets each staff member may claim at once. 0 removes the limit", interaction.OptionTypeInteger, i18n.MessageClaimSettingsInvalidLimit),
+			command.NewOptionalArgument("timeout_hours", "Unclaim tickets whose claimer hasn't replied within this many hours. 0 disables the timeout", interaction.OptionTypeInteger, i18n.MessageClaimSettingsInvalidHours),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ClaimSettingsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ClaimSettingsCommand) Execute(ctx registry.CommandContext, maxClaims, timeoutHours *int) {
+	settings, err := dbclient.Tables.ClaimLimits.Get(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if maxClaims != nil {
+		if *maxClaims < 0 || *maxClaims > maxClaimLimit {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimSettingsInvalidLimit, maxClaimLimit)
+			ctx.Reject()
+			return
+		}
+
+		if *maxClaims == 0 {
+			settings.MaxConcurrentClaims = nil
+		} else {
+			settings.MaxConcurrentClaims = maxClaims
+		}
+	}
+
+	if timeoutHours != nil {
+		if *timeoutHours < 0 || *timeoutHours > maxClaimTimeoutHours {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimSettingsInvalidHours, maxClaimTimeoutHours)
+			ctx.Reject()
+			return
+		}
+
+		if *timeoutHours == 0 {
+			settings.TimeoutHours = nil
+		} else {
+			settings.TimeoutHours = timeoutHours
+		}
+	}
+
+	if maxClaims != nil || timeoutHours != nil {
+		if err := dbclient.Tables.ClaimLimits.Set(ctx.GuildId(), settings); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	limit := "None"
+	if settings.MaxConcurrentClaims != nil {
+		limit = fmt.Sprintf("%d tickets", *settings.MaxConcurrentClaims)
+	}
+
+	timeout := "None"
+	if settings.TimeoutHours != nil {
+		timeout = fmt.Sprintf("%d hours", *settings.TimeoutHours)
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleClaimSettings, i18n.MessageClaimSettingsUpdated, limit, timeout)
+	ctx.Accept()
+}
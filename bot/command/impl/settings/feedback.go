@@ -0,0 +1,48 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type FeedbackCommand struct {
+}
+
+func (FeedbackCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "feedback",
+		Description:     i18n.HelpFeedback,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			FeedbackAddQuestionCommand{},
+			FeedbackRemoveQuestionCommand{},
+			FeedbackChannelCommand{},
+			FeedbackAlertsCommand{},
+		},
+	}
+}
+
+func (c FeedbackCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (FeedbackCommand) Execute(ctx registry.CommandContext) {
+	msg := "Select a subcommand:\n"
+
+	children := FeedbackCommand{}.Properties().Children
+	for _, child := range children {
+		msg += fmt.Sprintf("`/feedback %s` - %s\n", child.Properties().Name, i18n.GetMessageFromGuild(ctx.GuildId(), child.Properties().Description))
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+
+	ctx.ReplyRaw(customisation.Red, ctx.GetMessage(i18n.Error), msg)
+}
@@ -0,0 +1,91 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+	"unicode/utf8"
+)
+
+type FeedbackAddQuestionCommand struct {
+}
+
+var ratingQuestionTypes = map[string]tables.RatingQuestionType{
+	"text":  tables.RatingQuestionTypeText,
+	"yesno": tables.RatingQuestionTypeYesNo,
+}
+
+func (c FeedbackAddQuestionCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "addquestion",
+		Description:     i18n.HelpFeedbackAddQuestion,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("question", "The question to ask after a user rates their ticket", interaction.OptionTypeString, i18n.MessageFeedbackQuestionInvalidLabel),
+			command.NewRequiredAutocompleteableArgument("type", "The type of answer: text or yesno", interaction.OptionTypeString, i18n.MessageFeedbackQuestionInvalidType, c.AutoCompleteHandler),
+			command.NewOptionalArgument("required", "Whether the user must answer the question", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c FeedbackAddQuestionCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (FeedbackAddQuestionCommand) Execute(ctx registry.CommandContext, label, typeRaw string, required *bool) {
+	// Discord limits text input labels to 45 characters
+	if len(strings.TrimSpace(label)) == 0 || utf8.RuneCountInString(label) > 45 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackQuestionInvalidLabel)
+		ctx.Reject()
+		return
+	}
+
+	questionType, ok := ratingQuestionTypes[strings.ToLower(typeRaw)]
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackQuestionInvalidType)
+		ctx.Reject()
+		return
+	}
+
+	questions, err := dbclient.Tables.RatingQuestions.GetByGuild(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(questions) >= tables.MaxRatingQuestions {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackQuestionLimit, tables.MaxRatingQuestions)
+		ctx.Reject()
+		return
+	}
+
+	if _, err := dbclient.Tables.RatingQuestions.Create(ctx.GuildId(), label, questionType, required != nil && *required); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackQuestionAdded, label)
+	ctx.Accept()
+}
+
+func (FeedbackAddQuestionCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, option := range []string{"text", "yesno"} {
+		if strings.HasPrefix(option, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  option,
+				Value: option,
+			})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type FeedbackAlertsCommand struct {
+}
+
+func (FeedbackAlertsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "alerts",
+		Description:     i18n.HelpFeedbackAlerts,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("threshold", "Ratings at or below this number of stars trigger an alert. 0 disables alerts", interaction.OptionTypeInteger, i18n.MessageFeedbackAlertsInvalidThreshold),
+			command.NewOptionalArgument("role", "The role to mention in the feedback channel. If not specified, the ticket's claimer is sent a DM", interaction.OptionTypeRole, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c FeedbackAlertsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (FeedbackAlertsCommand) Execute(ctx registry.CommandContext, threshold int, roleId *uint64) {
+	if threshold < 0 || threshold > 5 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackAlertsInvalidThreshold)
+		ctx.Reject()
+		return
+	}
+
+	if threshold == 0 {
+		roleId = nil
+	}
+
+	if err := dbclient.Tables.RatingFeedback.SetAlerts(ctx.GuildId(), uint8(threshold), roleId); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if threshold == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackAlertsDisabled)
+	} else if roleId == nil {
+		ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackAlertsClaimer, threshold)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackAlertsRole, threshold, *roleId)
+	}
+
+	ctx.Accept()
+}
@@ -0,0 +1,68 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/rest/request"
+)
+
+type FeedbackChannelCommand struct {
+}
+
+func (FeedbackChannelCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "channel",
+		Description:     i18n.HelpFeedbackChannel,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("channel", "The channel to post feedback in. If not specified, feedback is no longer posted", interaction.OptionTypeChannel, i18n.MessageFeedbackInvalidChannel),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c FeedbackChannelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (FeedbackChannelCommand) Execute(ctx registry.CommandContext, channelId *uint64) {
+	if channelId != nil {
+		ch, err := ctx.Worker().GetChannel(*channelId)
+		if err != nil {
+			if restError, ok := err.(request.RestError); ok && restError.IsClientError() {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackInvalidChannel)
+				ctx.Reject()
+			} else {
+				ctx.HandleError(err)
+			}
+
+			return
+		}
+
+		if ch.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackInvalidChannel)
+			ctx.Reject()
+			return
+		}
+	}
+
+	if err := dbclient.Tables.RatingFeedback.SetChannel(ctx.GuildId(), channelId); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if channelId == nil {
+		ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackChannelDisabled)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackChannelSet, *channelId)
+	}
+
+	ctx.Accept()
+}
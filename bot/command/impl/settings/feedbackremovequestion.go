@@ -0,0 +1,81 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type FeedbackRemoveQuestionCommand struct {
+}
+
+func (c FeedbackRemoveQuestionCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "removequestion",
+		Description:     i18n.HelpFeedbackRemoveQuestion,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("question", "The question to remove", interaction.OptionTypeInteger, i18n.MessageFeedbackQuestionNotFound, c.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c FeedbackRemoveQuestionCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (FeedbackRemoveQuestionCommand) Execute(ctx registry.CommandContext, questionId int) {
+	question, ok, err := dbclient.Tables.RatingQuestions.Get(questionId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok || question.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageFeedbackQuestionNotFound)
+		ctx.Reject()
+		return
+	}
+
+	// Answers are deleted by the foreign key
+	if err := dbclient.Tables.RatingQuestions.Delete(ctx.GuildId(), questionId); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleRatingFeedback, i18n.MessageFeedbackQuestionRemoved, question.Label)
+	ctx.Accept()
+}
+
+func (FeedbackRemoveQuestionCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	questions, err := dbclient.Tables.RatingQuestions.GetByGuild(data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err) // TODO: Context
+		return nil
+	}
+
+	for _, question := range questions {
+		if strings.Contains(strings.ToLower(question.Label), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  question.Label,
+				Value: question.Id,
+			})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,66 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ModmailCommand struct {
+}
+
+func (ModmailCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "modmail",
+		Description:     i18n.HelpModmail,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("enabled", "Whether users may open tickets by sending the bot a DM", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("anonymous", "Whether staff replies are sent under the server's name instead of the staff member's", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ModmailCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ModmailCommand) Execute(ctx registry.CommandContext, enabled bool, anonymous *bool) {
+	settings, err := dbclient.Tables.ModmailSettings.Get(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	settings = tables.ModmailSettings{
+		Enabled:        enabled,
+		AnonymiseStaff: settings.AnonymiseStaff,
+	}
+
+	if anonymous != nil {
+		settings.AnonymiseStaff = *anonymous
+	}
+
+	if err := dbclient.Tables.ModmailSettings.Set(ctx.GuildId(), settings); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !settings.Enabled {
+		ctx.Reply(customisation.Green, i18n.TitleModmail, i18n.MessageModmailDisabled)
+	} else if settings.AnonymiseStaff {
+		ctx.Reply(customisation.Green, i18n.TitleModmail, i18n.MessageModmailEnabledAnonymous)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleModmail, i18n.MessageModmailEnabled)
+	}
+
+	ctx.Accept()
+}
@@ -0,0 +1,56 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+const maxOpenCooldownMinutes = 60 * 24 * 7
+
+type OpenLimitsCommand struct {
+}
+
+func (OpenLimitsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "openlimits",
+		Description:     i18n.HelpOpenLimits,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			OpenLimitsGuildCommand{},
+			OpenLimitsPanelCommand{},
+		},
+	}
+}
+
+func (c OpenLimitsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OpenLimitsCommand) Execute(ctx registry.CommandContext) {
+	msg := "Select a subcommand:\n"
+
+	children := OpenLimitsCommand{}.Properties().Children
+	for _, child := range children {
+		msg += fmt.Sprintf("`/openlimits %s` - %s\n", child.Properties().Name, i18n.GetMessageFromGuild(ctx.GuildId(), child.Properties().Description))
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+
+	ctx.ReplyRaw(customisation.Red, ctx.GetMessage(i18n.Error), msg)
+}
+
+func formatCooldown(minutes int) string {
+	if minutes == 0 {
+		return "None"
+	}
+
+	return fmt.Sprintf("%d minutes", minutes)
+}
@@ -0,0 +1,92 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"time"
+)
+
+const (
+	maxGuildOpenLimit           = 100
+	maxGuildOpenIntervalSeconds = 60 * 60
+)
+
+type OpenLimitsGuildCommand struct {
+}
+
+func (OpenLimitsGuildCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "guild",
+		Description:     i18n.HelpOpenLimitsGuild,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("max_tickets", "How many tickets may be opened in the server, by anyone, within the interval", interaction.OptionTypeInteger, i18n.MessageOpenLimitsInvalidLimit),
+			command.NewOptionalArgument("interval_seconds", "The interval that max_tickets applies to, in seconds", interaction.OptionTypeInteger, i18n.MessageOpenLimitsInvalidInterval),
+			command.NewOptionalArgument("user_cooldown_minutes", "How long each user must wait between opening tickets. 0 removes the cooldown", interaction.OptionTypeInteger, i18n.MessageOpenLimitsInvalidCooldown),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c OpenLimitsGuildCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OpenLimitsGuildCommand) Execute(ctx registry.CommandContext, maxTickets, intervalSeconds, userCooldownMinutes *int) {
+	limits, err := dbclient.Tables.TicketOpenLimits.Get(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if maxTickets != nil {
+		if *maxTickets < 1 || *maxTickets > maxGuildOpenLimit {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenLimitsInvalidLimit, maxGuildOpenLimit)
+			ctx.Reject()
+			return
+		}
+
+		limits.GuildLimit = *maxTickets
+	}
+
+	if intervalSeconds != nil {
+		if *intervalSeconds < 1 || *intervalSeconds > maxGuildOpenIntervalSeconds {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenLimitsInvalidInterval, maxGuildOpenIntervalSeconds)
+			ctx.Reject()
+			return
+		}
+
+		limits.GuildInterval = time.Duration(*intervalSeconds) * time.Second
+	}
+
+	if userCooldownMinutes != nil {
+		if *userCooldownMinutes < 0 || *userCooldownMinutes > maxOpenCooldownMinutes {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenLimitsInvalidCooldown, maxOpenCooldownMinutes)
+			ctx.Reject()
+			return
+		}
+
+		limits.UserCooldown = time.Duration(*userCooldownMinutes) * time.Minute
+	}
+
+	if maxTickets != nil || intervalSeconds != nil || userCooldownMinutes != nil {
+		if err := dbclient.Tables.TicketOpenLimits.Set(ctx.GuildId(), limits); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleOpenLimits, i18n.MessageOpenLimitsGuild,
+		limits.GuildLimit,
+		int(limits.GuildInterval.Seconds()),
+		formatCooldown(int(limits.UserCooldown.Minutes())),
+	)
+	ctx.Accept()
+}
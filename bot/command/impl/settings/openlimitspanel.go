@@ -0,0 +1,78 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"time"
+)
+
+type OpenLimitsPanelCommand struct {
+}
+
+func (OpenLimitsPanelCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "panel",
+		Description:     i18n.HelpOpenLimitsPanel,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to configure the cooldown of", interaction.OptionTypeInteger, i18n.MessageOpenLimitsInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("cooldown_minutes", "How long each user must wait between opening tickets from the panel. 0 removes the cooldown", interaction.OptionTypeInteger, i18n.MessageOpenLimitsInvalidCooldown),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c OpenLimitsPanelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (OpenLimitsPanelCommand) Execute(ctx registry.CommandContext, panelId int, cooldownMinutes *int) {
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenLimitsInvalidPanel)
+		ctx.Reject()
+		return
+	}
+
+	if cooldownMinutes != nil {
+		if *cooldownMinutes < 0 || *cooldownMinutes > maxOpenCooldownMinutes {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenLimitsInvalidCooldown, maxOpenCooldownMinutes)
+			ctx.Reject()
+			return
+		}
+
+		if *cooldownMinutes == 0 {
+			err = dbclient.Tables.PanelCooldowns.Delete(panelId)
+		} else {
+			err = dbclient.Tables.PanelCooldowns.Set(panelId, time.Duration(*cooldownMinutes)*time.Minute)
+		}
+
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	}
+
+	cooldown, err := dbclient.Tables.PanelCooldowns.Get(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleOpenLimits, i18n.MessageOpenLimitsPanel, panel.Title, formatCooldown(int(cooldown.Minutes())))
+	ctx.Accept()
+}
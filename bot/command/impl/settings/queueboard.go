@@ -0,0 +1,46 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type QueueBoardCommand struct {
+}
+
+func (QueueBoardCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "queueboard",
+		Description:     i18n.HelpQueueBoard,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support, // Subcommands have their own permission levels
+		Category:        command.Settings,
+		Children: []registry.Command{
+			QueueBoardSetupCommand{},
+			QueueBoardShiftCommand{},
+		},
+	}
+}
+
+func (c QueueBoardCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (QueueBoardCommand) Execute(ctx registry.CommandContext) {
+	msg := "Select a subcommand:\n"
+
+	children := QueueBoardCommand{}.Properties().Children
+	for _, child := range children {
+		msg += fmt.Sprintf("`/queueboard %s` - %s\n", child.Properties().Name, i18n.GetMessageFromGuild(ctx.GuildId(), child.Properties().Description))
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+
+	ctx.ReplyRaw(customisation.Red, ctx.GetMessage(i18n.Error), msg)
+}
@@ -0,0 +1,95 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/rest/request"
+)
+
+type QueueBoardSetupCommand struct {
+}
+
+func (QueueBoardSetupCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "setup",
+		Description:     i18n.HelpQueueBoardSetup,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("channel", "The channel to post the queue board in. Defaults to this channel", interaction.OptionTypeChannel, i18n.MessageQueueBoardInvalidChannel),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c QueueBoardSetupCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (QueueBoardSetupCommand) Execute(ctx registry.CommandContext, channelId *uint64) {
+	if channelId == nil {
+		tmp := ctx.ChannelId()
+		channelId = &tmp
+	}
+
+	ch, err := ctx.Worker().GetChannel(*channelId)
+	if err != nil {
+		if restError, ok := err.(request.RestError); ok && restError.IsClientError() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageQueueBoardInvalidChannel)
+			ctx.Reject()
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	if ch.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageQueueBoardInvalidChannel)
+		ctx.Reject()
+		return
+	}
+
+	msgEmbed, err := logic.BuildQueueBoardEmbed(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	msg, err := ctx.Worker().CreateMessageEmbed(ch.Id, msgEmbed)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	previous, hasPrevious, err := dbclient.Tables.QueueBoards.Get(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := dbclient.Tables.QueueBoards.Set(tables.QueueBoard{
+		GuildId:   ctx.GuildId(),
+		ChannelId: ch.Id,
+		MessageId: msg.Id,
+	}); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// A guild only has one board, so the old message would no longer be updated. It may already have been deleted.
+	if hasPrevious {
+		_ = ctx.Worker().DeleteMessage(previous.ChannelId, previous.MessageId)
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleQueueBoard, i18n.MessageQueueBoardCreated, ch.Id)
+	ctx.Accept()
+}
@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type QueueBoardShiftCommand struct {
+}
+
+func (QueueBoardShiftCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "shift",
+		Description:      i18n.HelpQueueBoardShift,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Settings,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c QueueBoardShiftCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+// Execute toggles whether the user is shown as on shift on the queue board
+func (QueueBoardShiftCommand) Execute(ctx registry.CommandContext) {
+	ended, err := dbclient.Tables.StaffShifts.End(ctx.GuildId(), ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ended {
+		ctx.Reply(customisation.Green, i18n.TitleQueueBoard, i18n.MessageQueueBoardShiftEnded)
+	} else {
+		if _, err := dbclient.Tables.StaffShifts.Start(ctx.GuildId(), ctx.UserId()); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleQueueBoard, i18n.MessageQueueBoardShiftStarted)
+	}
+
+	logic.RequestQueueBoardUpdate(ctx)
+	ctx.Accept()
+}
@@ -0,0 +1,65 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type RoutingCommand struct {
+}
+
+func (RoutingCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "routing",
+		Description:     i18n.HelpRouting,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		Children: []registry.Command{
+			RoutingAddCommand{},
+			RoutingListCommand{},
+			RoutingRemoveCommand{},
+		},
+	}
+}
+
+func (c RoutingCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (RoutingCommand) Execute(ctx registry.CommandContext) {
+	msg := "Select a subcommand:\n"
+
+	children := RoutingCommand{}.Properties().Children
+	for _, child := range children {
+		msg += fmt.Sprintf("`/routing %s` - %s\n", child.Properties().Name, i18n.GetMessageFromGuild(ctx.GuildId(), child.Properties().Description))
+	}
+
+	msg = strings.TrimSuffix(msg, "\n")
+
+	ctx.ReplyRaw(customisation.Red, ctx.GetMessage(i18n.Error), msg)
+}
+
+// getRoutingPanel returns false, having replied with an error, if the panel does not belong to the guild
+func getRoutingPanel(ctx registry.CommandContext, panelId int) (panelTitle string, formId *int, ok bool) {
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return "", nil, false
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidPanel)
+		ctx.Reject()
+		return "", nil, false
+	}
+
+	return panel.Title, panel.FormId, true
+}
@@ -0,0 +1,264 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/interaction"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxRoutingRules        = 25
+	maxRoutingValueLength  = 255
+	maxRoutingSchemeLength = 100
+)
+
+type RoutingAddCommand struct {
+}
+
+func (RoutingAddCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "add",
+		Description:     i18n.HelpRoutingAdd,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel whose tickets the rule applies to", interaction.OptionTypeInteger, i18n.MessageRoutingInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredAutocompleteableArgument("operator", "How to compare the answer: always, equals, contains or matches (a regular expression)", interaction.OptionTypeString, i18n.MessageRoutingInvalidOperator, routingOperatorAutoCompleteHandler),
+			command.NewOptionalArgument("question", "The label of the form question whose answer is checked", interaction.OptionTypeString, i18n.MessageRoutingInvalidQuestion),
+			command.NewOptionalArgument("value", "The value to compare the answer to", interaction.OptionTypeString, i18n.MessageRoutingMissingCondition),
+			command.NewOptionalArgument("category", "Category to open matching tickets in", interaction.OptionTypeChannel, i18n.MessageRoutingInvalidCategory),
+			command.NewOptionalAutocompleteableArgument("team", "Support team to give matching tickets to, instead of the panel's teams", interaction.OptionTypeInteger, i18n.MessageRoutingInvalidTeam, tickets.SupportTeamAutoCompleteHandler),
+			command.NewOptionalArgument("naming_scheme", "Naming scheme for matching tickets' channels", interaction.OptionTypeString, i18n.MessageRoutingInvalidNamingScheme),
+			command.NewOptionalAutocompleteableArgument("priority", "Priority to give matching tickets: low, normal, high or urgent", interaction.OptionTypeString, i18n.MessageRoutingInvalidPriority, routingPriorityAutoCompleteHandler),
+			command.NewOptionalArgument("position", "Where to evaluate the rule, starting at 1. Rules are added to the end by default", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c RoutingAddCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (RoutingAddCommand) Execute(ctx registry.CommandContext, panelId int, operatorName string, question, value *string, categoryId *uint64, teamId *int, namingScheme, priorityName *string, position *int) {
+	panelTitle, formId, ok := getRoutingPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	operator, ok := parseRoutingOperator(operatorName)
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidOperator)
+		ctx.Reject()
+		return
+	}
+
+	rule := tables.PanelRoutingRule{
+		PanelId:  panelId,
+		Operator: operator,
+	}
+
+	if operator != tables.RoutingOperatorAlways {
+		if question == nil || value == nil || strings.TrimSpace(*value) == "" {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingMissingCondition)
+			ctx.Reject()
+			return
+		}
+
+		if len(*value) > maxRoutingValueLength {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingValueTooLong, maxRoutingValueLength)
+			ctx.Reject()
+			return
+		}
+
+		if operator == tables.RoutingOperatorMatches {
+			if _, err := regexp.Compile(*value); err != nil {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidPattern)
+				ctx.Reject()
+				return
+			}
+		}
+
+		inputId, ok, err := findFormInput(formId, *question)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidQuestion, *question, panelTitle)
+			ctx.Reject()
+			return
+		}
+
+		rule.FormInputId = &inputId
+		rule.Value = *value
+	}
+
+	if categoryId != nil {
+		category, err := ctx.Worker().GetChannel(*categoryId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if category.Type != channel.ChannelTypeGuildCategory || category.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidCategory)
+			ctx.Reject()
+			return
+		}
+
+		rule.TargetCategory = categoryId
+	}
+
+	if teamId != nil {
+		_, ok, err := dbclient.Client.SupportTeam.GetById(ctx.GuildId(), *teamId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidTeam)
+			ctx.Reject()
+			return
+		}
+
+		rule.SupportTeamId = teamId
+	}
+
+	if namingScheme != nil {
+		if len(*namingScheme) == 0 || len(*namingScheme) > maxRoutingSchemeLength {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidNamingScheme, maxRoutingSchemeLength)
+			ctx.Reject()
+			return
+		}
+
+		rule.NamingScheme = namingScheme
+	}
+
+	if priorityName != nil {
+		priority, ok := parseTicketPriority(*priorityName)
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidPriority)
+			ctx.Reject()
+			return
+		}
+
+		rule.Priority = &priority
+	}
+
+	if rule.TargetCategory == nil && rule.SupportTeamId == nil && rule.NamingScheme == nil && rule.Priority == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingNoOutcome)
+		ctx.Reject()
+		return
+	}
+
+	rules, err := dbclient.Tables.PanelRoutingRules.GetByPanel(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(rules) >= maxRoutingRules {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingTooManyRules, maxRoutingRules)
+		ctx.Reject()
+		return
+	}
+
+	// Rules are added to the end, unless the user chose to place it before an existing rule
+	if len(rules) > 0 {
+		rule.Position = rules[len(rules)-1].Position + 1
+	}
+
+	if position != nil && *position >= 1 && *position <= len(rules) {
+		rule.Position = rules[*position-1].Position
+	}
+
+	if _, err := dbclient.Tables.PanelRoutingRules.Create(rule); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleRouting, i18n.MessageRoutingAdded, panelTitle)
+	ctx.Accept()
+}
+
+func findFormInput(formId *int, label string) (int, bool, error) {
+	if formId == nil {
+		return 0, false, nil
+	}
+
+	inputs, err := dbclient.Client.FormInput.GetInputs(*formId)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, input := range inputs {
+		if strings.EqualFold(strings.TrimSpace(input.Label), strings.TrimSpace(label)) {
+			return input.Id, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+func parseRoutingOperator(name string) (tables.RoutingOperator, bool) {
+	for operator, operatorName := range tables.RoutingOperatorNames {
+		if strings.EqualFold(operatorName, strings.TrimSpace(name)) {
+			return operator, true
+		}
+	}
+
+	return 0, false
+}
+
+func parseTicketPriority(name string) (tables.TicketPriority, bool) {
+	for priority, priorityName := range logic.TicketPriorityNames {
+		if strings.EqualFold(priorityName, strings.TrimSpace(name)) {
+			return priority, true
+		}
+	}
+
+	return 0, false
+}
+
+func routingOperatorAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, operator := range []tables.RoutingOperator{tables.RoutingOperatorAlways, tables.RoutingOperatorEquals, tables.RoutingOperatorContains, tables.RoutingOperatorMatches} {
+		name := tables.RoutingOperatorNames[operator]
+		if strings.HasPrefix(name, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  name,
+				Value: name,
+			})
+		}
+	}
+
+	return
+}
+
+func routingPriorityAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, priority := range []tables.TicketPriority{tables.PriorityLow, tables.PriorityNormal, tables.PriorityHigh, tables.PriorityUrgent} {
+		name := logic.TicketPriorityNames[priority]
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  name,
+				Value: strings.ToLower(name),
+			})
+		}
+	}
+
+	return
+}
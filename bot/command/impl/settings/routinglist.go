@@ -0,0 +1,127 @@
+package settings
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type RoutingListCommand struct {
+}
+
+func (RoutingListCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "list",
+		Description:     i18n.HelpRoutingList,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to list the routing rules of", interaction.OptionTypeInteger, i18n.MessageRoutingInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c RoutingListCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (RoutingListCommand) Execute(ctx registry.CommandContext, panelId int) {
+	panelTitle, formId, ok := getRoutingPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	rules, err := dbclient.Tables.PanelRoutingRules.GetByPanel(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(rules) == 0 {
+		ctx.Reply(customisation.Green, i18n.TitleRouting, i18n.MessageRoutingNoRules, panelTitle)
+		return
+	}
+
+	// form_input_id -> label
+	labels := make(map[int]string)
+	if formId != nil {
+		inputs, err := dbclient.Client.FormInput.GetInputs(*formId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		for _, input := range inputs {
+			labels[input.Id] = input.Label
+		}
+	}
+
+	teams, err := dbclient.Client.SupportTeam.Get(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	teamNames := make(map[int]string)
+	for _, team := range teams {
+		teamNames[team.Id] = team.Name
+	}
+
+	lines := make([]string, len(rules))
+	for i, rule := range rules {
+		lines[i] = fmt.Sprintf("**%d.** `#%d` %s", i+1, rule.Id, formatRoutingRule(rule, labels, teamNames))
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleRouting, i18n.MessageRoutingList, panelTitle, utils.StringMax(strings.Join(lines, "\n"), 3800, "..."))
+}
+
+func formatRoutingRule(rule tables.PanelRoutingRule, labels map[int]string, teamNames map[int]string) string {
+	condition := "Always"
+	if rule.Operator != tables.RoutingOperatorAlways {
+		label := "a deleted question"
+		if rule.FormInputId != nil {
+			if name, ok := labels[*rule.FormInputId]; ok {
+				label = fmt.Sprintf("\"%s\"", name)
+			}
+		}
+
+		condition = fmt.Sprintf("If %s %s `%s`", label, tables.RoutingOperatorNames[rule.Operator], rule.Value)
+	}
+
+	var outcomes []string
+	if rule.TargetCategory != nil {
+		outcomes = append(outcomes, fmt.Sprintf("category <#%d>", *rule.TargetCategory))
+	}
+
+	if rule.SupportTeamId != nil {
+		name, ok := teamNames[*rule.SupportTeamId]
+		if !ok {
+			name = "a deleted team"
+		}
+
+		outcomes = append(outcomes, fmt.Sprintf("team **%s**", name))
+	}
+
+	if rule.NamingScheme != nil {
+		outcomes = append(outcomes, fmt.Sprintf("naming scheme `%s`", *rule.NamingScheme))
+	}
+
+	if rule.Priority != nil {
+		outcomes = append(outcomes, fmt.Sprintf("priority **%s**", logic.TicketPriorityNames[*rule.Priority]))
+	}
+
+	return fmt.Sprintf("%s → %s", condition, strings.Join(outcomes, ", "))
+}
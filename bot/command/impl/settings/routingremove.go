@@ -0,0 +1,57 @@
+package settings
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type RoutingRemoveCommand struct {
+}
+
+func (RoutingRemoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "remove",
+		Description:     i18n.HelpRoutingRemove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Settings,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to remove the routing rule from", interaction.OptionTypeInteger, i18n.MessageRoutingInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewRequiredArgument("rule", "The ID of the rule, as shown by /routing list", interaction.OptionTypeInteger, i18n.MessageRoutingInvalidRule),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c RoutingRemoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (RoutingRemoveCommand) Execute(ctx registry.CommandContext, panelId, ruleId int) {
+	panelTitle, _, ok := getRoutingPanel(ctx, panelId)
+	if !ok {
+		return
+	}
+
+	deleted, err := dbclient.Tables.PanelRoutingRules.Delete(panelId, ruleId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !deleted {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageRoutingInvalidRule)
+		ctx.Reject()
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleRouting, i18n.MessageRoutingRemoved, ruleId, panelTitle)
+	ctx.Accept()
+}
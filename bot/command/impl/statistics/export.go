@@ -0,0 +1,184 @@
+package statistics
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/export"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/rest/request"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type ExportCommand struct {
+}
+
+func (ExportCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "export",
+		Description:     i18n.HelpExport,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Children: []registry.Command{
+			ExportTicketsCommand{},
+			ExportRatingsCommand{},
+			ExportStaffCommand{},
+		},
+		Category:    command.Statistics,
+		PremiumOnly: true,
+	}
+}
+
+func (c ExportCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ExportCommand) Execute(ctx registry.CommandContext) {
+	usageEmbed := embed.EmbedField{
+		Name:   "Usage",
+		Value:  "`/export tickets [from] [to] [format]`\n`/export ratings [from] [to] [format]`\n`/export staff [from] [to] [format]`",
+		Inline: false,
+	}
+
+	ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageInvalidArgument, utils.ToSlice(usageEmbed))
+	ctx.Reject()
+}
+
+const exportDateFormat = "2006-01-02"
+
+type exportFunc func(w io.Writer, format export.Format, guildId uint64, from, to time.Time) (int, error)
+
+func exportArguments() []command.Argument {
+	return command.Arguments(
+		command.NewOptionalArgument("from", "The first day to include, in the format YYYY-MM-DD", interaction.OptionTypeString, i18n.MessageExportInvalidDate),
+		command.NewOptionalArgument("to", "The last day to include, in the format YYYY-MM-DD", interaction.OptionTypeString, i18n.MessageExportInvalidDate),
+		command.NewOptionalAutocompleteableArgument("format", "The format of the file: csv or json", interaction.OptionTypeString, i18n.MessageExportInvalidFormat, exportFormatAutoCompleteHandler),
+	)
+}
+
+// runExport writes the export to a temporary file rather than to memory, and then uploads it as an attachment
+func runExport(ctx registry.CommandContext, name string, fromRaw, toRaw, formatRaw *string, fn exportFunc) {
+	format := export.FormatCsv
+	if formatRaw != nil {
+		var ok bool
+		format, ok = export.ParseFormat(strings.ToLower(*formatRaw))
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageExportInvalidFormat)
+			ctx.Reject()
+			return
+		}
+	}
+
+	from, to := time.Unix(0, 0), time.Now()
+
+	// The last day included in the export, for the filename
+	lastDay := to
+	if fromRaw != nil {
+		parsed, err := time.Parse(exportDateFormat, *fromRaw)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageExportInvalidDate)
+			ctx.Reject()
+			return
+		}
+
+		from = parsed
+	}
+
+	if toRaw != nil {
+		parsed, err := time.Parse(exportDateFormat, *toRaw)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageExportInvalidDate)
+			ctx.Reject()
+			return
+		}
+
+		// Include the whole of the last day
+		lastDay = parsed
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	if !to.After(from) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageExportInvalidDate)
+		ctx.Reject()
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleExport, i18n.MessageExportGenerating)
+
+	file, err := os.CreateTemp("", fmt.Sprintf("export-%d-*.%s", ctx.GuildId(), format))
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	count, err := fn(writer, format, ctx.GuildId(), from, to)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := writer.Flush(); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if count == 0 {
+		ctx.Reply(customisation.Red, i18n.TitleExport, i18n.MessageExportEmpty)
+		return
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if info.Size() > export.MaxFileSize {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageExportTooLarge)
+		return
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	res := command.NewEphemeralEmbedMessageResponse(utils.BuildEmbed(ctx, customisation.Green, i18n.TitleExport, i18n.MessageExportComplete, nil, count))
+	res.Attachments = []request.Attachment{
+		{
+			Name:        fmt.Sprintf("%s-%s-%s.%s", name, from.Format(exportDateFormat), lastDay.Format(exportDateFormat), format),
+			ContentType: format.ContentType(),
+			Reader:      file,
+		},
+	}
+
+	_, _ = ctx.ReplyWith(res)
+	ctx.Accept()
+}
+
+func exportFormatAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, format := range []export.Format{export.FormatCsv, export.FormatJson} {
+		if strings.HasPrefix(string(format), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  string(format),
+				Value: string(format),
+			})
+		}
+	}
+
+	return
+}
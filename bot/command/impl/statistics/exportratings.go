@@ -0,0 +1,34 @@
+package statistics
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/export"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ExportRatingsCommand struct {
+}
+
+func (ExportRatingsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "ratings",
+		Description:      i18n.HelpExportRatings,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Statistics,
+		PremiumOnly:      true,
+		Arguments:        exportArguments(),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ExportRatingsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ExportRatingsCommand) Execute(ctx registry.CommandContext, from, to, format *string) {
+	runExport(ctx, "ratings", from, to, format, export.Ratings)
+}
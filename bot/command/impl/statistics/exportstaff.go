@@ -0,0 +1,34 @@
+package statistics
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/export"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ExportStaffCommand struct {
+}
+
+func (ExportStaffCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "staff",
+		Description:      i18n.HelpExportStaff,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Statistics,
+		PremiumOnly:      true,
+		Arguments:        exportArguments(),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ExportStaffCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ExportStaffCommand) Execute(ctx registry.CommandContext, from, to, format *string) {
+	runExport(ctx, "staff", from, to, format, export.Staff)
+}
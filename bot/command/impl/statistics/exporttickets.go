@@ -0,0 +1,34 @@
+package statistics
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/export"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ExportTicketsCommand struct {
+}
+
+func (ExportTicketsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "tickets",
+		Description:      i18n.HelpExportTickets,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Statistics,
+		PremiumOnly:      true,
+		Arguments:        exportArguments(),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ExportTicketsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ExportTicketsCommand) Execute(ctx registry.CommandContext, from, to, format *string) {
+	runExport(ctx, "tickets", from, to, format, export.Tickets)
+}
@@ -24,6 +24,9 @@ func (StatsCommand) Properties() registry.Properties {
 		Children: []registry.Command{
 			StatsUserCommand{},
 			StatsServerCommand{},
+			StatsDigestCommand{},
+			StatsLeaderboardCommand{},
+			StatsPanelCommand{},
 		},
 		Category:    command.Statistics,
 		PremiumOnly: true,
@@ -37,7 +40,7 @@ func (c StatsCommand) GetExecutor() interface{} {
 func (StatsCommand) Execute(ctx registry.CommandContext) {
 	usageEmbed := embed.EmbedField{
 		Name:   "Usage",
-		Value:  "`/stats server`\n`/stats user @User`",
+		Value:  "`/stats server`\n`/stats user @User`\n`/stats leaderboard [metric] [period]`\n`/stats panel <panel>`\n`/stats digest weekly #channel`",
 		Inline: false,
 	}
 
@@ -0,0 +1,128 @@
+package statistics
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/rest/request"
+	"strings"
+	"time"
+)
+
+type StatsDigestCommand struct {
+}
+
+var digestFrequencies = map[string]tables.DigestFrequency{
+	"weekly":  tables.DigestFrequencyWeekly,
+	"monthly": tables.DigestFrequencyMonthly,
+}
+
+func (StatsDigestCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "digest",
+		Description:     i18n.HelpStatsDigest,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Statistics,
+		PremiumOnly:     true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("frequency", "How often to send the digest: weekly, monthly or disabled", interaction.OptionTypeString, i18n.MessageStatsDigestInvalidFrequency, StatsDigestCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("channel", "The channel to post the digest in. If not specified, it is sent to you in DMs", interaction.OptionTypeChannel, i18n.MessageStatsDigestInvalidChannel),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c StatsDigestCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (StatsDigestCommand) Execute(ctx registry.CommandContext, frequencyRaw string, channelId *uint64) {
+	frequencyRaw = strings.ToLower(frequencyRaw)
+
+	if frequencyRaw == "disabled" {
+		if err := dbclient.Tables.StatsDigests.Delete(ctx.GuildId()); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.TitleStatsDigest, i18n.MessageStatsDigestDisabled)
+		return
+	}
+
+	frequency, ok := digestFrequencies[frequencyRaw]
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestInvalidFrequency)
+		ctx.Reject()
+		return
+	}
+
+	if channelId != nil {
+		ch, err := ctx.Worker().GetChannel(*channelId)
+		if err != nil {
+			if restError, ok := err.(request.RestError); ok && restError.IsClientError() {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestInvalidChannel)
+				ctx.Reject()
+			} else {
+				ctx.HandleError(err)
+			}
+
+			return
+		}
+
+		if ch.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageStatsDigestInvalidChannel)
+			ctx.Reject()
+			return
+		}
+	}
+
+	digest := tables.StatsDigest{
+		GuildId:   ctx.GuildId(),
+		Frequency: frequency,
+		ChannelId: channelId,
+	}
+
+	if channelId == nil {
+		digest.UserId = utils.Ptr(ctx.UserId())
+	}
+
+	if err := dbclient.Tables.StatsDigests.Set(digest); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// The current period is incomplete, so mark it as delivered: the first digest is sent once it has ended
+	if _, err := dbclient.Tables.StatsDigestDeliveries.Claim(ctx.GuildId(), logic.DigestPeriodStart(frequency, time.Now())); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if channelId == nil {
+		ctx.Reply(customisation.Green, i18n.TitleStatsDigest, i18n.MessageStatsDigestDm, frequencyRaw)
+	} else {
+		ctx.Reply(customisation.Green, i18n.TitleStatsDigest, i18n.MessageStatsDigestChannel, frequencyRaw, *channelId)
+	}
+
+	ctx.Accept()
+}
+
+func (StatsDigestCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, option := range []string{"weekly", "monthly", "disabled"} {
+		if strings.HasPrefix(option, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  option,
+				Value: option,
+			})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,121 @@
+package statistics
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type StatsLeaderboardCommand struct {
+}
+
+func (c StatsLeaderboardCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "leaderboard",
+		Description:     i18n.HelpStatsLeaderboard,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Statistics,
+		PremiumOnly:     true,
+		Arguments: command.Arguments(
+			command.NewOptionalAutocompleteableArgument("metric", "What to rank staff by: answered, claimed, rating or response_time", interaction.OptionTypeString, i18n.MessageLeaderboardInvalidMetric, leaderboardMetricAutoCompleteHandler),
+			command.NewOptionalAutocompleteableArgument("period", "The period to rank staff over: week, month or all", interaction.OptionTypeString, i18n.MessageLeaderboardInvalidPeriod, leaderboardPeriodAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c StatsLeaderboardCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (StatsLeaderboardCommand) Execute(ctx registry.CommandContext, metric, period *string) {
+	options, ok := parseLeaderboardOptions(ctx, metric, period)
+	if !ok {
+		return
+	}
+
+	sendLeaderboard(ctx, options)
+}
+
+// parseLeaderboardOptions replies with an error if the options are invalid
+func parseLeaderboardOptions(ctx registry.CommandContext, metricRaw, periodRaw *string) (logic.LeaderboardOptions, bool) {
+	options := logic.LeaderboardOptions{
+		Metric: tables.LeaderboardMetricAnswered,
+		Period: logic.LeaderboardPeriodMonth,
+	}
+
+	if metricRaw != nil {
+		metric, ok := logic.ParseLeaderboardMetric(strings.ToLower(*metricRaw))
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLeaderboardInvalidMetric)
+			ctx.Reject()
+			return options, false
+		}
+
+		options.Metric = metric
+	}
+
+	if periodRaw != nil {
+		period, ok := logic.ParseLeaderboardPeriod(strings.ToLower(*periodRaw))
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLeaderboardInvalidPeriod)
+			ctx.Reject()
+			return options, false
+		}
+
+		options.Period = period
+	}
+
+	return options, true
+}
+
+func sendLeaderboard(ctx registry.CommandContext, options logic.LeaderboardOptions) {
+	msgEmbed, hasNext, err := logic.BuildLeaderboardMessage(ctx, options, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	res := command.MessageResponse{
+		Embeds:     []*embed.Embed{msgEmbed},
+		Flags:      message.SumFlags(message.FlagEphemeral),
+		Components: logic.BuildLeaderboardComponents(options, 0, hasNext),
+	}
+
+	_, _ = ctx.ReplyWith(res)
+}
+
+func leaderboardMetricAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, metric := range tables.LeaderboardMetrics {
+		if strings.HasPrefix(string(metric), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  string(metric),
+				Value: string(metric),
+			})
+		}
+	}
+
+	return
+}
+
+func leaderboardPeriodAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, period := range logic.LeaderboardPeriods {
+		if strings.HasPrefix(string(period), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  string(period),
+				Value: string(period),
+			})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,60 @@
+package statistics
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type StatsPanelCommand struct {
+}
+
+func (c StatsPanelCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "panel",
+		Description:     i18n.HelpStatsPanel,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Statistics,
+		PremiumOnly:     true,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("panel", "The panel to show statistics for", interaction.OptionTypeInteger, i18n.MessageLeaderboardInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalAutocompleteableArgument("metric", "What to rank staff by: answered, claimed, rating or response_time", interaction.OptionTypeString, i18n.MessageLeaderboardInvalidMetric, leaderboardMetricAutoCompleteHandler),
+			command.NewOptionalAutocompleteableArgument("period", "The period to rank staff over: week, month or all", interaction.OptionTypeString, i18n.MessageLeaderboardInvalidPeriod, leaderboardPeriodAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c StatsPanelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (StatsPanelCommand) Execute(ctx registry.CommandContext, panelId int, metric, period *string) {
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Verify panel is from same guild
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageLeaderboardInvalidPanel)
+		ctx.Reject()
+		return
+	}
+
+	options, ok := parseLeaderboardOptions(ctx, metric, period)
+	if !ok {
+		return
+	}
+
+	options.PanelId = &panel.PanelId
+	sendLeaderboard(ctx, options)
+}
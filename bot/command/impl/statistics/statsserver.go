@@ -9,6 +9,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel/embed"
@@ -68,6 +69,13 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		return
 	})
 
+	// feedback question answers
+	var feedbackQuestions []tables.RatingQuestionSummary
+	group.Go(func() (err error) {
+		feedbackQuestions, err = dbclient.Tables.RatingAnswers.GetSummary(ctx.GuildId())
+		return
+	})
+
 	// first response times
 	var firstResponseTime database.FirstResponseTimeData
 	group.Go(func() (err error) {
@@ -103,10 +111,22 @@ func (StatsServerCommand) Execute(ctx registry.CommandContext) {
 		AddField("Average Ticket Duration (Monthly)", formatNullableTime(ticketDuration.Monthly), true).
 		AddField("Average Ticket Duration (Weekly)", formatNullableTime(ticketDuration.Weekly), true)
 
+	for _, question := range feedbackQuestions {
+		msgEmbed.AddField(question.Label, formatQuestionSummary(question), true)
+	}
+
 	_, _ = ctx.ReplyWith(command.NewEphemeralEmbedMessageResponse(msgEmbed))
 	ctx.Accept()
 }
 
+func formatQuestionSummary(summary tables.RatingQuestionSummary) string {
+	if summary.Type == tables.RatingQuestionTypeYesNo && summary.Responses > 0 {
+		return fmt.Sprintf("%d%% yes (%d responses)", summary.Yes*100/summary.Responses, summary.Responses)
+	}
+
+	return fmt.Sprintf("%d responses", summary.Responses)
+}
+
 func formatNullableTime(duration *time.Duration) string {
 	return utils.FormatNullableTime(duration)
 }
@@ -25,6 +25,13 @@ func (ManageTagsCommand) Properties() registry.Properties {
 			ManageTagsAddCommand{},
 			ManageTagsDeleteCommand{},
 			ManageTagsListCommand{},
+			ManageTagsAttachCommand{},
+			ManageTagsDetachCommand{},
+			ManageTagsRestrictCommand{},
+			ManageTagsUnrestrictCommand{},
+			ManageTagsActionCommand{},
+			ManageTagsHistoryCommand{},
+			ManageTagsRollbackCommand{},
 		},
 		Category: command.Tags,
 	}
@@ -0,0 +1,83 @@
+package tags
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type ManageTagsActionCommand struct {
+}
+
+var tagPostActions = map[string]tables.TagPostAction{
+	"none":  tables.TagPostActionNone,
+	"close": tables.TagPostActionClose,
+	"claim": tables.TagPostActionClaim,
+}
+
+func (c ManageTagsActionCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "action",
+		Description:     i18n.HelpTagAction,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tags,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "ID of the tag", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, TagCommand{}.AutoCompleteHandler),
+			command.NewRequiredAutocompleteableArgument("action", "What to do to the ticket after the tag is sent: none, close or claim", interaction.OptionTypeString, i18n.MessageTagActionInvalid, c.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ManageTagsActionCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ManageTagsActionCommand) Execute(ctx registry.CommandContext, tagId, actionRaw string) {
+	action, ok := tagPostActions[strings.ToLower(actionRaw)]
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagActionInvalid)
+		ctx.Reject()
+		return
+	}
+
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		ctx.Reject()
+		return
+	}
+
+	if err := dbclient.Tables.TagSettings.SetPostAction(ctx.GuildId(), tagId, action); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagActionSuccess, tagId, strings.ToLower(actionRaw))
+	ctx.Accept()
+}
+
+func (ManageTagsActionCommand) AutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, option := range []string{"none", "close", "claim"} {
+		if strings.HasPrefix(option, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  option,
+				Value: option,
+			})
+		}
+	}
+
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel/embed"
@@ -83,7 +84,7 @@ func (ManageTagsAddCommand) Execute(ctx registry.CommandContext, tagId, content
 		Embed:           nil,
 	}
 
-	if err := dbclient.Client.Tag.Set(tag); err != nil {
+	if _, err := logic.SaveTag(tag, ctx.UserId()); err != nil {
 		ctx.HandleError(err)
 		return
 	}
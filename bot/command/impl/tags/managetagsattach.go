@@ -0,0 +1,107 @@
+package tags
+
+import (
+	"errors"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ManageTagsAttachCommand struct {
+}
+
+func (c ManageTagsAttachCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "attach",
+		Description:     i18n.HelpTagAttach,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tags,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "ID of the tag to attach the file to", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, TagCommand{}.AutoCompleteHandler),
+			command.NewRequiredArgument("file", "The file to send with the tag", interaction.OptionTypeAttachment, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ManageTagsAttachCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ManageTagsAttachCommand) Execute(ctx registry.CommandContext, tagId string, attachmentId uint64) {
+	interactionContext, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	attachment, ok := interactionContext.ResolvedAttachment(attachmentId)
+	if !ok {
+		ctx.HandleError(errors.New("Attachment missing from resolved data"))
+		return
+	}
+
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		ctx.Reject()
+		return
+	}
+
+	count, size, err := dbclient.Tables.TagAttachments.GetUsage(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if count >= tables.MaxTagAttachments {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagAttachmentLimit, tables.MaxTagAttachments)
+		ctx.Reject()
+		return
+	}
+
+	remaining := tables.MaxTagAttachmentSize - size
+	if attachment.Size > remaining {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagAttachmentTooLarge)
+		ctx.Reject()
+		return
+	}
+
+	// The attachment's URL expires, so the file itself is stored
+	data, contentType, err := logic.DownloadAttachment(ctx.Worker().TraceContext(), attachment.Url, remaining)
+	if err != nil {
+		if errors.Is(err, logic.ErrAttachmentTooLarge) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagAttachmentTooLarge)
+			ctx.Reject()
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	if err := dbclient.Tables.TagAttachments.Add(ctx.GuildId(), tagId, tables.TagAttachment{
+		FileName:    attachment.Filename,
+		ContentType: contentType,
+		Data:        data,
+	}); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagAttachSuccess, attachment.Filename, tagId)
+	ctx.Accept()
+}
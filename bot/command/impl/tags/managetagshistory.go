@@ -0,0 +1,87 @@
+package tags
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type ManageTagsHistoryCommand struct {
+}
+
+func (ManageTagsHistoryCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "history",
+		Description:     i18n.HelpTagHistory,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tags,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "ID of the tag to view the history of", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, TagCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ManageTagsHistoryCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ManageTagsHistoryCommand) Execute(ctx registry.CommandContext, tagId string) {
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		ctx.Reject()
+		return
+	}
+
+	versions, err := dbclient.Tables.TagVersions.GetRecent(ctx.GuildId(), tagId, tables.MaxTagVersions)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(versions) == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagHistoryEmpty, tagId)
+		return
+	}
+
+	var joined string
+	for _, version := range versions {
+		joined += formatTagVersion(version) + "\n"
+	}
+	joined = strings.TrimSuffix(joined, "\n")
+
+	ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagHistory, tagId, joined)
+}
+
+func formatTagVersion(version tables.TagVersion) string {
+	line := fmt.Sprintf("**v%d** <t:%d:R>", version.Version, version.EditedAt.Unix())
+	if version.EditedBy != nil {
+		line += fmt.Sprintf(" by <@%d>", *version.EditedBy)
+	}
+
+	if version.Content != nil {
+		preview := strings.ReplaceAll(utils.StringMax(*version.Content, 50, "..."), "`", "")
+		line += fmt.Sprintf(": `%s`", strings.ReplaceAll(preview, "\n", " "))
+	}
+
+	if version.Embed != nil {
+		line += " (embed)"
+	}
+
+	return line
+}
@@ -37,9 +37,15 @@ func (ManageTagsListCommand) Execute(ctx registry.CommandContext) {
 		return
 	}
 
+	usage, err := dbclient.Tables.TagUsage.GetAll(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
 	var joined string
 	for _, id := range ids {
-		joined += fmt.Sprintf("• `%s`\n", id)
+		joined += fmt.Sprintf("• `%s` (%d uses)\n", id, usage[id])
 	}
 	joined = strings.TrimSuffix(joined, "\n")
 
@@ -0,0 +1,87 @@
+package tags
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/impl/tickets"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ManageTagsRestrictCommand struct {
+}
+
+func (c ManageTagsRestrictCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "restrict",
+		Description:     i18n.HelpTagRestrict,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Tags,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "ID of the tag to restrict", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, TagCommand{}.AutoCompleteHandler),
+			command.NewOptionalAutocompleteableArgument("panel", "Only allow the tag in tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageTagRestrictInvalidPanel, tickets.SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("role", "Only allow members with this role to use the tag", interaction.OptionTypeRole, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ManageTagsRestrictCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ManageTagsRestrictCommand) Execute(ctx registry.CommandContext, tagId string, panelId *int, roleId *uint64) {
+	if panelId == nil && roleId == nil {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagRestrictMissing)
+		ctx.Reject()
+		return
+	}
+
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		ctx.Reject()
+		return
+	}
+
+	var panelIds []int
+	if panelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(*panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		// Verify panel is from same guild
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagRestrictInvalidPanel)
+			ctx.Reject()
+			return
+		}
+
+		panelIds = []int{panel.PanelId}
+	}
+
+	var roleIds []uint64
+	if roleId != nil {
+		roleIds = []uint64{*roleId}
+	}
+
+	if err := dbclient.Tables.TagSettings.AddRestrictions(ctx.GuildId(), tagId, panelIds, roleIds); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagRestrictSuccess, tagId)
+	ctx.Accept()
+}
@@ -0,0 +1,76 @@
+package tags
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ManageTagsRollbackCommand struct {
+}
+
+func (ManageTagsRollbackCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "rollback",
+		Description:     i18n.HelpTagRollback,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tags,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "ID of the tag to roll back", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, TagCommand{}.AutoCompleteHandler),
+			command.NewRequiredArgument("version", "Version to restore, as shown by /managetags history", interaction.OptionTypeInteger, i18n.MessageTagRollbackInvalidVersion),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ManageTagsRollbackCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ManageTagsRollbackCommand) Execute(ctx registry.CommandContext, tagId string, versionNumber int) {
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		ctx.Reject()
+		return
+	}
+
+	version, ok, err := dbclient.Tables.TagVersions.Get(ctx.GuildId(), tagId, versionNumber)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagRollbackInvalidVersion)
+		ctx.Reject()
+		return
+	}
+
+	tag, err := logic.TagFromVersion(ctx.GuildId(), tagId, version)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Rolling back is recorded as a new version, so that it can itself be undone
+	newVersion, err := logic.SaveTag(tag, ctx.UserId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagRollbackSuccess, tagId, versionNumber, newVersion)
+	ctx.Accept()
+}
@@ -0,0 +1,54 @@
+package tags
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ManageTagsUnrestrictCommand struct {
+}
+
+func (c ManageTagsUnrestrictCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "unrestrict",
+		Description:     i18n.HelpTagUnrestrict,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Admin,
+		Category:        command.Tags,
+		Arguments: command.Arguments(
+			command.NewRequiredAutocompleteableArgument("id", "ID of the tag to remove all panel and role restrictions from", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, TagCommand{}.AutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ManageTagsUnrestrictCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ManageTagsUnrestrictCommand) Execute(ctx registry.CommandContext, tagId string) {
+	exists, err := dbclient.Client.Tag.Exists(ctx.GuildId(), tagId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !exists {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagInvalidTag)
+		ctx.Reject()
+		return
+	}
+
+	if err := dbclient.Tables.TagSettings.ClearRestrictions(ctx.GuildId(), tagId); err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagUnrestrictSuccess, tagId)
+	ctx.Accept()
+}
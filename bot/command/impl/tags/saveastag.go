@@ -0,0 +1,71 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
+)
+
+type SaveAsTagCommand struct {
+}
+
+func (SaveAsTagCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "Save as Tag",
+		Type:             interaction.ApplicationCommandTypeMessage,
+		PermissionLevel:  permission.Support,
+		Category:         command.Tags,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c SaveAsTagCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (SaveAsTagCommand) Execute(ctx registry.CommandContext) {
+	interaction, ok := ctx.(*context.SlashCommandContext)
+	if !ok {
+		return
+	}
+
+	messageId := interaction.Interaction.Data.TargetId
+
+	msg, ok := interaction.ResolvedMessage(messageId)
+	if !ok {
+		ctx.HandleError(errors.New("Message missing from resolved data"))
+		return
+	}
+
+	if _, ok := logic.TagFromMessage(ctx.GuildId(), "", msg); !ok {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageSaveAsTagEmpty)
+		return
+	}
+
+	// Application commands can't respond with a modal here, so the tag ID is collected from a modal opened by a button
+	msgEmbed := utils.BuildEmbed(ctx, customisation.Green, i18n.TitleSaveAsTag, i18n.MessageSaveAsTagPrompt, nil)
+
+	_, _ = ctx.ReplyWith(command.MessageResponse{
+		Embeds: []*embed.Embed{msgEmbed},
+		Flags:  message.SumFlags(message.FlagEphemeral),
+		Components: []component.Component{
+			component.BuildActionRow(component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.TitleSaveAsTag),
+				CustomId: fmt.Sprintf("save_tag_%d_%d", ctx.ChannelId(), msg.Id),
+				Style:    component.ButtonStylePrimary,
+			})),
+		},
+	})
+}
@@ -1,6 +1,8 @@
 package tags
 
 import (
+	"errors"
+	"fmt"
 	"github.com/TicketsBot/common/permission"
 	"github.com/TicketsBot/common/premium"
 	"github.com/TicketsBot/common/sentry"
@@ -8,11 +10,13 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel/embed"
 	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
 )
 
 type TagCommand struct {
@@ -28,6 +32,7 @@ func (c TagCommand) Properties() registry.Properties {
 		Category:        command.Tags,
 		Arguments: command.Arguments(
 			command.NewRequiredAutocompleteableArgument("id", "The ID of the tag to be sent to the channel", interaction.OptionTypeString, i18n.MessageTagInvalidArguments, c.AutoCompleteHandler),
+			command.NewOptionalArgument("arguments", "Values for the tag's arguments, e.g. amount:20 reason:\"late delivery\"", interaction.OptionTypeString, i18n.MessageTagInvalidArguments),
 		),
 	}
 }
@@ -36,10 +41,10 @@ func (c TagCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (TagCommand) Execute(ctx registry.CommandContext, tagId string) {
+func (TagCommand) Execute(ctx registry.CommandContext, tagId string, argumentsRaw *string) {
 	usageEmbed := embed.EmbedField{
 		Name:   "Usage",
-		Value:  "`/tag [TagID]`",
+		Value:  "`/tag [TagID] [arguments]`",
 		Inline: false,
 	}
 
@@ -60,13 +65,55 @@ func (TagCommand) Execute(ctx registry.CommandContext, tagId string) {
 		return
 	}
 
-	// Count user as a participant so that Tickets Answered stat includes tickets where only /tag was used
-	if ticket.GuildId != 0 {
-		go func() {
-			if err := dbclient.Client.Participants.Set(ctx.GuildId(), ticket.Id, ctx.UserId()); err != nil {
-				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	settings, err := dbclient.Tables.TagSettings.Get(ctx.GuildId(), tag.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	canUse, reason, err := logic.CanUseTag(ctx, settings, ticket)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if !canUse {
+		ctx.Reply(customisation.Red, i18n.Error, reason)
+		ctx.Reject()
+		return
+	}
+
+	if settings.PostAction != tables.TagPostActionNone && ticket.GuildId == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		ctx.Reject()
+		return
+	}
+
+	if settings.PostAction == tables.TagPostActionClose && !utils.CanClose(ctx, ticket) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageCloseNoPermission)
+		ctx.Reject()
+		return
+	}
+
+	if settings.PostAction == tables.TagPostActionClaim && ticket.IsThread {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimThread)
+		ctx.Reject()
+		return
+	}
+
+	// Check the claim limit before the tag is sent, so that the tag isn't sent without the ticket being claimed
+	if settings.PostAction == tables.TagPostActionClaim {
+		if err := logic.CheckClaimLimit(ticket, ctx.UserId()); err != nil {
+			var limitErr logic.ClaimLimitError
+			if errors.As(err, &limitErr) {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimLimitReached, limitErr.Limit)
+				ctx.Reject()
+			} else {
+				ctx.HandleError(err)
 			}
-		}()
+
+			return
+		}
 	}
 
 	var content string
@@ -74,21 +121,112 @@ func (TagCommand) Execute(ctx registry.CommandContext, tagId string) {
 		content = logic.DoPlaceholderSubstitutions(*tag.Content, ctx.Worker(), ticket)
 	}
 
-	var embeds []*embed.Embed
+	var tagEmbed *embed.Embed
 	if tag.Embed != nil {
-		embeds = []*embed.Embed{
-			logic.BuildCustomEmbed(ctx.Worker(), ticket, *tag.Embed.CustomEmbed, tag.Embed.Fields, ctx.PremiumTier() == premium.None),
+		tagEmbed = logic.BuildCustomEmbed(ctx.Worker(), ticket, *tag.Embed.CustomEmbed, tag.Embed.Fields, ctx.PremiumTier() == premium.None)
+	}
+
+	// Substitute arguments supplied by the user
+	if argumentNames := logic.TagArgumentNames(content, tagEmbed); len(argumentNames) > 0 {
+		var arguments map[string]string
+		if argumentsRaw != nil {
+			var ok bool
+			if arguments, ok = logic.ParseTagArguments(*argumentsRaw); !ok {
+				ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagArgumentsInvalid)
+				ctx.Reject()
+				return
+			}
+		}
+
+		var missing []string
+		for _, name := range argumentNames {
+			if _, ok := arguments[name]; !ok {
+				missing = append(missing, fmt.Sprintf("`%s`", name))
+			}
 		}
+
+		if len(missing) > 0 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTagArgumentsMissing, strings.Join(missing, ", "))
+			ctx.Reject()
+			return
+		}
+
+		content = logic.SubstituteTagArguments(content, tagEmbed, arguments)
+	}
+
+	var embeds []*embed.Embed
+	if tagEmbed != nil {
+		embeds = []*embed.Embed{tagEmbed}
 	}
 
+	attachments, err := dbclient.Tables.TagAttachments.Get(ctx.GuildId(), tag.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	// Count user as a participant so that Tickets Answered stat includes tickets where only /tag was used
+	if ticket.GuildId != 0 {
+		go func() {
+			if err := dbclient.Client.Participants.Set(ctx.GuildId(), ticket.Id, ctx.UserId()); err != nil {
+				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			}
+		}()
+	}
+
+	go func() {
+		if err := dbclient.Tables.TagUsage.Increment(ctx.GuildId(), tag.Id); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+	}()
+
 	data := command.MessageResponse{
 		Content: content,
 		Embeds:  embeds,
 	}
 
-	if _, err := ctx.ReplyWith(data); err != nil {
-		ctx.HandleError(err)
-		return
+	if len(attachments) == 0 {
+		if _, err := ctx.ReplyWith(data); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+	} else {
+		// Files can't be sent in the initial interaction response, so the tag is sent as a separate message
+		data.Attachments = logic.BuildTagAttachmentFiles(attachments)
+
+		if _, err := ctx.Worker().CreateMessageComplex(ctx.ChannelId(), data.IntoCreateMessageData()); err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		ctx.Reply(customisation.Green, i18n.MessageTag, i18n.MessageTagSent, tag.Id)
+	}
+
+	switch settings.PostAction {
+	case tables.TagPostActionClose:
+		logic.CloseTicket(ctx, nil)
+	case tables.TagPostActionClaim:
+		// Replying would edit the response containing the tag
+		if err := logic.ClaimTicket(ctx, ticket, ctx.UserId()); err != nil {
+			// Another ticket may have been claimed since the limit was checked
+			var limitErr logic.ClaimLimitError
+			if !errors.As(err, &limitErr) {
+				ctx.HandleError(err)
+				return
+			}
+
+			limitEmbed := utils.BuildEmbed(ctx, customisation.Red, i18n.Error, i18n.MessageClaimLimitReached, nil, limitErr.Limit)
+			if _, err := ctx.Worker().CreateMessageEmbed(ctx.ChannelId(), limitEmbed); err != nil {
+				ctx.HandleError(err)
+			}
+
+			return
+		}
+
+		claimedEmbed := utils.BuildEmbed(ctx, customisation.Green, i18n.TitleClaimed, i18n.MessageClaimed, nil, fmt.Sprintf("<@%d>", ctx.UserId()))
+		if _, err := ctx.Worker().CreateMessageEmbed(ctx.ChannelId(), claimedEmbed); err != nil {
+			ctx.HandleError(err)
+		}
 	}
 }
 
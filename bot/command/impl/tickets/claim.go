@@ -1,6 +1,7 @@
 package tickets
 
 import (
+	"errors"
 	"fmt"
 	"github.com/TicketsBot/common/permission"
 	"github.com/TicketsBot/worker/bot/command"
@@ -58,6 +59,12 @@ func (ClaimCommand) Execute(ctx registry.CommandContext) {
 	}
 
 	if err := logic.ClaimTicket(ctx, ticket, ctx.UserId()); err != nil {
+		var limitErr logic.ClaimLimitError
+		if errors.As(err, &limitErr) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageClaimLimitReached, limitErr.Limit)
+			return
+		}
+
 		ctx.HandleError(err)
 		return
 	}
@@ -0,0 +1,101 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type ReplyCommand struct {
+}
+
+func (ReplyCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "reply",
+		Description:     i18n.HelpReply,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewRequiredArgument("message", "The message to send in the ticket", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("anonymous", "Send the message under the team's name instead of your own. Defaults to the panel's setting", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c ReplyCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (ReplyCommand) Execute(ctx registry.CommandContext, content string, anonymous *bool) {
+	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx.ChannelId(), ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if ticket.Id == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageNotATicketChannel)
+		ctx.Reject()
+		return
+	}
+
+	settings, err := logic.GetAnonymiseSettings(ticket)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	isAnonymous := settings.Enabled
+	if anonymous != nil {
+		isAnonymous = *anonymous
+	}
+
+	author, err := ctx.User()
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	msg, err := logic.SendStaffReply(ctx, ticket, author, content, isAnonymous)
+	if err != nil {
+		if err == logic.ErrNoTicketWebhook {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageReplyNoWebhook)
+		} else {
+			ctx.HandleError(err)
+		}
+
+		return
+	}
+
+	if err := logic.RecordStaffReply(ticket, msg.Id, author.Id); err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	}
+
+	// Messages sent through the webhook aren't seen by the modmail relay, as they aren't sent by a user
+	session, ok, err := dbclient.Tables.ModmailSessions.GetByTicket(ticket.GuildId, ticket.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	} else if ok {
+		relayed := message.Message{
+			Content: content,
+			Author:  author,
+		}
+
+		if err := logic.RelayModmailStaffMessage(ctx, session, relayed, isAnonymous); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+	}
+
+	ctx.Reply(customisation.Green, i18n.TitleReply, i18n.MessageReplySent)
+	ctx.Accept()
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
 	"github.com/rxdn/gdl/objects/channel/message"
 	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
 	"strings"
 )
 
@@ -95,6 +97,11 @@ func (StartTicketCommand) Execute(ctx registry.CommandContext) {
 				return
 			}
 		}
+
+		// Staff may also bring the conversation leading up to the message into the ticket
+		if userPermissionLevel >= permcache.Support {
+			sendMoveMessagesPrompt(ctx, ticket, msg)
+		}
 	}
 }
 
@@ -165,3 +172,13 @@ func sendMovedMessage(ctx registry.CommandContext, ticket database.Ticket, msg m
 		return
 	}
 }
+
+func sendMoveMessagesPrompt(ctx registry.CommandContext, ticket database.Ticket, msg message.Message) {
+	msgEmbed := utils.BuildEmbed(ctx, customisation.Green, i18n.Ticket, i18n.MessageMoveMessagesPrompt, nil, *ticket.ChannelId)
+
+	_, _ = ctx.ReplyWith(command.MessageResponse{
+		Embeds:     []*embed.Embed{msgEmbed},
+		Flags:      message.SumFlags(message.FlagEphemeral),
+		Components: []component.Component{logic.BuildMoveMessagesSelect(ticket.Id, msg.Id)},
+	})
+}
@@ -8,12 +8,8 @@ import (
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
-	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
-	"github.com/rxdn/gdl/objects/channel"
-	"github.com/rxdn/gdl/objects/channel/embed"
 	"github.com/rxdn/gdl/objects/interaction"
-	"github.com/rxdn/gdl/rest"
 )
 
 type SwitchPanelCommand struct {
@@ -65,101 +61,7 @@ func (SwitchPanelCommand) Execute(ctx registry.CommandContext, panelId int) {
 		return
 	}
 
-	// Update panel assigned to ticket in database
-	if err := dbclient.Client.Tickets.SetPanelId(ctx.GuildId(), ticket.Id, panelId); err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Get ticket claimer
-	claimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Update welcome message
-	if ticket.WelcomeMessageId != nil {
-		msg, err := ctx.Worker().GetChannelMessage(ctx.ChannelId(), *ticket.WelcomeMessageId)
-
-		// Error is likely to be due to message being deleted, we want to continue further even if it is
-		if err == nil {
-			var subject string
-
-			embeds := utils.PtrElems(msg.Embeds) // TODO: Fix types
-			if len(embeds) == 0 {
-				embeds = make([]*embed.Embed, 1)
-				subject = "No subject given"
-			} else {
-				subject = embeds[0].Title // TODO: Store subjects in database
-			}
-
-			embeds[0], err = logic.BuildWelcomeMessageEmbed(ctx, ticket, subject, &panel)
-			if err != nil {
-				ctx.HandleError(err)
-				return
-			}
-
-			for i := 1; i < len(embeds); i++ {
-				embeds[i].Color = embeds[0].Color
-			}
-
-			editData := rest.EditMessageData{
-				Content:    msg.Content,
-				Embeds:     embeds,
-				Flags:      msg.Flags,
-				Components: msg.Components,
-			}
-
-			if _, err = ctx.Worker().EditMessage(ctx.ChannelId(), *ticket.WelcomeMessageId, editData); err != nil {
-				ctx.HandleWarning(err)
-			}
-		}
-	}
-
-	// Append additional ticket members to overwrites
-	members, err := dbclient.Client.TicketMembers.Get(ctx.GuildId(), ticket.Id)
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Calculate new channel permissions
-	var overwrites []channel.PermissionOverwrite
-	if claimer == 0 {
-		overwrites, err = logic.CreateOverwrites(ctx.Worker(), ctx.GuildId(), ticket.UserId, ctx.Worker().BotId, &panel, members...)
-		if err != nil {
-			ctx.HandleError(err)
-			return
-		}
-	} else {
-		overwrites, err = logic.GenerateClaimedOverwrites(ctx.Worker(), ticket, claimer, members...)
-		if err != nil {
-			ctx.HandleError(err)
-			return
-		}
-
-		// GenerateClaimedOverwrites returns nil if the permissions are the same as an unclaimed ticket
-		// so if this is the case, we still need to calculate permissions
-		if overwrites == nil {
-			overwrites, err = logic.CreateOverwrites(ctx.Worker(), ctx.GuildId(), ticket.UserId, ctx.Worker().BotId, &panel, members...)
-		}
-	}
-
-	channelName, err := logic.GenerateChannelName(ctx, &panel, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
-	if err != nil {
-		ctx.HandleError(err)
-		return
-	}
-
-	// Update channel permissions
-	data := rest.ModifyChannelData{
-		Name:                 channelName,
-		PermissionOverwrites: overwrites,
-		ParentId:             panel.TargetCategory,
-	}
-
-	if _, err = ctx.Worker().ModifyChannel(*ticket.ChannelId, data); err != nil {
+	if err := logic.SwitchPanel(ctx, ticket, panel); err != nil {
 		ctx.HandleError(err)
 		return
 	}
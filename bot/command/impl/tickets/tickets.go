@@ -0,0 +1,47 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsCommand struct {
+}
+
+func (TicketsCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "tickets",
+		Description:     i18n.HelpTickets,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Everyone, // Subcommands have their own permission levels
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Children: []registry.Command{
+			TicketsBulkCommand{},
+			TicketsSearchCommand{},
+			TicketsOpenCommand{},
+			TicketsMineCommand{},
+		},
+	}
+}
+
+func (c TicketsCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsCommand) Execute(ctx registry.CommandContext) {
+	usageEmbed := embed.EmbedField{
+		Name:   "Usage",
+		Value:  "`/tickets bulk close|claim|label|move`\n`/tickets search [user] [panel] [claimer] [text] [from] [to] [status]`\n`/tickets open [user] [panel] [unclaimed_only]`\n`/tickets mine`",
+		Inline: false,
+	}
+
+	ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageInvalidArgument, utils.ToSlice(usageEmbed))
+	ctx.Reject()
+}
@@ -0,0 +1,217 @@
+package tickets
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"strings"
+	"time"
+)
+
+type TicketsBulkCommand struct {
+}
+
+func (TicketsBulkCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "bulk",
+		Description:     i18n.HelpTicketsBulk,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Children: []registry.Command{
+			TicketsBulkCloseCommand{},
+			TicketsBulkClaimCommand{},
+			TicketsBulkLabelCommand{},
+			TicketsBulkMoveCommand{},
+		},
+	}
+}
+
+func (c TicketsBulkCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkCommand) Execute(ctx registry.CommandContext) {
+	usageEmbed := embed.EmbedField{
+		Name:   "Usage",
+		Value:  "`/tickets bulk close [reason]`\n`/tickets bulk claim`\n`/tickets bulk label <label>`\n`/tickets bulk move <to>`",
+		Inline: false,
+	}
+
+	ctx.ReplyWithFields(customisation.Red, i18n.Error, i18n.MessageInvalidArgument, utils.ToSlice(usageEmbed))
+	ctx.Reject()
+}
+
+const (
+	// Progress can only be shown until the preview's interaction token expires, so keep jobs to a size that will
+	// usually have finished by then
+	maxBulkTickets = 250
+
+	bulkPreviewTicketCount = 20
+)
+
+var bulkPreviewMessages = map[tables.BulkAction]i18n.MessageId{
+	tables.BulkActionClose: i18n.MessageBulkPreviewClose,
+	tables.BulkActionClaim: i18n.MessageBulkPreviewClaim,
+	tables.BulkActionLabel: i18n.MessageBulkPreviewLabel,
+	tables.BulkActionMove:  i18n.MessageBulkPreviewMove,
+}
+
+func bulkFilterArguments() []command.Argument {
+	return command.Arguments(
+		command.NewOptionalAutocompleteableArgument("panel", "Only include tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageBulkInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler),
+		command.NewOptionalArgument("opener", "Only include tickets opened by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+		command.NewOptionalArgument("older_than", "Only include tickets opened at least this many hours ago", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+		command.NewOptionalArgument("inactive_for", "Only include tickets with no messages for at least this many hours", interaction.OptionTypeInteger, i18n.MessageInvalidArgument),
+		command.NewOptionalArgument("has_label", "Only include tickets with this label", interaction.OptionTypeString, i18n.MessageBulkInvalidLabel),
+	)
+}
+
+// parseBulkFilter returns false if a filter was invalid, in which case the user has already been told
+func parseBulkFilter(ctx registry.CommandContext, panelId *int, openerId *uint64, olderThan, inactiveFor *int, label *string) (logic.BulkTicketFilter, bool) {
+	filter := logic.BulkTicketFilter{
+		PanelId:  panelId,
+		OpenerId: openerId,
+		Label:    label,
+	}
+
+	if panelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(*panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return filter, false
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidPanel)
+			return filter, false
+		}
+	}
+
+	if olderThan != nil {
+		if *olderThan < 0 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageInvalidArgument)
+			return filter, false
+		}
+
+		duration := time.Duration(*olderThan) * time.Hour
+		filter.OlderThan = &duration
+	}
+
+	if inactiveFor != nil {
+		if *inactiveFor < 0 {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageInvalidArgument)
+			return filter, false
+		}
+
+		duration := time.Duration(*inactiveFor) * time.Hour
+		filter.InactiveFor = &duration
+	}
+
+	if label != nil && !isValidLabel(*label) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidLabel)
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func isValidLabel(label string) bool {
+	return len(strings.TrimSpace(label)) > 0 && len(label) <= tables.MaxTicketLabelLength
+}
+
+// runBulkPreview stores the job and shows the tickets it will affect, along with a button to confirm it. Nothing is
+// changed until the job has been confirmed.
+func runBulkPreview(ctx registry.CommandContext, action tables.BulkAction, argument *string, filter logic.BulkTicketFilter, previewArgs ...interface{}) {
+	tickets, err := logic.FindBulkTickets(ctx.GuildId(), filter)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if len(tickets) == 0 {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkNoTickets)
+		return
+	}
+
+	// Let the user know that the job doesn't cover every ticket, so that they can run it again afterwards
+	var excluded int
+	if len(tickets) > maxBulkTickets {
+		excluded = len(tickets) - maxBulkTickets
+		tickets = tickets[:maxBulkTickets]
+	}
+
+	// Previews that were never confirmed can be cleaned up once they have expired
+	if err := dbclient.Tables.BulkTicketJobs.DeleteBefore(time.Now().Add(-time.Hour * 24)); err != nil {
+		ctx.HandleWarning(err)
+	}
+
+	ticketIds := make([]int, len(tickets))
+	for i, ticket := range tickets {
+		ticketIds[i] = ticket.Id
+	}
+
+	jobId, err := dbclient.Tables.BulkTicketJobs.Create(tables.BulkTicketJob{
+		GuildId:   ctx.GuildId(),
+		UserId:    ctx.UserId(),
+		Action:    action,
+		TicketIds: ticketIds,
+		Argument:  argument,
+	})
+
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	var mentions []string
+	for i, ticket := range tickets {
+		if i >= bulkPreviewTicketCount {
+			mentions = append(mentions, fmt.Sprintf("+%d", len(tickets)-bulkPreviewTicketCount))
+			break
+		}
+
+		mentions = append(mentions, fmt.Sprintf("<#%d>", *ticket.ChannelId))
+	}
+
+	previewEmbed := utils.BuildEmbed(ctx, customisation.Orange, i18n.TitleBulk, bulkPreviewMessages[action], nil, append([]interface{}{len(tickets)}, previewArgs...)...).
+		AddField("Tickets", utils.StringMax(strings.Join(mentions, " "), 1024), false)
+
+	if excluded > 0 {
+		previewEmbed.AddField("Not Included", ctx.GetMessage(i18n.MessageBulkTruncated, excluded, maxBulkTickets), false)
+	}
+
+	confirmStyle := component.ButtonStylePrimary
+	if action == tables.BulkActionClose {
+		confirmStyle = component.ButtonStyleDanger
+	}
+
+	_, _ = ctx.ReplyWith(command.MessageResponse{
+		Embeds: utils.Slice(previewEmbed),
+		Flags:  message.SumFlags(message.FlagEphemeral),
+		Components: utils.Slice(component.BuildActionRow(
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.MessageBulkConfirmButton),
+				CustomId: fmt.Sprintf("bulk_confirm_%d", jobId),
+				Style:    confirmStyle,
+			}),
+			component.BuildButton(component.Button{
+				Label:    ctx.GetMessage(i18n.MessageBulkCancelButton),
+				CustomId: fmt.Sprintf("bulk_cancel_%d", jobId),
+				Style:    component.ButtonStyleSecondary,
+			}),
+		)),
+	})
+}
@@ -0,0 +1,40 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsBulkClaimCommand struct {
+}
+
+func (TicketsBulkClaimCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "claim",
+		Description:      i18n.HelpTicketsBulkClaim,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Support,
+		Category:         command.Tickets,
+		InteractionOnly:  true,
+		Arguments:        bulkFilterArguments(),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsBulkClaimCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+// Execute claims the matching tickets for the user running the command. Tickets that are already claimed are skipped.
+func (TicketsBulkClaimCommand) Execute(ctx registry.CommandContext, panelId *int, openerId *uint64, olderThan, inactiveFor *int, label *string) {
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, inactiveFor, label)
+	if !ok {
+		return
+	}
+
+	runBulkPreview(ctx, tables.BulkActionClaim, nil, filter)
+}
@@ -0,0 +1,42 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsBulkCloseCommand struct {
+}
+
+func (TicketsBulkCloseCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "close",
+		Description:     i18n.HelpTicketsBulkClose,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: append(
+			command.Arguments(command.NewOptionalArgument("reason", "The reason for closing the tickets", interaction.OptionTypeString, i18n.MessageInvalidArgument)),
+			bulkFilterArguments()...,
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsBulkCloseCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkCloseCommand) Execute(ctx registry.CommandContext, reason *string, panelId *int, openerId *uint64, olderThan, inactiveFor *int, label *string) {
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, inactiveFor, label)
+	if !ok {
+		return
+	}
+
+	runBulkPreview(ctx, tables.BulkActionClose, reason, filter)
+}
@@ -0,0 +1,50 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+)
+
+type TicketsBulkLabelCommand struct {
+}
+
+func (TicketsBulkLabelCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "label",
+		Description:     i18n.HelpTicketsBulkLabel,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: append(
+			command.Arguments(command.NewRequiredArgument("label", "The label to add to the tickets", interaction.OptionTypeString, i18n.MessageBulkInvalidLabel)),
+			bulkFilterArguments()...,
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsBulkLabelCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkLabelCommand) Execute(ctx registry.CommandContext, newLabel string, panelId *int, openerId *uint64, olderThan, inactiveFor *int, label *string) {
+	newLabel = strings.ToLower(strings.TrimSpace(newLabel))
+	if !isValidLabel(newLabel) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidLabel)
+		return
+	}
+
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, inactiveFor, label)
+	if !ok {
+		return
+	}
+
+	runBulkPreview(ctx, tables.BulkActionLabel, &newLabel, filter, newLabel)
+}
@@ -0,0 +1,57 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strconv"
+)
+
+type TicketsBulkMoveCommand struct {
+}
+
+func (TicketsBulkMoveCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "move",
+		Description:     i18n.HelpTicketsBulkMove,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: append(
+			command.Arguments(command.NewRequiredAutocompleteableArgument("to", "The panel to move the tickets to", interaction.OptionTypeInteger, i18n.MessageBulkInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler)),
+			bulkFilterArguments()...,
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsBulkMoveCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsBulkMoveCommand) Execute(ctx registry.CommandContext, targetPanelId int, panelId *int, openerId *uint64, olderThan, inactiveFor *int, label *string) {
+	panel, err := dbclient.Client.Panel.GetById(targetPanelId)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageBulkInvalidPanel)
+		return
+	}
+
+	filter, ok := parseBulkFilter(ctx, panelId, openerId, olderThan, inactiveFor, label)
+	if !ok {
+		return
+	}
+
+	argument := strconv.Itoa(panel.PanelId)
+	runBulkPreview(ctx, tables.BulkActionMove, &argument, filter, panel.Title)
+}
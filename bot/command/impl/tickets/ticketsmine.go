@@ -0,0 +1,37 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsMineCommand struct {
+}
+
+func (TicketsMineCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:             "mine",
+		Description:      i18n.HelpTicketsMine,
+		Type:             interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel:  permission.Everyone,
+		Category:         command.Tickets,
+		InteractionOnly:  true,
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsMineCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsMineCommand) Execute(ctx registry.CommandContext) {
+	replyWithOpenTickets(ctx, logic.OpenTicketsOptions{
+		UserId: utils.Ptr(ctx.UserId()),
+		Mine:   true,
+	})
+}
@@ -0,0 +1,75 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+)
+
+type TicketsOpenCommand struct {
+}
+
+func (TicketsOpenCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "open",
+		Description:     i18n.HelpTicketsOpen,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("user", "Only show tickets opened by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("panel", "Only show tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageTicketSearchInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("unclaimed_only", "Only show tickets that nobody has claimed", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsOpenCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+func (TicketsOpenCommand) Execute(ctx registry.CommandContext, userId *uint64, panelId *int, unclaimedOnly *bool) {
+	if panelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(*panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTicketSearchInvalidPanel)
+			return
+		}
+	}
+
+	options := logic.OpenTicketsOptions{
+		UserId:        userId,
+		PanelId:       panelId,
+		UnclaimedOnly: unclaimedOnly != nil && *unclaimedOnly,
+	}
+
+	replyWithOpenTickets(ctx, options)
+}
+
+func replyWithOpenTickets(ctx registry.CommandContext, options logic.OpenTicketsOptions) {
+	msgEmbed, components, err := logic.BuildOpenTicketsMessage(ctx, options, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	_, _ = ctx.ReplyWith(command.MessageResponse{
+		Embeds:     utils.Slice(msgEmbed),
+		Flags:      message.SumFlags(message.FlagEphemeral),
+		Components: components,
+	})
+}
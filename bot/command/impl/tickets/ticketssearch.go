@@ -0,0 +1,143 @@
+package tickets
+
+import (
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/worker/bot/command"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+	"time"
+)
+
+type TicketsSearchCommand struct {
+}
+
+func (TicketsSearchCommand) Properties() registry.Properties {
+	return registry.Properties{
+		Name:            "search",
+		Description:     i18n.HelpTicketsSearch,
+		Type:            interaction.ApplicationCommandTypeChatInput,
+		PermissionLevel: permission.Support,
+		Category:        command.Tickets,
+		InteractionOnly: true,
+		Arguments: command.Arguments(
+			command.NewOptionalArgument("user", "Only show tickets opened by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("panel", "Only show tickets opened from this panel", interaction.OptionTypeInteger, i18n.MessageTicketSearchInvalidPanel, SwitchPanelCommand{}.AutoCompleteHandler),
+			command.NewOptionalArgument("claimer", "Only show tickets claimed by this user", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalArgument("text", "Words to search for in subjects, close reasons and form answers", interaction.OptionTypeString, i18n.MessageInvalidArgument),
+			command.NewOptionalArgument("from", "Only show tickets opened on or after this day, in the format YYYY-MM-DD", interaction.OptionTypeString, i18n.MessageTicketSearchInvalidDate),
+			command.NewOptionalArgument("to", "Only show tickets opened on or before this day, in the format YYYY-MM-DD", interaction.OptionTypeString, i18n.MessageTicketSearchInvalidDate),
+			command.NewOptionalAutocompleteableArgument("status", "Only show open or closed tickets", interaction.OptionTypeString, i18n.MessageTicketSearchInvalidStatus, ticketStatusAutoCompleteHandler),
+		),
+		DefaultEphemeral: true,
+	}
+}
+
+func (c TicketsSearchCommand) GetExecutor() interface{} {
+	return c.Execute
+}
+
+const ticketSearchDateFormat = "2006-01-02"
+
+func (TicketsSearchCommand) Execute(ctx registry.CommandContext, userId *uint64, panelId *int, claimerId *uint64, text, fromRaw, toRaw, status *string) {
+	filter := tables.TicketSearchFilter{
+		OpenerId:  userId,
+		PanelId:   panelId,
+		ClaimerId: claimerId,
+	}
+
+	if panelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(*panelId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if panel.PanelId == 0 || panel.GuildId != ctx.GuildId() {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTicketSearchInvalidPanel)
+			return
+		}
+	}
+
+	if text != nil && strings.TrimSpace(*text) != "" {
+		filter.Text = utils.Ptr(strings.TrimSpace(*text))
+	}
+
+	if fromRaw != nil {
+		from, err := time.Parse(ticketSearchDateFormat, *fromRaw)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTicketSearchInvalidDate)
+			return
+		}
+
+		filter.From = &from
+	}
+
+	if toRaw != nil {
+		to, err := time.Parse(ticketSearchDateFormat, *toRaw)
+		if err != nil {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTicketSearchInvalidDate)
+			return
+		}
+
+		// Include the whole of the last day
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	if status != nil {
+		switch strings.ToLower(*status) {
+		case "open":
+			filter.Open = utils.Ptr(true)
+		case "closed":
+			filter.Open = utils.Ptr(false)
+		case "all":
+		default:
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTicketSearchInvalidStatus)
+			return
+		}
+	}
+
+	// Searches that are no longer being paged through can be cleaned up
+	if err := dbclient.Tables.TicketSearches.DeleteBefore(time.Now().Add(-time.Hour * 24)); err != nil {
+		ctx.HandleWarning(err)
+	}
+
+	searchId, err := dbclient.Tables.TicketSearches.Create(ctx.GuildId(), ctx.UserId(), filter)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	msgEmbed, hasNext, err := logic.BuildTicketSearchMessage(ctx, filter, 0)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	_, _ = ctx.ReplyWith(command.MessageResponse{
+		Embeds:     utils.Slice(msgEmbed),
+		Flags:      message.SumFlags(message.FlagEphemeral),
+		Components: logic.BuildTicketSearchComponents(searchId, 0, hasNext),
+	})
+}
+
+func ticketStatusAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	for _, status := range []string{"open", "closed", "all"} {
+		if strings.HasPrefix(status, strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  status,
+				Value: status,
+			})
+		}
+	}
+
+	return
+}
@@ -1,17 +1,24 @@
 package tickets
 
 import (
+	"errors"
 	"fmt"
 	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker/bot/command"
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
 	"github.com/TicketsBot/worker/bot/logic"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/objects/channel/message"
 	"github.com/rxdn/gdl/objects/interaction"
+	"strings"
+	"time"
 )
 
 type TransferCommand struct {
@@ -25,7 +32,9 @@ func (TransferCommand) Properties() registry.Properties {
 		PermissionLevel: permission.Support,
 		Category:        command.Tickets,
 		Arguments: command.Arguments(
-			command.NewRequiredArgument("user", "Support representative to transfer the ticket to", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalArgument("user", "Support representative to transfer the ticket to", interaction.OptionTypeUser, i18n.MessageInvalidUser),
+			command.NewOptionalAutocompleteableArgument("team", "Support team to offer the ticket to, so that anyone on it can accept", interaction.OptionTypeInteger, i18n.MessageTransferInvalidTeam, SupportTeamAutoCompleteHandler),
+			command.NewOptionalArgument("request", "Whether the user must accept the transfer first. Transfers to a team are always requests", interaction.OptionTypeBoolean, i18n.MessageInvalidArgument),
 		),
 	}
 }
@@ -34,7 +43,13 @@ func (c TransferCommand) GetExecutor() interface{} {
 	return c.Execute
 }
 
-func (TransferCommand) Execute(ctx registry.CommandContext, userId uint64) {
+func (TransferCommand) Execute(ctx registry.CommandContext, userId *uint64, teamId *int, request *bool) {
+	if (userId == nil) == (teamId == nil) {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferNoTarget)
+		ctx.Reject()
+		return
+	}
+
 	// Get ticket struct
 	ticket, err := dbclient.Client.Tickets.GetByChannelAndGuild(ctx.ChannelId(), ctx.GuildId())
 	if err != nil {
@@ -61,7 +76,24 @@ func (TransferCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		return
 	}
 
-	member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), userId)
+	if teamId != nil {
+		_, ok, err := dbclient.Client.SupportTeam.GetById(ctx.GuildId(), *teamId)
+		if err != nil {
+			ctx.HandleError(err)
+			return
+		}
+
+		if !ok {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferInvalidTeam)
+			ctx.Reject()
+			return
+		}
+
+		requestTransfer(ctx, ticket, tables.TransferRequest{TargetTeamId: teamId})
+		return
+	}
+
+	member, err := ctx.Worker().GetGuildMember(ctx.GuildId(), *userId)
 	if err != nil {
 		ctx.HandleError(err)
 		return
@@ -79,10 +111,98 @@ func (TransferCommand) Execute(ctx registry.CommandContext, userId uint64) {
 		return
 	}
 
-	if err := logic.ClaimTicket(ctx, ticket, userId); err != nil {
+	if request != nil && *request {
+		requestTransfer(ctx, ticket, tables.TransferRequest{TargetUserId: userId})
+		return
+	}
+
+	if err := logic.ClaimTicket(ctx, ticket, *userId); err != nil {
+		var limitErr logic.ClaimLimitError
+		if errors.As(err, &limitErr) {
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferClaimLimitReached, *userId, limitErr.Limit)
+			return
+		}
+
+		ctx.HandleError(err)
+		return
+	}
+
+	// The ticket has already been transferred, so only the history is missing
+	if err := logic.RecordDirectTransfer(ticket, ctx.UserId(), *userId); err != nil {
+		ctx.HandleWarning(err)
+	}
+
+	historyField, err := logic.BuildTransferHistoryField(ticket.GuildId, ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	ctx.ReplyWithFieldsPermanent(customisation.Green, i18n.TitleClaim, i18n.MessageClaimed, utils.Slice(historyField), fmt.Sprintf("<@%d>", *userId))
+}
+
+func requestTransfer(ctx registry.CommandContext, ticket database.Ticket, request tables.TransferRequest) {
+	pending, err := dbclient.Tables.TransferRequests.HasPending(ticket.GuildId, ticket.Id)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if pending {
+		ctx.Reply(customisation.Red, i18n.Error, i18n.MessageTransferAlreadyPending)
+		ctx.Reject()
+		return
+	}
+
+	request.GuildId = ticket.GuildId
+	request.TicketId = ticket.Id
+	request.RequesterId = ctx.UserId()
+	request.Status = tables.TransferRequestPending
+	request.ExpiresAt = time.Now().Add(logic.TransferRequestTimeout)
+
+	request.Id, err = dbclient.Tables.TransferRequests.Create(request)
+	if err != nil {
+		ctx.HandleError(err)
+		return
+	}
+
+	if err := logic.SendTransferRequest(ctx, ticket, request); err != nil {
+		// Nobody can respond to the request, so don't leave it blocking further requests
+		if _, err := dbclient.Tables.TransferRequests.Resolve(request.Id, tables.TransferRequestCancelled, nil); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+
 		ctx.HandleError(err)
 		return
 	}
 
-	ctx.ReplyPermanent(customisation.Green, i18n.TitleClaim, i18n.MessageClaimed, fmt.Sprintf("<@%d>", userId))
+	ctx.Reply(customisation.Green, i18n.TitleTransferRequest, i18n.MessageTransferRequestSent, message.BuildTimestamp(request.ExpiresAt, message.TimestampStyleRelativeTime))
+	ctx.Accept()
+}
+
+func SupportTeamAutoCompleteHandler(data interaction.ApplicationCommandAutoCompleteInteraction, value string) (choices []interaction.ApplicationCommandOptionChoice) {
+	if data.GuildId.Value == 0 {
+		return nil
+	}
+
+	teams, err := dbclient.Client.SupportTeam.Get(data.GuildId.Value)
+	if err != nil {
+		sentry.Error(err)
+		return nil
+	}
+
+	for _, team := range teams {
+		if len(choices) >= 25 {
+			break
+		}
+
+		if strings.Contains(strings.ToLower(team.Name), strings.ToLower(value)) {
+			choices = append(choices, interaction.ApplicationCommandOptionChoice{
+				Name:  team.Name,
+				Value: team.Id,
+			})
+		}
+	}
+
+	return
 }
@@ -34,21 +34,31 @@ func (cm *CommandManager) RegisterCommands() {
 
 	cm.registry["addadmin"] = settings.AddAdminCommand{}
 	cm.registry["addsupport"] = settings.AddSupportCommand{}
+	cm.registry["anonymise"] = settings.AnonymiseCommand{}
 	cm.registry["autoclose"] = settings.AutoCloseCommand{}
 	cm.registry["blacklist"] = settings.BlacklistCommand{}
+	cm.registry["businesshours"] = settings.BusinessHoursCommand{}
+	cm.registry["claimsettings"] = settings.ClaimSettingsCommand{}
+	cm.registry["feedback"] = settings.FeedbackCommand{}
 	cm.registry["language"] = settings.LanguageCommand{}
+	cm.registry["modmail"] = settings.ModmailCommand{}
+	cm.registry["openlimits"] = settings.OpenLimitsCommand{}
 	cm.registry["panel"] = settings.PanelCommand{}
 	cm.registry["premium"] = settings.PremiumCommand{}
+	cm.registry["queueboard"] = settings.QueueBoardCommand{}
 	cm.registry["removeadmin"] = settings.RemoveAdminCommand{}
 	cm.registry["removesupport"] = settings.RemoveSupportCommand{}
+	cm.registry["routing"] = settings.RoutingCommand{}
 	cm.registry["premium"] = settings.PremiumCommand{}
 	cm.registry["setup"] = setup.SetupCommand{}
 	cm.registry["viewstaff"] = settings.ViewStaffCommand{}
 
 	//cm.registry["sync"] = settings.SyncCommand{}
 	cm.registry["stats"] = statistics.StatsCommand{}
+	cm.registry["export"] = statistics.ExportCommand{}
 
 	cm.registry["managetags"] = tags.ManageTagsCommand{}
+	cm.registry["Save as Tag"] = tags.SaveAsTagCommand{}
 	cm.registry["tag"] = tags.TagCommand{}
 
 	cm.registry["add"] = tickets.AddCommand{}
@@ -59,7 +69,9 @@ func (cm *CommandManager) RegisterCommands() {
 	cm.registry["Start Ticket"] = tickets.StartTicketCommand{}
 	cm.registry["remove"] = tickets.RemoveCommand{}
 	cm.registry["rename"] = tickets.RenameCommand{}
+	cm.registry["reply"] = tickets.ReplyCommand{}
 	cm.registry["switchpanel"] = tickets.SwitchPanelCommand{}
+	cm.registry["tickets"] = tickets.TicketsCommand{}
 	cm.registry["transfer"] = tickets.TransferCommand{}
 	cm.registry["unclaim"] = tickets.UnclaimCommand{}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/rxdn/gdl/objects/interaction"
 	"github.com/rxdn/gdl/objects/interaction/component"
 	"github.com/rxdn/gdl/rest"
+	"github.com/rxdn/gdl/rest/request"
 )
 
 type MessageResponse struct {
@@ -15,6 +16,8 @@ type MessageResponse struct {
 	AllowedMentions message.AllowedMention `json:"allowed_mentions,omitempty"`
 	Flags           uint                   `json:"flags"`
 	Components      []component.Component  `json:"components,omitempty"`
+	// Attachments can only be sent through the REST API, not in the initial interaction response
+	Attachments []request.Attachment `json:"-"`
 }
 
 func NewTextMessageResponse(content string) MessageResponse {
@@ -77,6 +80,7 @@ func (r *MessageResponse) IntoCreateMessageData() rest.CreateMessageData {
 		AllowedMentions: r.AllowedMentions,
 		Flags:           r.Flags,
 		Components:      r.Components,
+		Attachments:     r.Attachments,
 	}
 }
 
@@ -88,6 +92,7 @@ func (r *MessageResponse) IntoWebhookBody() rest.WebhookBody {
 		AllowedMentions: r.AllowedMentions,
 		Flags:           r.Flags,
 		Components:      r.Components,
+		Attachments:     r.Attachments,
 	}
 }
 
@@ -97,6 +102,7 @@ func (r *MessageResponse) IntoWebhookEditBody() rest.WebhookEditBody {
 		Embeds:          r.Embeds,
 		AllowedMentions: r.AllowedMentions,
 		Components:      r.Components,
+		Attachments:     r.Attachments,
 	}
 
 	// Discord API doesn't remove if null
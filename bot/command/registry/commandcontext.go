@@ -13,6 +13,7 @@ import (
 	"github.com/rxdn/gdl/objects/guild"
 	"github.com/rxdn/gdl/objects/member"
 	"github.com/rxdn/gdl/objects/user"
+	"github.com/sirupsen/logrus"
 )
 
 type CommandContext interface {
@@ -48,6 +49,9 @@ type CommandContext interface {
 	HandleError(err error)
 	HandleWarning(err error)
 
+	// Logger carries the fields of the request, e.g. guild, user and command
+	Logger() *logrus.Entry
+
 	GetMessage(messageId i18n.MessageId, format ...interface{}) string
 	GetColour(colour customisation.Colour) int
 
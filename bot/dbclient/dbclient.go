@@ -4,14 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logging"
 	"github.com/TicketsBot/worker/config"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/logrusadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
-	"github.com/sirupsen/logrus"
 )
 
 var Client *database.Database
+var Tables *tables.Tables
 var Pool *pgxpool.Pool
 
 func Connect() {
@@ -29,8 +31,12 @@ func Connect() {
 	}
 
 	// TODO: Sentry
-	cfg.ConnConfig.LogLevel = pgx.LogLevelWarn
-	cfg.ConnConfig.Logger = logrusadapter.NewLogger(logrus.New())
+	// Queries are only logged at the info level, which is required to record their duration
+	cfg.ConnConfig.LogLevel = pgx.LogLevelInfo
+	cfg.ConnConfig.Logger = metricsLogger{
+		logger:   logrusadapter.NewLogger(logging.For("bot/dbclient")),
+		logLevel: pgx.LogLevelWarn,
+	}
 
 	Pool, err = pgxpool.ConnectConfig(context.Background(), cfg)
 	if err != nil {
@@ -38,4 +44,5 @@ func Connect() {
 	}
 
 	Client = database.NewDatabase(Pool)
+	Tables = tables.NewTables(Pool)
 }
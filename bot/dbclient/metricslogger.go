@@ -0,0 +1,25 @@
+package dbclient
+
+import (
+	"context"
+	"github.com/TicketsBot/worker/bot/metrics"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// metricsLogger records the duration of queries, which pgx only exposes through its logger, while still forwarding
+// anything at or above logLevel to the wrapped logger
+type metricsLogger struct {
+	logger   pgx.Logger
+	logLevel pgx.LogLevel
+}
+
+func (l metricsLogger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	if duration, ok := data["time"].(time.Duration); ok {
+		metrics.Timing(metrics.DatabaseDuration, duration, metrics.Tags{"operation": msg})
+	}
+
+	if level <= l.logLevel {
+		l.logger.Log(ctx, level, msg, data)
+	}
+}
@@ -0,0 +1,226 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// BlacklistEntry holds the details of a user or role blacklist. Whether the target is blacklisted at all is still
+// stored in the blacklist and role_blacklist tables, so entries created elsewhere (e.g. the dashboard) have no details.
+type BlacklistEntry struct {
+	GuildId   uint64
+	TargetId  uint64
+	IsRole    bool
+	Reason    *string
+	AddedBy   *uint64
+	AddedAt   *time.Time
+	ExpiresAt *time.Time
+}
+
+type BlacklistEntriesTable struct {
+	*pgxpool.Pool
+}
+
+func newBlacklistEntriesTable(db *pgxpool.Pool) *BlacklistEntriesTable {
+	return &BlacklistEntriesTable{
+		db,
+	}
+}
+
+func (t BlacklistEntriesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS blacklist_entries(
+	"guild_id" int8 NOT NULL,
+	"target_id" int8 NOT NULL,
+	"is_role" bool NOT NULL,
+	"reason" VARCHAR(512) DEFAULT NULL,
+	"added_by" int8 NOT NULL,
+	"added_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	"expires_at" TIMESTAMPTZ DEFAULT NULL,
+	PRIMARY KEY("guild_id", "target_id")
+);
+CREATE INDEX IF NOT EXISTS blacklist_entries_expires_at ON blacklist_entries("expires_at") WHERE "expires_at" IS NOT NULL;
+`
+}
+
+func (t *BlacklistEntriesTable) Get(guildId, targetId uint64) (entry BlacklistEntry, ok bool, err error) {
+	query := `
+SELECT "guild_id", "target_id", "is_role", "reason", "added_by", "added_at", "expires_at"
+FROM blacklist_entries
+WHERE "guild_id" = $1 AND "target_id" = $2;`
+
+	err = t.QueryRow(context.Background(), query, guildId, targetId).Scan(
+		&entry.GuildId,
+		&entry.TargetId,
+		&entry.IsRole,
+		&entry.Reason,
+		&entry.AddedBy,
+		&entry.AddedAt,
+		&entry.ExpiresAt,
+	)
+
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// GetForUser returns the entry that applies to a user, preferring a user blacklist over the blacklist of any of their
+// roles. The returned entry may be for a target that is no longer blacklisted.
+func (t *BlacklistEntriesTable) GetForUser(guildId, userId uint64, roles []uint64) (entry BlacklistEntry, ok bool, err error) {
+	query := `
+SELECT "guild_id", "target_id", "is_role", "reason", "added_by", "added_at", "expires_at"
+FROM blacklist_entries
+WHERE "guild_id" = $1 AND (("target_id" = $2 AND NOT "is_role") OR ("target_id" = ANY($3) AND "is_role"))
+ORDER BY "is_role" ASC, "expires_at" DESC NULLS FIRST
+LIMIT 1;`
+
+	err = t.QueryRow(context.Background(), query, guildId, userId, roles).Scan(
+		&entry.GuildId,
+		&entry.TargetId,
+		&entry.IsRole,
+		&entry.Reason,
+		&entry.AddedBy,
+		&entry.AddedAt,
+		&entry.ExpiresAt,
+	)
+
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// GetPage returns blacklisted users followed by blacklisted roles, joined with their details where they exist
+func (t *BlacklistEntriesTable) GetPage(guildId uint64, limit, offset int) ([]BlacklistEntry, error) {
+	query := `
+SELECT $1::int8, blacklisted.target_id, blacklisted.is_role, blacklist_entries.reason, blacklist_entries.added_by, blacklist_entries.added_at, blacklist_entries.expires_at
+FROM (
+	SELECT "user_id" AS target_id, false AS is_role FROM blacklist WHERE "guild_id" = $1
+	UNION ALL
+	SELECT "role_id" AS target_id, true AS is_role FROM role_blacklist WHERE "guild_id" = $1
+) AS blacklisted
+LEFT OUTER JOIN blacklist_entries
+ON blacklist_entries.guild_id = $1 AND blacklist_entries.target_id = blacklisted.target_id
+ORDER BY blacklisted.is_role ASC, blacklisted.target_id ASC
+LIMIT $2 OFFSET $3;`
+
+	rows, err := t.Query(context.Background(), query, guildId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var entries []BlacklistEntry
+	for rows.Next() {
+		var entry BlacklistEntry
+		if err := rows.Scan(
+			&entry.GuildId,
+			&entry.TargetId,
+			&entry.IsRole,
+			&entry.Reason,
+			&entry.AddedBy,
+			&entry.AddedAt,
+			&entry.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetBlacklistedRoleCount returns how many roles are blacklisted in the guild, whether or not they have an entry
+func (t *BlacklistEntriesTable) GetBlacklistedRoleCount(guildId uint64) (count int, err error) {
+	query := `SELECT COUNT(*) FROM role_blacklist WHERE "guild_id" = $1;`
+	err = t.QueryRow(context.Background(), query, guildId).Scan(&count)
+	return
+}
+
+func (t *BlacklistEntriesTable) Set(entry BlacklistEntry) (err error) {
+	query := `
+INSERT INTO blacklist_entries("guild_id", "target_id", "is_role", "reason", "added_by", "added_at", "expires_at")
+VALUES($1, $2, $3, $4, $5, NOW(), $6)
+ON CONFLICT("guild_id", "target_id") DO UPDATE SET
+	"is_role" = $3,
+	"reason" = $4,
+	"added_by" = $5,
+	"added_at" = NOW(),
+	"expires_at" = $6;`
+
+	_, err = t.Exec(context.Background(), query,
+		entry.GuildId,
+		entry.TargetId,
+		entry.IsRole,
+		entry.Reason,
+		entry.AddedBy,
+		entry.ExpiresAt,
+	)
+
+	return
+}
+
+func (t *BlacklistEntriesTable) Delete(guildId, targetId uint64) (err error) {
+	query := `DELETE FROM blacklist_entries WHERE "guild_id" = $1 AND "target_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, targetId)
+	return
+}
+
+// LiftExpired lifts the blacklists of all entries that have expired, removing and returning the entries. The entries
+// and the blacklists are deleted in the same statement, so either both are removed or neither is, and each expired
+// entry is only ever returned to a single worker.
+func (t *BlacklistEntriesTable) LiftExpired() ([]BlacklistEntry, error) {
+	query := `
+WITH expired AS (
+	DELETE FROM blacklist_entries
+	WHERE "expires_at" IS NOT NULL AND "expires_at" <= NOW()
+	RETURNING "guild_id", "target_id", "is_role", "reason", "added_by", "added_at", "expires_at"
+), lifted_users AS (
+	DELETE FROM blacklist
+	USING expired
+	WHERE blacklist."guild_id" = expired."guild_id" AND blacklist."user_id" = expired."target_id" AND NOT expired."is_role"
+), lifted_roles AS (
+	DELETE FROM role_blacklist
+	USING expired
+	WHERE role_blacklist."guild_id" = expired."guild_id" AND role_blacklist."role_id" = expired."target_id" AND expired."is_role"
+)
+SELECT "guild_id", "target_id", "is_role", "reason", "added_by", "added_at", "expires_at" FROM expired;`
+
+	rows, err := t.Query(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var entries []BlacklistEntry
+	for rows.Next() {
+		var entry BlacklistEntry
+		if err := rows.Scan(
+			&entry.GuildId,
+			&entry.TargetId,
+			&entry.IsRole,
+			&entry.Reason,
+			&entry.AddedBy,
+			&entry.AddedAt,
+			&entry.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
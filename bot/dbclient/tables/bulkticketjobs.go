@@ -0,0 +1,149 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type BulkAction int16
+
+const (
+	BulkActionClose BulkAction = iota
+	BulkActionClaim
+	BulkActionLabel
+	BulkActionMove
+)
+
+type BulkJobStatus int16
+
+const (
+	BulkJobPending BulkJobStatus = iota
+	BulkJobRunning
+	BulkJobCancelled
+	BulkJobCompleted
+)
+
+// BulkTicketJob is a bulk operation on a fixed set of tickets. Argument holds the close reason, the label, or the ID of
+// the panel to move the tickets to, depending on the action.
+type BulkTicketJob struct {
+	Id        int
+	GuildId   uint64
+	UserId    uint64
+	Action    BulkAction
+	TicketIds []int
+	Argument  *string
+	Status    BulkJobStatus
+	CreatedAt time.Time
+}
+
+// BulkTicketJobsTable stores bulk operations from the preview until they have finished. As the confirm and cancel
+// buttons may be handled by a different worker to the one running the job, the status is the source of truth for
+// whether a job should continue.
+type BulkTicketJobsTable struct {
+	*pgxpool.Pool
+}
+
+func newBulkTicketJobsTable(db *pgxpool.Pool) *BulkTicketJobsTable {
+	return &BulkTicketJobsTable{
+		db,
+	}
+}
+
+func (t BulkTicketJobsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS bulk_ticket_jobs(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"action" int2 NOT NULL,
+	"ticket_ids" int4[] NOT NULL,
+	"argument" text DEFAULT NULL,
+	"status" int2 NOT NULL,
+	"created_at" TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS bulk_ticket_jobs_created_at ON bulk_ticket_jobs("created_at");
+`
+}
+
+func (t *BulkTicketJobsTable) Get(id int) (job BulkTicketJob, ok bool, err error) {
+	query := `
+SELECT "id", "guild_id", "user_id", "action", "ticket_ids", "argument", "status", "created_at"
+FROM bulk_ticket_jobs
+WHERE "id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, id).Scan(
+		&job.Id, &job.GuildId, &job.UserId, &job.Action, &job.TicketIds, &job.Argument, &job.Status, &job.CreatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return BulkTicketJob{}, false, nil
+	} else if err != nil {
+		return BulkTicketJob{}, false, err
+	}
+
+	return job, true, nil
+}
+
+func (t *BulkTicketJobsTable) GetStatus(id int) (status BulkJobStatus, err error) {
+	query := `SELECT "status" FROM bulk_ticket_jobs WHERE "id" = $1;`
+	err = t.QueryRow(context.Background(), query, id).Scan(&status)
+	return
+}
+
+// Create stores a new pending job, returning its ID
+func (t *BulkTicketJobsTable) Create(job BulkTicketJob) (id int, err error) {
+	query := `
+INSERT INTO bulk_ticket_jobs("guild_id", "user_id", "action", "ticket_ids", "argument", "status", "created_at")
+VALUES($1, $2, $3, $4, $5, $6, NOW())
+RETURNING "id";`
+
+	err = t.QueryRow(context.Background(), query, job.GuildId, job.UserId, job.Action, job.TicketIds, job.Argument, BulkJobPending).Scan(&id)
+	return
+}
+
+// Start marks a pending job as running, if it was created after notBefore. Returns false if the job has already been
+// started, cancelled or has expired, so that a job can never be run twice.
+func (t *BulkTicketJobsTable) Start(id int, notBefore time.Time) (bool, error) {
+	query := `
+UPDATE bulk_ticket_jobs
+SET "status" = $2
+WHERE "id" = $1 AND "status" = $3 AND "created_at" > $4;`
+
+	res, err := t.Exec(context.Background(), query, id, BulkJobRunning, BulkJobPending, notBefore)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Cancel returns false if the job had already finished
+func (t *BulkTicketJobsTable) Cancel(id int) (bool, error) {
+	query := `
+UPDATE bulk_ticket_jobs
+SET "status" = $2
+WHERE "id" = $1 AND "status" IN ($3, $4);`
+
+	res, err := t.Exec(context.Background(), query, id, BulkJobCancelled, BulkJobPending, BulkJobRunning)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (t *BulkTicketJobsTable) Complete(id int) (err error) {
+	query := `UPDATE bulk_ticket_jobs SET "status" = $2 WHERE "id" = $1 AND "status" = $3;`
+	_, err = t.Exec(context.Background(), query, id, BulkJobCompleted, BulkJobRunning)
+	return
+}
+
+// DeleteBefore removes jobs that can no longer be confirmed or cancelled
+func (t *BulkTicketJobsTable) DeleteBefore(before time.Time) (err error) {
+	query := `DELETE FROM bulk_ticket_jobs WHERE "created_at" < $1 AND "status" != $2;`
+	_, err = t.Exec(context.Background(), query, before, BulkJobRunning)
+	return
+}
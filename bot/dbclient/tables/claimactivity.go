@@ -0,0 +1,117 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type ClaimActivity struct {
+	GuildId      uint64
+	TicketId     int
+	ClaimerId    uint64
+	TimeoutHours int
+}
+
+// ClaimActivityTable stores when each ticket was claimed, and when the claimer last replied, for claim timeouts
+type ClaimActivityTable struct {
+	*pgxpool.Pool
+}
+
+func newClaimActivityTable(db *pgxpool.Pool) *ClaimActivityTable {
+	return &ClaimActivityTable{
+		db,
+	}
+}
+
+func (t ClaimActivityTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS claim_activity(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"claimer_id" int8 NOT NULL,
+	"claimed_at" TIMESTAMPTZ NOT NULL,
+	"last_reply_at" TIMESTAMPTZ DEFAULT NULL,
+	"locked_at" TIMESTAMPTZ DEFAULT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+CREATE INDEX IF NOT EXISTS claim_activity_guild_id ON claim_activity("guild_id");
+`
+}
+
+// Set restarts the timeout for the ticket's new claimer
+func (t *ClaimActivityTable) Set(guildId uint64, ticketId int, claimerId uint64) (err error) {
+	query := `
+INSERT INTO claim_activity("guild_id", "ticket_id", "claimer_id", "claimed_at", "last_reply_at", "locked_at")
+VALUES($1, $2, $3, NOW(), NULL, NULL)
+ON CONFLICT("guild_id", "ticket_id") DO UPDATE SET "claimer_id" = $3, "claimed_at" = NOW(), "last_reply_at" = NULL, "locked_at" = NULL;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, claimerId)
+	return
+}
+
+// RecordReply restarts the timeout if the user is the ticket's claimer
+func (t *ClaimActivityTable) RecordReply(guildId uint64, ticketId int, userId uint64, at time.Time) (err error) {
+	query := `
+UPDATE claim_activity
+SET "last_reply_at" = $4
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "claimer_id" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, userId, at)
+	return
+}
+
+func (t *ClaimActivityTable) Delete(guildId uint64, ticketId int) (err error) {
+	query := `DELETE FROM claim_activity WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, ticketId)
+	return
+}
+
+// claimTimeoutLockDuration is how long a timed out claim is locked to the worker unclaiming it, after which another
+// worker may retry it, in case the worker that locked it stopped part way through
+const claimTimeoutLockDuration = "15 minutes"
+
+// LockTimedOut locks and returns the claims of open tickets whose claimer has not replied within the guild's timeout,
+// so that each is only returned to one worker. The row is removed when the ticket is unclaimed, and should be unlocked
+// with Unlock if unclaiming fails, so that it is retried.
+func (t *ClaimActivityTable) LockTimedOut() ([]ClaimActivity, error) {
+	query := `
+UPDATE claim_activity
+SET "locked_at" = NOW()
+FROM claim_limits, tickets
+WHERE claim_activity."guild_id" = claim_limits."guild_id"
+	AND claim_activity."guild_id" = tickets."guild_id"
+	AND claim_activity."ticket_id" = tickets."id"
+	AND tickets."open" = TRUE
+	AND claim_limits."timeout_hours" IS NOT NULL
+	AND COALESCE(claim_activity."last_reply_at", claim_activity."claimed_at") < NOW() - make_interval(hours => claim_limits."timeout_hours")
+	AND (claim_activity."locked_at" IS NULL OR claim_activity."locked_at" < NOW() - $1::interval)
+RETURNING claim_activity."guild_id", claim_activity."ticket_id", claim_activity."claimer_id", claim_limits."timeout_hours";`
+
+	rows, err := t.Query(context.Background(), query, claimTimeoutLockDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var timedOut []ClaimActivity
+	for rows.Next() {
+		var activity ClaimActivity
+		if err := rows.Scan(&activity.GuildId, &activity.TicketId, &activity.ClaimerId, &activity.TimeoutHours); err != nil {
+			return nil, err
+		}
+
+		timedOut = append(timedOut, activity)
+	}
+
+	return timedOut, rows.Err()
+}
+
+// Unlock allows a timed out claim to be returned by LockTimedOut again
+func (t *ClaimActivityTable) Unlock(guildId uint64, ticketId int) (err error) {
+	query := `UPDATE claim_activity SET "locked_at" = NULL WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, ticketId)
+	return
+}
@@ -0,0 +1,71 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ClaimLimits are nil when there is no limit or timeout
+type ClaimLimits struct {
+	MaxConcurrentClaims *int
+	TimeoutHours        *int
+}
+
+type ClaimLimitsTable struct {
+	*pgxpool.Pool
+}
+
+func newClaimLimitsTable(db *pgxpool.Pool) *ClaimLimitsTable {
+	return &ClaimLimitsTable{
+		db,
+	}
+}
+
+func (t ClaimLimitsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS claim_limits(
+	"guild_id" int8 NOT NULL,
+	"max_concurrent_claims" int4 DEFAULT NULL,
+	"timeout_hours" int4 DEFAULT NULL,
+	PRIMARY KEY("guild_id")
+);
+`
+}
+
+func (t *ClaimLimitsTable) Get(guildId uint64) (settings ClaimLimits, err error) {
+	query := `SELECT "max_concurrent_claims", "timeout_hours" FROM claim_limits WHERE "guild_id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, guildId).Scan(&settings.MaxConcurrentClaims, &settings.TimeoutHours)
+	if err == pgx.ErrNoRows {
+		return ClaimLimits{}, nil
+	}
+
+	return
+}
+
+func (t *ClaimLimitsTable) Set(guildId uint64, settings ClaimLimits) (err error) {
+	query := `
+INSERT INTO claim_limits("guild_id", "max_concurrent_claims", "timeout_hours")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET "max_concurrent_claims" = $2, "timeout_hours" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, settings.MaxConcurrentClaims, settings.TimeoutHours)
+	return
+}
+
+// CountClaimedBy returns the number of open tickets the user has claimed, other than the given ticket
+func (t *ClaimLimitsTable) CountClaimedBy(guildId, userId uint64, excludeTicketId int) (count int, err error) {
+	query := `
+SELECT COUNT(*)
+FROM ticket_claims
+INNER JOIN tickets
+ON ticket_claims."guild_id" = tickets."guild_id" AND ticket_claims."ticket_id" = tickets."id"
+WHERE ticket_claims."guild_id" = $1
+	AND ticket_claims."user_id" = $2
+	AND ticket_claims."ticket_id" != $3
+	AND tickets."open" = TRUE;`
+
+	err = t.QueryRow(context.Background(), query, guildId, userId, excludeTicketId).Scan(&count)
+	return
+}
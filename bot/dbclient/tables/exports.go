@@ -0,0 +1,212 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type TicketExportRecord struct {
+	Id                       int
+	OpenerId                 uint64
+	ClaimerId                *uint64
+	PanelId                  *int
+	PanelTitle               *string
+	OpenTime                 time.Time
+	CloseTime                *time.Time
+	CloseReason              *string
+	ClosedBy                 *uint64
+	Rating                   *int16
+	FirstResponderId         *uint64
+	FirstResponseTimeSeconds *float64
+}
+
+type RatingExportRecord struct {
+	TicketId  int
+	OpenerId  uint64
+	ClaimerId *uint64
+	Rating    int16
+	CloseTime *time.Time
+}
+
+type StaffExportRecord struct {
+	UserId                          uint64
+	TicketsAnswered                 int
+	TicketsClaimed                  int
+	RatingCount                     int
+	RatingAverage                   *float64
+	AverageFirstResponseTimeSeconds *float64
+}
+
+// ExportQueries reads the tables owned by github.com/TicketsBot/database in pages, so that exports of large guilds
+// never need to hold every row in memory at once. It has no schema of its own.
+type ExportQueries struct {
+	*pgxpool.Pool
+}
+
+func newExportQueries(db *pgxpool.Pool) *ExportQueries {
+	return &ExportQueries{
+		db,
+	}
+}
+
+// GetTicketsPage returns up to limit tickets opened within [from, to) with an ID greater than afterId, in ID order
+func (q *ExportQueries) GetTicketsPage(guildId uint64, from, to time.Time, afterId, limit int) ([]TicketExportRecord, error) {
+	query := `
+SELECT
+	tickets."id",
+	tickets."user_id",
+	ticket_claims."user_id",
+	tickets."panel_id",
+	panels."title",
+	tickets."open_time",
+	tickets."close_time",
+	close_reason."close_reason",
+	close_reason."closed_by",
+	service_ratings."rating",
+	first_response_time."user_id",
+	EXTRACT(EPOCH FROM first_response_time."response_time")::float8
+FROM tickets
+LEFT OUTER JOIN ticket_claims
+ON tickets."guild_id" = ticket_claims."guild_id" AND tickets."id" = ticket_claims."ticket_id"
+LEFT OUTER JOIN panels
+ON tickets."panel_id" = panels."panel_id"
+LEFT OUTER JOIN close_reason
+ON tickets."guild_id" = close_reason."guild_id" AND tickets."id" = close_reason."ticket_id"
+LEFT OUTER JOIN service_ratings
+ON tickets."guild_id" = service_ratings."guild_id" AND tickets."id" = service_ratings."ticket_id"
+LEFT OUTER JOIN first_response_time
+ON tickets."guild_id" = first_response_time."guild_id" AND tickets."id" = first_response_time."ticket_id"
+WHERE tickets."guild_id" = $1 AND tickets."open_time" >= $2 AND tickets."open_time" < $3 AND tickets."id" > $4
+ORDER BY tickets."id" ASC
+LIMIT $5;`
+
+	rows, err := q.Query(context.Background(), query, guildId, from, to, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var records []TicketExportRecord
+	for rows.Next() {
+		var record TicketExportRecord
+		if err := rows.Scan(
+			&record.Id,
+			&record.OpenerId,
+			&record.ClaimerId,
+			&record.PanelId,
+			&record.PanelTitle,
+			&record.OpenTime,
+			&record.CloseTime,
+			&record.CloseReason,
+			&record.ClosedBy,
+			&record.Rating,
+			&record.FirstResponderId,
+			&record.FirstResponseTimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// GetRatingsPage returns up to limit ratings for tickets closed within [from, to) with a ticket ID greater than afterId,
+// in ticket ID order
+func (q *ExportQueries) GetRatingsPage(guildId uint64, from, to time.Time, afterId, limit int) ([]RatingExportRecord, error) {
+	query := `
+SELECT tickets."id", tickets."user_id", ticket_claims."user_id", service_ratings."rating", tickets."close_time"
+FROM service_ratings
+INNER JOIN tickets
+ON service_ratings."guild_id" = tickets."guild_id" AND service_ratings."ticket_id" = tickets."id"
+LEFT OUTER JOIN ticket_claims
+ON tickets."guild_id" = ticket_claims."guild_id" AND tickets."id" = ticket_claims."ticket_id"
+WHERE service_ratings."guild_id" = $1 AND tickets."close_time" >= $2 AND tickets."close_time" < $3 AND tickets."id" > $4
+ORDER BY tickets."id" ASC
+LIMIT $5;`
+
+	rows, err := q.Query(context.Background(), query, guildId, from, to, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var records []RatingExportRecord
+	for rows.Next() {
+		var record RatingExportRecord
+		if err := rows.Scan(&record.TicketId, &record.OpenerId, &record.ClaimerId, &record.Rating, &record.CloseTime); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// GetStaffPage returns up to limit staff members who answered or claimed a ticket opened within [from, to), with a user
+// ID greater than afterUserId, in user ID order
+func (q *ExportQueries) GetStaffPage(guildId uint64, from, to time.Time, afterUserId uint64, limit int) ([]StaffExportRecord, error) {
+	query := `
+WITH period_tickets AS (
+	SELECT "id"
+	FROM tickets
+	WHERE "guild_id" = $1 AND "open_time" >= $2 AND "open_time" < $3
+), answered AS (
+	SELECT first_response_time."user_id", COUNT(*) AS count, AVG(EXTRACT(EPOCH FROM first_response_time."response_time"))::float8 AS response_time
+	FROM first_response_time
+	INNER JOIN period_tickets ON first_response_time."ticket_id" = period_tickets."id"
+	WHERE first_response_time."guild_id" = $1
+	GROUP BY first_response_time."user_id"
+), claimed AS (
+	SELECT ticket_claims."user_id", COUNT(*) AS count, COUNT(service_ratings."rating") AS rating_count, AVG(service_ratings."rating")::float8 AS rating
+	FROM ticket_claims
+	INNER JOIN period_tickets ON ticket_claims."ticket_id" = period_tickets."id"
+	LEFT OUTER JOIN service_ratings
+	ON ticket_claims."guild_id" = service_ratings."guild_id" AND ticket_claims."ticket_id" = service_ratings."ticket_id"
+	WHERE ticket_claims."guild_id" = $1
+	GROUP BY ticket_claims."user_id"
+)
+SELECT
+	COALESCE(answered."user_id", claimed."user_id") AS user_id,
+	COALESCE(answered.count, 0),
+	COALESCE(claimed.count, 0),
+	COALESCE(claimed.rating_count, 0),
+	claimed.rating,
+	answered.response_time
+FROM answered
+FULL OUTER JOIN claimed ON answered."user_id" = claimed."user_id"
+WHERE COALESCE(answered."user_id", claimed."user_id") > $4
+ORDER BY user_id ASC
+LIMIT $5;`
+
+	rows, err := q.Query(context.Background(), query, guildId, from, to, afterUserId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var records []StaffExportRecord
+	for rows.Next() {
+		var record StaffExportRecord
+		if err := rows.Scan(
+			&record.UserId,
+			&record.TicketsAnswered,
+			&record.TicketsClaimed,
+			&record.RatingCount,
+			&record.RatingAverage,
+			&record.AverageFirstResponseTimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
@@ -0,0 +1,150 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ValidationFormat int16
+
+const (
+	ValidationFormatText ValidationFormat = iota
+	ValidationFormatInteger
+	ValidationFormatDecimal
+	ValidationFormatEmail
+	ValidationFormatUrl
+)
+
+type FormInputValidation struct {
+	FormInputId   int
+	Format        ValidationFormat
+	Pattern       *string
+	MinLength     *int
+	MaxLength     *int
+	MinValue      *float64
+	MaxValue      *float64
+	AllowedValues []string
+}
+
+type FormInputValidationTable struct {
+	*pgxpool.Pool
+}
+
+func newFormInputValidationTable(db *pgxpool.Pool) *FormInputValidationTable {
+	return &FormInputValidationTable{
+		db,
+	}
+}
+
+func (t FormInputValidationTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS form_input_validation(
+	"form_input_id" int4 NOT NULL,
+	"format" int2 NOT NULL DEFAULT 0,
+	"pattern" VARCHAR(255) DEFAULT NULL,
+	"min_length" int4 DEFAULT NULL,
+	"max_length" int4 DEFAULT NULL,
+	"min_value" float8 DEFAULT NULL,
+	"max_value" float8 DEFAULT NULL,
+	"allowed_values" VARCHAR(100)[] DEFAULT NULL,
+	FOREIGN KEY("form_input_id") REFERENCES form_input("id") ON DELETE CASCADE,
+	PRIMARY KEY("form_input_id")
+);
+`
+}
+
+func (t *FormInputValidationTable) Get(formInputId int) (validation FormInputValidation, ok bool, err error) {
+	query := `
+SELECT "form_input_id", "format", "pattern", "min_length", "max_length", "min_value", "max_value", "allowed_values"
+FROM form_input_validation
+WHERE "form_input_id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, formInputId).Scan(
+		&validation.FormInputId,
+		&validation.Format,
+		&validation.Pattern,
+		&validation.MinLength,
+		&validation.MaxLength,
+		&validation.MinValue,
+		&validation.MaxValue,
+		&validation.AllowedValues,
+	)
+
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// GetAllForInputs returns a map of form_input_id -> validation rules. Inputs without any rules are omitted.
+func (t *FormInputValidationTable) GetAllForInputs(formInputIds []int) (map[int]FormInputValidation, error) {
+	query := `
+SELECT "form_input_id", "format", "pattern", "min_length", "max_length", "min_value", "max_value", "allowed_values"
+FROM form_input_validation
+WHERE "form_input_id" = ANY($1);`
+
+	rows, err := t.Query(context.Background(), query, formInputIds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	validations := make(map[int]FormInputValidation)
+	for rows.Next() {
+		var validation FormInputValidation
+		if err := rows.Scan(
+			&validation.FormInputId,
+			&validation.Format,
+			&validation.Pattern,
+			&validation.MinLength,
+			&validation.MaxLength,
+			&validation.MinValue,
+			&validation.MaxValue,
+			&validation.AllowedValues,
+		); err != nil {
+			return nil, err
+		}
+
+		validations[validation.FormInputId] = validation
+	}
+
+	return validations, rows.Err()
+}
+
+func (t *FormInputValidationTable) Set(validation FormInputValidation) (err error) {
+	query := `
+INSERT INTO form_input_validation("form_input_id", "format", "pattern", "min_length", "max_length", "min_value", "max_value", "allowed_values")
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT("form_input_id") DO UPDATE SET
+	"format" = $2,
+	"pattern" = $3,
+	"min_length" = $4,
+	"max_length" = $5,
+	"min_value" = $6,
+	"max_value" = $7,
+	"allowed_values" = $8;`
+
+	_, err = t.Exec(context.Background(), query,
+		validation.FormInputId,
+		validation.Format,
+		validation.Pattern,
+		validation.MinLength,
+		validation.MaxLength,
+		validation.MinValue,
+		validation.MaxValue,
+		validation.AllowedValues,
+	)
+
+	return
+}
+
+func (t *FormInputValidationTable) Delete(formInputId int) (err error) {
+	query := `DELETE FROM form_input_validation WHERE "form_input_id" = $1;`
+	_, err = t.Exec(context.Background(), query, formInputId)
+	return
+}
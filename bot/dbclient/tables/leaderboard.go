@@ -0,0 +1,137 @@
+package tables
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardMetricAnswered     LeaderboardMetric = "answered"
+	LeaderboardMetricClaimed      LeaderboardMetric = "claimed"
+	LeaderboardMetricRating       LeaderboardMetric = "rating"
+	LeaderboardMetricResponseTime LeaderboardMetric = "response_time"
+)
+
+var LeaderboardMetrics = []LeaderboardMetric{
+	LeaderboardMetricAnswered,
+	LeaderboardMetricClaimed,
+	LeaderboardMetricRating,
+	LeaderboardMetricResponseTime,
+}
+
+// leaderboardOrdering must only ever contain constants, as it is interpolated into the query
+var leaderboardOrdering = map[LeaderboardMetric]string{
+	LeaderboardMetricAnswered:     `tickets_answered DESC`,
+	LeaderboardMetricClaimed:      `tickets_claimed DESC`,
+	LeaderboardMetricRating:       `rating_average DESC NULLS LAST, rating_count DESC`,
+	LeaderboardMetricResponseTime: `response_time ASC NULLS LAST`,
+}
+
+type LeaderboardEntry struct {
+	UserId                          uint64
+	TicketsAnswered                 int
+	TicketsClaimed                  int
+	RatingCount                     int
+	RatingAverage                   *float64
+	AverageFirstResponseTimeSeconds *float64
+}
+
+type PanelTotals struct {
+	Opened int
+	Closed int
+}
+
+// LeaderboardQueries ranks staff by their activity on tickets, optionally on a single panel, using the tables owned by
+// github.com/TicketsBot/database. It has no schema of its own.
+type LeaderboardQueries struct {
+	*pgxpool.Pool
+}
+
+func newLeaderboardQueries(db *pgxpool.Pool) *LeaderboardQueries {
+	return &LeaderboardQueries{
+		db,
+	}
+}
+
+// Get returns up to limit staff members who answered or claimed a ticket opened since from, ranked by metric. If panelId
+// is not nil, only tickets opened from that panel are counted.
+func (q *LeaderboardQueries) Get(guildId uint64, panelId *int, from time.Time, metric LeaderboardMetric, offset, limit int) ([]LeaderboardEntry, error) {
+	ordering, ok := leaderboardOrdering[metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard metric %s", metric)
+	}
+
+	query := `
+WITH period_tickets AS (
+	SELECT "id"
+	FROM tickets
+	WHERE "guild_id" = $1 AND "open_time" >= $2 AND ($3::int IS NULL OR "panel_id" = $3)
+), answered AS (
+	SELECT first_response_time."user_id", COUNT(*) AS count, AVG(EXTRACT(EPOCH FROM first_response_time."response_time"))::float8 AS response_time
+	FROM first_response_time
+	INNER JOIN period_tickets ON first_response_time."ticket_id" = period_tickets."id"
+	WHERE first_response_time."guild_id" = $1
+	GROUP BY first_response_time."user_id"
+), claimed AS (
+	SELECT ticket_claims."user_id", COUNT(*) AS count, COUNT(service_ratings."rating") AS rating_count, AVG(service_ratings."rating")::float8 AS rating
+	FROM ticket_claims
+	INNER JOIN period_tickets ON ticket_claims."ticket_id" = period_tickets."id"
+	LEFT OUTER JOIN service_ratings
+	ON ticket_claims."guild_id" = service_ratings."guild_id" AND ticket_claims."ticket_id" = service_ratings."ticket_id"
+	WHERE ticket_claims."guild_id" = $1
+	GROUP BY ticket_claims."user_id"
+)
+SELECT
+	COALESCE(answered."user_id", claimed."user_id") AS user_id,
+	COALESCE(answered.count, 0) AS tickets_answered,
+	COALESCE(claimed.count, 0) AS tickets_claimed,
+	COALESCE(claimed.rating_count, 0) AS rating_count,
+	claimed.rating AS rating_average,
+	answered.response_time AS response_time
+FROM answered
+FULL OUTER JOIN claimed ON answered."user_id" = claimed."user_id"
+ORDER BY ` + ordering + `, user_id ASC
+OFFSET $4
+LIMIT $5;`
+
+	rows, err := q.Query(context.Background(), query, guildId, from, panelId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var entries []LeaderboardEntry
+	for rows.Next() {
+		var entry LeaderboardEntry
+		if err := rows.Scan(
+			&entry.UserId,
+			&entry.TicketsAnswered,
+			&entry.TicketsClaimed,
+			&entry.RatingCount,
+			&entry.RatingAverage,
+			&entry.AverageFirstResponseTimeSeconds,
+		); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetPanelTotals returns the number of tickets opened from the panel since from, and how many of those have been closed
+func (q *LeaderboardQueries) GetPanelTotals(guildId uint64, panelId int, from time.Time) (totals PanelTotals, err error) {
+	query := `
+SELECT COUNT(*), COUNT("close_time")
+FROM tickets
+WHERE "guild_id" = $1 AND "panel_id" = $2 AND "open_time" >= $3;`
+
+	err = q.QueryRow(context.Background(), query, guildId, panelId, from).Scan(&totals.Opened, &totals.Closed)
+	return
+}
@@ -0,0 +1,109 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// ModmailSession links a user's DMs with a bot to the ticket that their messages are relayed into. A user may only
+// have one session per bot at a time, as there is no other way to tell which ticket a DM is intended for. The session
+// is reserved before the ticket is opened, so that a user can't open two tickets at once, and rows without a ticket
+// are not returned by the getters.
+type ModmailSession struct {
+	BotId    uint64
+	UserId   uint64
+	GuildId  uint64
+	TicketId int
+}
+
+type ModmailSessionsTable struct {
+	*pgxpool.Pool
+}
+
+func newModmailSessionsTable(db *pgxpool.Pool) *ModmailSessionsTable {
+	return &ModmailSessionsTable{
+		db,
+	}
+}
+
+func (t ModmailSessionsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS modmail_sessions(
+	"bot_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 DEFAULT NULL,
+	"reserved_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	UNIQUE("guild_id", "ticket_id"),
+	PRIMARY KEY("bot_id", "user_id")
+);
+`
+}
+
+func (t *ModmailSessionsTable) GetByUser(botId, userId uint64) (session ModmailSession, ok bool, e error) {
+	query := `SELECT "bot_id", "user_id", "guild_id", "ticket_id" FROM modmail_sessions WHERE "bot_id" = $1 AND "user_id" = $2 AND "ticket_id" IS NOT NULL;`
+
+	err := t.QueryRow(context.Background(), query, botId, userId).Scan(&session.BotId, &session.UserId, &session.GuildId, &session.TicketId)
+	if err == nil {
+		ok = true
+	} else if err != pgx.ErrNoRows {
+		e = err
+	}
+
+	return
+}
+
+func (t *ModmailSessionsTable) GetByTicket(guildId uint64, ticketId int) (session ModmailSession, ok bool, e error) {
+	query := `SELECT "bot_id", "user_id", "guild_id", "ticket_id" FROM modmail_sessions WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	err := t.QueryRow(context.Background(), query, guildId, ticketId).Scan(&session.BotId, &session.UserId, &session.GuildId, &session.TicketId)
+	if err == nil {
+		ok = true
+	} else if err != pgx.ErrNoRows {
+		e = err
+	}
+
+	return
+}
+
+// modmailReservationTimeout is how long a session may be reserved without a ticket before another attempt to open a
+// ticket may take it over, in case the worker that reserved it stopped part way through
+const modmailReservationTimeout = "5 minutes"
+
+// Reserve returns false if the user already has a session with the bot, or is already opening a ticket
+func (t *ModmailSessionsTable) Reserve(botId, userId, guildId uint64) (bool, error) {
+	query := `
+INSERT INTO modmail_sessions("bot_id", "user_id", "guild_id", "ticket_id", "reserved_at")
+VALUES($1, $2, $3, NULL, NOW())
+ON CONFLICT("bot_id", "user_id") DO UPDATE SET "guild_id" = $3, "reserved_at" = NOW()
+WHERE modmail_sessions."ticket_id" IS NULL AND modmail_sessions."reserved_at" < NOW() - $4::interval;`
+
+	res, err := t.Exec(context.Background(), query, botId, userId, guildId, modmailReservationTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// SetTicket completes a reservation, once the ticket has been opened
+func (t *ModmailSessionsTable) SetTicket(botId, userId uint64, ticketId int) (err error) {
+	query := `UPDATE modmail_sessions SET "ticket_id" = $3 WHERE "bot_id" = $1 AND "user_id" = $2 AND "ticket_id" IS NULL;`
+	_, err = t.Exec(context.Background(), query, botId, userId, ticketId)
+	return
+}
+
+// Release removes a reservation, if opening the ticket failed
+func (t *ModmailSessionsTable) Release(botId, userId uint64) (err error) {
+	query := `DELETE FROM modmail_sessions WHERE "bot_id" = $1 AND "user_id" = $2 AND "ticket_id" IS NULL;`
+	_, err = t.Exec(context.Background(), query, botId, userId)
+	return
+}
+
+func (t *ModmailSessionsTable) DeleteByTicket(guildId uint64, ticketId int) (err error) {
+	query := `DELETE FROM modmail_sessions WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, ticketId)
+	return
+}
@@ -0,0 +1,79 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ModmailSettings struct {
+	Enabled bool
+	// If set, staff replies are relayed to the user under the server's name rather than the staff member's
+	AnonymiseStaff bool
+}
+
+type ModmailSettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newModmailSettingsTable(db *pgxpool.Pool) *ModmailSettingsTable {
+	return &ModmailSettingsTable{
+		db,
+	}
+}
+
+func (t ModmailSettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS modmail_settings(
+	"guild_id" int8 NOT NULL,
+	"enabled" bool NOT NULL DEFAULT 'f',
+	"anonymise_staff" bool NOT NULL DEFAULT 'f',
+	PRIMARY KEY("guild_id")
+);
+`
+}
+
+// Get returns the zero value, i.e. disabled, if the guild has not configured modmail
+func (t *ModmailSettingsTable) Get(guildId uint64) (settings ModmailSettings, err error) {
+	query := `SELECT "enabled", "anonymise_staff" FROM modmail_settings WHERE "guild_id" = $1;`
+
+	if err = t.QueryRow(context.Background(), query, guildId).Scan(&settings.Enabled, &settings.AnonymiseStaff); err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// GetEnabledGuilds returns the IDs of all guilds that have enabled modmail
+func (t *ModmailSettingsTable) GetEnabledGuilds() ([]uint64, error) {
+	query := `SELECT "guild_id" FROM modmail_settings WHERE "enabled";`
+
+	rows, err := t.Query(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var guildIds []uint64
+	for rows.Next() {
+		var guildId uint64
+		if err := rows.Scan(&guildId); err != nil {
+			return nil, err
+		}
+
+		guildIds = append(guildIds, guildId)
+	}
+
+	return guildIds, rows.Err()
+}
+
+func (t *ModmailSettingsTable) Set(guildId uint64, settings ModmailSettings) (err error) {
+	query := `
+INSERT INTO modmail_settings("guild_id", "enabled", "anonymise_staff")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET "enabled" = $2, "anonymise_staff" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, settings.Enabled, settings.AnonymiseStaff)
+	return
+}
@@ -0,0 +1,193 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type OpenTicketListEntry struct {
+	Id              int
+	OpenerId        uint64
+	ChannelId       *uint64
+	PanelTitle      *string
+	ClaimerId       *uint64
+	OpenTime        time.Time
+	LastMessageTime *time.Time
+	Priority        TicketPriority
+}
+
+// OpenTicketListQueries lists open tickets along with their claimer, last activity and priority, which would otherwise
+// need several queries per ticket. It has no schema of its own.
+type OpenTicketListQueries struct {
+	*pgxpool.Pool
+}
+
+func newOpenTicketListQueries(db *pgxpool.Pool) *OpenTicketListQueries {
+	return &OpenTicketListQueries{
+		db,
+	}
+}
+
+// Get returns up to limit of the guild's open tickets, the most urgent first, and then the longest waiting first
+func (q *OpenTicketListQueries) Get(guildId uint64, openerId *uint64, panelId *int, unclaimedOnly bool, offset, limit int) ([]OpenTicketListEntry, error) {
+	query := `
+SELECT
+	tickets."id",
+	tickets."user_id",
+	tickets."channel_id",
+	panels."title",
+	ticket_claims."user_id",
+	tickets."open_time",
+	ticket_last_message."last_message_time",
+	COALESCE(ticket_priority."priority", $5)
+FROM tickets
+LEFT OUTER JOIN panels
+ON tickets."panel_id" = panels."panel_id"
+LEFT OUTER JOIN ticket_claims
+ON tickets."guild_id" = ticket_claims."guild_id" AND tickets."id" = ticket_claims."ticket_id"
+LEFT OUTER JOIN ticket_last_message
+ON tickets."guild_id" = ticket_last_message."guild_id" AND tickets."id" = ticket_last_message."ticket_id"
+LEFT OUTER JOIN ticket_priority
+ON tickets."guild_id" = ticket_priority."guild_id" AND tickets."id" = ticket_priority."ticket_id"
+WHERE tickets."guild_id" = $1
+	AND tickets."open" = TRUE
+	AND tickets."channel_id" IS NOT NULL
+	AND ($2::int8 IS NULL OR tickets."user_id" = $2)
+	AND ($3::int4 IS NULL OR tickets."panel_id" = $3)
+	AND (NOT $4::bool OR ticket_claims."user_id" IS NULL)
+ORDER BY COALESCE(ticket_priority."priority", $5) DESC, tickets."open_time" ASC
+OFFSET $6
+LIMIT $7;`
+
+	rows, err := q.Query(context.Background(), query, guildId, openerId, panelId, unclaimedOnly, PriorityNormal, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var entries []OpenTicketListEntry
+	for rows.Next() {
+		var entry OpenTicketListEntry
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.OpenerId,
+			&entry.ChannelId,
+			&entry.PanelTitle,
+			&entry.ClaimerId,
+			&entry.OpenTime,
+			&entry.LastMessageTime,
+			&entry.Priority,
+		); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+type QueuePanelSummary struct {
+	PanelTitle *string
+	Open       int
+	Unclaimed  int
+}
+
+// GetQueueSummary returns the number of open and unclaimed tickets from each panel, with tickets opened without a panel
+// having a nil title
+func (q *OpenTicketListQueries) GetQueueSummary(guildId uint64) ([]QueuePanelSummary, error) {
+	query := `
+SELECT
+	panels."title",
+	COUNT(*),
+	COUNT(*) FILTER (WHERE ticket_claims."user_id" IS NULL)
+FROM tickets
+LEFT OUTER JOIN panels
+ON tickets."panel_id" = panels."panel_id"
+LEFT OUTER JOIN ticket_claims
+ON tickets."guild_id" = ticket_claims."guild_id" AND tickets."id" = ticket_claims."ticket_id"
+WHERE tickets."guild_id" = $1
+	AND tickets."open" = TRUE
+	AND tickets."channel_id" IS NOT NULL
+GROUP BY panels."panel_id", panels."title"
+ORDER BY COUNT(*) DESC;`
+
+	rows, err := q.Query(context.Background(), query, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var summaries []QueuePanelSummary
+	for rows.Next() {
+		var summary QueuePanelSummary
+		if err := rows.Scan(&summary.PanelTitle, &summary.Open, &summary.Unclaimed); err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, rows.Err()
+}
+
+// GetOldestUnclaimed returns the open ticket that has been waiting for a claim the longest, regardless of priority
+func (q *OpenTicketListQueries) GetOldestUnclaimed(guildId uint64) (entry OpenTicketListEntry, ok bool, err error) {
+	query := `
+SELECT tickets."id", tickets."user_id", tickets."channel_id", tickets."open_time"
+FROM tickets
+LEFT OUTER JOIN ticket_claims
+ON tickets."guild_id" = ticket_claims."guild_id" AND tickets."id" = ticket_claims."ticket_id"
+WHERE tickets."guild_id" = $1
+	AND tickets."open" = TRUE
+	AND tickets."channel_id" IS NOT NULL
+	AND ticket_claims."user_id" IS NULL
+ORDER BY tickets."open_time" ASC
+LIMIT 1;`
+
+	err = q.QueryRow(context.Background(), query, guildId).Scan(&entry.Id, &entry.OpenerId, &entry.ChannelId, &entry.OpenTime)
+	if err == pgx.ErrNoRows {
+		return OpenTicketListEntry{}, false, nil
+	} else if err != nil {
+		return OpenTicketListEntry{}, false, err
+	}
+
+	return entry, true, nil
+}
+
+// GetInactive returns the IDs of the guild's open tickets that have not had a message since before, falling back to
+// the time the ticket was opened if no messages have been sent
+func (q *OpenTicketListQueries) GetInactive(guildId uint64, before time.Time) ([]int, error) {
+	query := `
+SELECT tickets."id"
+FROM tickets
+LEFT OUTER JOIN ticket_last_message
+ON tickets."guild_id" = ticket_last_message."guild_id" AND tickets."id" = ticket_last_message."ticket_id"
+WHERE tickets."guild_id" = $1
+	AND tickets."open" = TRUE
+	AND tickets."channel_id" IS NOT NULL
+	AND COALESCE(ticket_last_message."last_message_time", tickets."open_time") < $2;`
+
+	rows, err := q.Query(context.Background(), query, guildId, before)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var ticketIds []int
+	for rows.Next() {
+		var ticketId int
+		if err := rows.Scan(&ticketId); err != nil {
+			return nil, err
+		}
+
+		ticketIds = append(ticketIds, ticketId)
+	}
+
+	return ticketIds, rows.Err()
+}
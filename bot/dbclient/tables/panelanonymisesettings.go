@@ -0,0 +1,59 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PanelAnonymiseSettings controls whether staff messages in a panel's tickets are reposted through the ticket's
+// webhook under a team identity, hiding the staff member's own name and avatar from the ticket opener
+type PanelAnonymiseSettings struct {
+	Enabled   bool
+	TeamName  *string
+	AvatarUrl *string
+}
+
+type PanelAnonymiseSettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newPanelAnonymiseSettingsTable(db *pgxpool.Pool) *PanelAnonymiseSettingsTable {
+	return &PanelAnonymiseSettingsTable{
+		db,
+	}
+}
+
+func (t PanelAnonymiseSettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_anonymise_settings(
+	"panel_id" int4 NOT NULL,
+	"enabled" bool NOT NULL DEFAULT 'f',
+	"team_name" VARCHAR(80) DEFAULT NULL,
+	"avatar_url" VARCHAR(1024) DEFAULT NULL,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id")
+);
+`
+}
+
+// Get returns the zero value, i.e. disabled, if the panel has not been configured
+func (t *PanelAnonymiseSettingsTable) Get(panelId int) (settings PanelAnonymiseSettings, err error) {
+	query := `SELECT "enabled", "team_name", "avatar_url" FROM panel_anonymise_settings WHERE "panel_id" = $1;`
+
+	if err = t.QueryRow(context.Background(), query, panelId).Scan(&settings.Enabled, &settings.TeamName, &settings.AvatarUrl); err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+func (t *PanelAnonymiseSettingsTable) Set(panelId int, settings PanelAnonymiseSettings) (err error) {
+	query := `
+INSERT INTO panel_anonymise_settings("panel_id", "enabled", "team_name", "avatar_url")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("panel_id") DO UPDATE SET "enabled" = $2, "team_name" = $3, "avatar_url" = $4;`
+
+	_, err = t.Exec(context.Background(), query, panelId, settings.Enabled, settings.TeamName, settings.AvatarUrl)
+	return
+}
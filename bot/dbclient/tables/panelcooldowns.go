@@ -0,0 +1,62 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// PanelCooldownsTable stores how long a user must wait between opening tickets from the same panel
+type PanelCooldownsTable struct {
+	*pgxpool.Pool
+}
+
+func newPanelCooldownsTable(db *pgxpool.Pool) *PanelCooldownsTable {
+	return &PanelCooldownsTable{
+		db,
+	}
+}
+
+func (t PanelCooldownsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_cooldowns(
+	"panel_id" int4 NOT NULL,
+	"user_cooldown" int4 NOT NULL,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id")
+);
+`
+}
+
+// Get returns 0 if the panel has no cooldown
+func (t *PanelCooldownsTable) Get(panelId int) (time.Duration, error) {
+	query := `SELECT "user_cooldown" FROM panel_cooldowns WHERE "panel_id" = $1;`
+
+	var seconds int
+	if err := t.QueryRow(context.Background(), query, panelId).Scan(&seconds); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func (t *PanelCooldownsTable) Set(panelId int, cooldown time.Duration) (err error) {
+	query := `
+INSERT INTO panel_cooldowns("panel_id", "user_cooldown")
+VALUES($1, $2)
+ON CONFLICT("panel_id") DO UPDATE SET "user_cooldown" = $2;`
+
+	_, err = t.Exec(context.Background(), query, panelId, int(cooldown.Seconds()))
+	return
+}
+
+func (t *PanelCooldownsTable) Delete(panelId int) (err error) {
+	query := `DELETE FROM panel_cooldowns WHERE "panel_id" = $1;`
+	_, err = t.Exec(context.Background(), query, panelId)
+	return
+}
@@ -0,0 +1,70 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// PanelHolidaysTable stores dates on which a panel is closed all day, regardless of its opening hours
+type PanelHolidaysTable struct {
+	*pgxpool.Pool
+}
+
+func newPanelHolidaysTable(db *pgxpool.Pool) *PanelHolidaysTable {
+	return &PanelHolidaysTable{
+		db,
+	}
+}
+
+func (t PanelHolidaysTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_holidays(
+	"panel_id" int4 NOT NULL,
+	"date" DATE NOT NULL,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id", "date")
+);
+`
+}
+
+// GetByPanel returns the holidays of a panel. As dates have no timezone, only the year, month and day of the returned
+// times are meaningful.
+func (t *PanelHolidaysTable) GetByPanel(panelId int) ([]time.Time, error) {
+	query := `SELECT "date" FROM panel_holidays WHERE "panel_id" = $1 ORDER BY "date" ASC;`
+
+	rows, err := t.Query(context.Background(), query, panelId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+
+		dates = append(dates, date)
+	}
+
+	return dates, rows.Err()
+}
+
+func (t *PanelHolidaysTable) Add(panelId int, date time.Time) (err error) {
+	query := `
+INSERT INTO panel_holidays("panel_id", "date")
+VALUES($1, $2)
+ON CONFLICT("panel_id", "date") DO NOTHING;`
+
+	_, err = t.Exec(context.Background(), query, panelId, date)
+	return
+}
+
+func (t *PanelHolidaysTable) Delete(panelId int, date time.Time) (err error) {
+	query := `DELETE FROM panel_holidays WHERE "panel_id" = $1 AND "date" = $2;`
+	_, err = t.Exec(context.Background(), query, panelId, date)
+	return
+}
@@ -0,0 +1,119 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// OpeningHours is a range of time starting on a weekday during which a panel is staffed. Times are stored as minutes
+// since midnight, in the timezone of the panel's schedule. A close minute of 1440 means the end of the day, and a close
+// minute before the open minute means that the hours run overnight, closing on the following day.
+type OpeningHours struct {
+	PanelId     int
+	Weekday     time.Weekday
+	OpenMinute  int
+	CloseMinute int
+}
+
+func (h OpeningHours) IsOvernight() bool {
+	return h.CloseMinute < h.OpenMinute
+}
+
+type PanelOpeningHoursTable struct {
+	*pgxpool.Pool
+}
+
+func newPanelOpeningHoursTable(db *pgxpool.Pool) *PanelOpeningHoursTable {
+	return &PanelOpeningHoursTable{
+		db,
+	}
+}
+
+func (t PanelOpeningHoursTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_opening_hours(
+	"panel_id" int4 NOT NULL,
+	"weekday" int2 NOT NULL CHECK ("weekday" >= 0 AND "weekday" <= 6),
+	"open_minute" int2 NOT NULL CHECK ("open_minute" >= 0 AND "open_minute" < 1440),
+	"close_minute" int2 NOT NULL CHECK ("close_minute" >= 0 AND "close_minute" <= 1440 AND "close_minute" <> "open_minute"),
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id", "weekday", "open_minute")
+);
+`
+}
+
+func (t *PanelOpeningHoursTable) GetByPanel(panelId int) ([]OpeningHours, error) {
+	query := `
+SELECT "panel_id", "weekday", "open_minute", "close_minute"
+FROM panel_opening_hours
+WHERE "panel_id" = $1
+ORDER BY "weekday" ASC, "open_minute" ASC;`
+
+	rows, err := t.Query(context.Background(), query, panelId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var hours []OpeningHours
+	for rows.Next() {
+		var weekday int16
+		var openingHours OpeningHours
+		if err := rows.Scan(&openingHours.PanelId, &weekday, &openingHours.OpenMinute, &openingHours.CloseMinute); err != nil {
+			return nil, err
+		}
+
+		openingHours.Weekday = time.Weekday(weekday)
+		hours = append(hours, openingHours)
+	}
+
+	return hours, rows.Err()
+}
+
+func (t *PanelOpeningHoursTable) Add(hours OpeningHours) (err error) {
+	query := `
+INSERT INTO panel_opening_hours("panel_id", "weekday", "open_minute", "close_minute")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("panel_id", "weekday", "open_minute") DO UPDATE SET "close_minute" = $4;`
+
+	_, err = t.Exec(context.Background(), query, hours.PanelId, int16(hours.Weekday), hours.OpenMinute, hours.CloseMinute)
+	return
+}
+
+// SetDay replaces any opening hours that start on the weekday with a single range
+func (t *PanelOpeningHoursTable) SetDay(hours OpeningHours) error {
+	tx, err := t.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	deleteQuery := `DELETE FROM panel_opening_hours WHERE "panel_id" = $1 AND "weekday" = $2;`
+	if _, err := tx.Exec(context.Background(), deleteQuery, hours.PanelId, int16(hours.Weekday)); err != nil {
+		return err
+	}
+
+	insertQuery := `
+INSERT INTO panel_opening_hours("panel_id", "weekday", "open_minute", "close_minute")
+VALUES($1, $2, $3, $4);`
+	if _, err := tx.Exec(context.Background(), insertQuery, hours.PanelId, int16(hours.Weekday), hours.OpenMinute, hours.CloseMinute); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+func (t *PanelOpeningHoursTable) DeleteDay(panelId int, weekday time.Weekday) (err error) {
+	query := `DELETE FROM panel_opening_hours WHERE "panel_id" = $1 AND "weekday" = $2;`
+	_, err = t.Exec(context.Background(), query, panelId, int16(weekday))
+	return
+}
+
+func (t *PanelOpeningHoursTable) Delete(panelId int, weekday time.Weekday, openMinute int) (err error) {
+	query := `DELETE FROM panel_opening_hours WHERE "panel_id" = $1 AND "weekday" = $2 AND "open_minute" = $3;`
+	_, err = t.Exec(context.Background(), query, panelId, int16(weekday), openMinute)
+	return
+}
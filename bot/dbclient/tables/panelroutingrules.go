@@ -0,0 +1,160 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type RoutingOperator int16
+
+const (
+	RoutingOperatorAlways RoutingOperator = iota
+	RoutingOperatorEquals
+	RoutingOperatorContains
+	RoutingOperatorMatches
+)
+
+var RoutingOperatorNames = map[RoutingOperator]string{
+	RoutingOperatorAlways:   "always",
+	RoutingOperatorEquals:   "equals",
+	RoutingOperatorContains: "contains",
+	RoutingOperatorMatches:  "matches",
+}
+
+// PanelRoutingRule decides where a ticket opened from a panel should go, based on the answers to the panel's form.
+// Any outcome left as nil falls back to the panel's own setting.
+type PanelRoutingRule struct {
+	Id             int
+	PanelId        int
+	Position       int
+	FormInputId    *int
+	Operator       RoutingOperator
+	Value          string
+	TargetCategory *uint64
+	SupportTeamId  *int
+	NamingScheme   *string
+	Priority       *TicketPriority
+}
+
+type PanelRoutingRulesTable struct {
+	*pgxpool.Pool
+}
+
+func newPanelRoutingRulesTable(db *pgxpool.Pool) *PanelRoutingRulesTable {
+	return &PanelRoutingRulesTable{
+		db,
+	}
+}
+
+func (t PanelRoutingRulesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_routing_rules(
+	"id" SERIAL NOT NULL UNIQUE,
+	"panel_id" int4 NOT NULL,
+	"position" int4 NOT NULL DEFAULT 0,
+	"form_input_id" int4 DEFAULT NULL,
+	"operator" int2 NOT NULL DEFAULT 0,
+	"value" VARCHAR(255) NOT NULL DEFAULT '',
+	"target_category" int8 DEFAULT NULL,
+	"support_team_id" int4 DEFAULT NULL,
+	"naming_scheme" VARCHAR(100) DEFAULT NULL,
+	"priority" int2 DEFAULT NULL,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	FOREIGN KEY("form_input_id") REFERENCES form_input("id") ON DELETE CASCADE,
+	FOREIGN KEY("support_team_id") REFERENCES support_team("id") ON DELETE SET NULL,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS panel_routing_rules_panel_id ON panel_routing_rules("panel_id");
+`
+}
+
+// GetByPanel returns the rules for a panel, in the order that they should be evaluated
+func (t *PanelRoutingRulesTable) GetByPanel(panelId int) ([]PanelRoutingRule, error) {
+	query := `
+SELECT "id", "panel_id", "position", "form_input_id", "operator", "value", "target_category", "support_team_id", "naming_scheme", "priority"
+FROM panel_routing_rules
+WHERE "panel_id" = $1
+ORDER BY "position" ASC, "id" ASC;`
+
+	rows, err := t.Query(context.Background(), query, panelId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var rules []PanelRoutingRule
+	for rows.Next() {
+		var rule PanelRoutingRule
+		if err := rows.Scan(
+			&rule.Id,
+			&rule.PanelId,
+			&rule.Position,
+			&rule.FormInputId,
+			&rule.Operator,
+			&rule.Value,
+			&rule.TargetCategory,
+			&rule.SupportTeamId,
+			&rule.NamingScheme,
+			&rule.Priority,
+		); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// Create inserts the rule, moving any rules at or after its position back so that it is evaluated before them
+func (t *PanelRoutingRulesTable) Create(rule PanelRoutingRule) (id int, err error) {
+	tx, err := t.Begin(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	shiftQuery := `UPDATE panel_routing_rules SET "position" = "position" + 1 WHERE "panel_id" = $1 AND "position" >= $2;`
+	if _, err := tx.Exec(context.Background(), shiftQuery, rule.PanelId, rule.Position); err != nil {
+		return 0, err
+	}
+
+	query := `
+INSERT INTO panel_routing_rules("panel_id", "position", "form_input_id", "operator", "value", "target_category", "support_team_id", "naming_scheme", "priority")
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING "id";`
+
+	if err := tx.QueryRow(context.Background(), query,
+		rule.PanelId,
+		rule.Position,
+		rule.FormInputId,
+		rule.Operator,
+		rule.Value,
+		rule.TargetCategory,
+		rule.SupportTeamId,
+		rule.NamingScheme,
+		rule.Priority,
+	).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Delete returns false if the panel has no rule with the ID
+func (t *PanelRoutingRulesTable) Delete(panelId, ruleId int) (bool, error) {
+	query := `DELETE FROM panel_routing_rules WHERE "panel_id" = $1 AND "id" = $2;`
+
+	res, err := t.Exec(context.Background(), query, panelId, ruleId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,75 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OutOfHoursMode int16
+
+const (
+	// OutOfHoursModeRefuse prevents tickets from being opened outside of business hours
+	OutOfHoursModeRefuse OutOfHoursMode = iota
+	// OutOfHoursModeAccept opens the ticket, but lets the user know that it may take longer to get a response
+	OutOfHoursModeAccept
+)
+
+type PanelSchedule struct {
+	PanelId  int
+	Timezone string
+	Mode     OutOfHoursMode
+}
+
+// PanelSchedulesTable stores whether a panel has business hours. The hours themselves are stored in
+// PanelOpeningHoursTable, and any days that the panel is closed in PanelHolidaysTable.
+type PanelSchedulesTable struct {
+	*pgxpool.Pool
+}
+
+func newPanelSchedulesTable(db *pgxpool.Pool) *PanelSchedulesTable {
+	return &PanelSchedulesTable{
+		db,
+	}
+}
+
+func (t PanelSchedulesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS panel_schedules(
+	"panel_id" int4 NOT NULL,
+	"timezone" VARCHAR(64) NOT NULL DEFAULT 'UTC',
+	"out_of_hours_mode" int2 NOT NULL DEFAULT 0,
+	FOREIGN KEY("panel_id") REFERENCES panels("panel_id") ON DELETE CASCADE,
+	PRIMARY KEY("panel_id")
+);
+`
+}
+
+func (t *PanelSchedulesTable) Get(panelId int) (schedule PanelSchedule, ok bool, err error) {
+	query := `SELECT "panel_id", "timezone", "out_of_hours_mode" FROM panel_schedules WHERE "panel_id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, panelId).Scan(&schedule.PanelId, &schedule.Timezone, &schedule.Mode)
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+func (t *PanelSchedulesTable) Set(schedule PanelSchedule) (err error) {
+	query := `
+INSERT INTO panel_schedules("panel_id", "timezone", "out_of_hours_mode")
+VALUES($1, $2, $3)
+ON CONFLICT("panel_id") DO UPDATE SET "timezone" = $2, "out_of_hours_mode" = $3;`
+
+	_, err = t.Exec(context.Background(), query, schedule.PanelId, schedule.Timezone, schedule.Mode)
+	return
+}
+
+func (t *PanelSchedulesTable) Delete(panelId int) (err error) {
+	query := `DELETE FROM panel_schedules WHERE "panel_id" = $1;`
+	_, err = t.Exec(context.Background(), query, panelId)
+	return
+}
@@ -0,0 +1,118 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type PeriodStats struct {
+	Opened              int
+	Closed              int
+	RatingCount         int
+	RatingAverage       *float64
+	AverageResponseTime *time.Duration
+	AverageDuration     *time.Duration
+}
+
+type StaffTicketCount struct {
+	UserId  uint64
+	Tickets int
+}
+
+// PeriodStatsQueries aggregates the tables owned by github.com/TicketsBot/database over an arbitrary period, which the
+// views it provides do not support. It has no schema of its own.
+type PeriodStatsQueries struct {
+	*pgxpool.Pool
+}
+
+func newPeriodStatsQueries(db *pgxpool.Pool) *PeriodStatsQueries {
+	return &PeriodStatsQueries{
+		db,
+	}
+}
+
+// Get returns the statistics for tickets opened (or, for closures, ratings and durations, closed) within [from, to)
+func (q *PeriodStatsQueries) Get(guildId uint64, from, to time.Time) (PeriodStats, error) {
+	query := `
+SELECT
+	(SELECT COUNT(*) FROM tickets WHERE "guild_id" = $1 AND "open_time" >= $2 AND "open_time" < $3),
+	(SELECT COUNT(*) FROM tickets WHERE "guild_id" = $1 AND "close_time" >= $2 AND "close_time" < $3),
+	COUNT(service_ratings."rating"),
+	AVG(service_ratings."rating")::float8,
+	(
+		SELECT AVG(EXTRACT(EPOCH FROM first_response_time."response_time"))::float8
+		FROM first_response_time
+		INNER JOIN tickets
+		ON first_response_time."guild_id" = tickets."guild_id" AND first_response_time."ticket_id" = tickets."id"
+		WHERE first_response_time."guild_id" = $1 AND tickets."open_time" >= $2 AND tickets."open_time" < $3
+	),
+	(
+		SELECT AVG(EXTRACT(EPOCH FROM tickets."close_time" - tickets."open_time"))::float8
+		FROM tickets
+		WHERE tickets."guild_id" = $1 AND tickets."close_time" >= $2 AND tickets."close_time" < $3
+	)
+FROM service_ratings
+INNER JOIN tickets
+ON service_ratings."guild_id" = tickets."guild_id" AND service_ratings."ticket_id" = tickets."id"
+WHERE service_ratings."guild_id" = $1 AND tickets."close_time" >= $2 AND tickets."close_time" < $3;`
+
+	var stats PeriodStats
+	var responseTimeSeconds, durationSeconds *float64
+	if err := q.QueryRow(context.Background(), query, guildId, from, to).Scan(
+		&stats.Opened,
+		&stats.Closed,
+		&stats.RatingCount,
+		&stats.RatingAverage,
+		&responseTimeSeconds,
+		&durationSeconds,
+	); err != nil {
+		return PeriodStats{}, err
+	}
+
+	stats.AverageResponseTime = secondsToDuration(responseTimeSeconds)
+	stats.AverageDuration = secondsToDuration(durationSeconds)
+
+	return stats, nil
+}
+
+func secondsToDuration(seconds *float64) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+
+	duration := time.Duration(*seconds * float64(time.Second))
+	return &duration
+}
+
+// GetTopStaff returns the staff members who responded to the most tickets opened within [from, to)
+func (q *PeriodStatsQueries) GetTopStaff(guildId uint64, from, to time.Time, limit int) ([]StaffTicketCount, error) {
+	query := `
+SELECT first_response_time."user_id", COUNT(*)
+FROM first_response_time
+INNER JOIN tickets
+ON first_response_time."guild_id" = tickets."guild_id" AND first_response_time."ticket_id" = tickets."id"
+WHERE first_response_time."guild_id" = $1 AND tickets."open_time" >= $2 AND tickets."open_time" < $3
+GROUP BY first_response_time."user_id"
+ORDER BY COUNT(*) DESC
+LIMIT $4;`
+
+	rows, err := q.Query(context.Background(), query, guildId, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var counts []StaffTicketCount
+	for rows.Next() {
+		var count StaffTicketCount
+		if err := rows.Scan(&count.UserId, &count.Tickets); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
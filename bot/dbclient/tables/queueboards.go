@@ -0,0 +1,65 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type QueueBoard struct {
+	GuildId   uint64
+	ChannelId uint64
+	MessageId uint64
+}
+
+// QueueBoardsTable stores the message in each guild that is kept up to date with the ticket queue
+type QueueBoardsTable struct {
+	*pgxpool.Pool
+}
+
+func newQueueBoardsTable(db *pgxpool.Pool) *QueueBoardsTable {
+	return &QueueBoardsTable{
+		db,
+	}
+}
+
+func (t QueueBoardsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS queue_boards(
+	"guild_id" int8 NOT NULL,
+	"channel_id" int8 NOT NULL,
+	"message_id" int8 NOT NULL,
+	PRIMARY KEY("guild_id")
+);
+`
+}
+
+func (t *QueueBoardsTable) Get(guildId uint64) (board QueueBoard, ok bool, err error) {
+	query := `SELECT "guild_id", "channel_id", "message_id" FROM queue_boards WHERE "guild_id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, guildId).Scan(&board.GuildId, &board.ChannelId, &board.MessageId)
+	if err == pgx.ErrNoRows {
+		return QueueBoard{}, false, nil
+	} else if err != nil {
+		return QueueBoard{}, false, err
+	}
+
+	return board, true, nil
+}
+
+func (t *QueueBoardsTable) Set(board QueueBoard) (err error) {
+	query := `
+INSERT INTO queue_boards("guild_id", "channel_id", "message_id")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET "channel_id" = $2, "message_id" = $3;`
+
+	_, err = t.Exec(context.Background(), query, board.GuildId, board.ChannelId, board.MessageId)
+	return
+}
+
+// Delete removes the guild's board, as long as it is still the given message
+func (t *QueueBoardsTable) Delete(guildId, messageId uint64) (err error) {
+	query := `DELETE FROM queue_boards WHERE "guild_id" = $1 AND "message_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, messageId)
+	return
+}
@@ -0,0 +1,126 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Answers to yes / no questions are stored as one of these values
+const (
+	RatingAnswerYes = "Yes"
+	RatingAnswerNo  = "No"
+)
+
+type RatingQuestionSummary struct {
+	QuestionId int
+	Label      string
+	Type       RatingQuestionType
+	Responses  int
+	Yes        int
+}
+
+type RatingAnswersTable struct {
+	*pgxpool.Pool
+}
+
+func newRatingAnswersTable(db *pgxpool.Pool) *RatingAnswersTable {
+	return &RatingAnswersTable{
+		db,
+	}
+}
+
+func (t RatingAnswersTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS rating_answers(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"question_id" int4 NOT NULL,
+	"answer" VARCHAR(1024) NOT NULL,
+	FOREIGN KEY("question_id") REFERENCES rating_questions("id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "question_id")
+);
+CREATE INDEX IF NOT EXISTS rating_answers_question_id ON rating_answers("question_id");
+`
+}
+
+// GetByTicket returns the answers to each question, keyed by question ID
+func (t *RatingAnswersTable) GetByTicket(guildId uint64, ticketId int) (map[int]string, error) {
+	query := `SELECT "question_id", "answer" FROM rating_answers WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	rows, err := t.Query(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	answers := make(map[int]string)
+	for rows.Next() {
+		var questionId int
+		var answer string
+		if err := rows.Scan(&questionId, &answer); err != nil {
+			return nil, err
+		}
+
+		answers[questionId] = answer
+	}
+
+	return answers, rows.Err()
+}
+
+// Set replaces any answers previously given for the ticket, keyed by question ID
+func (t *RatingAnswersTable) Set(guildId uint64, ticketId int, answers map[int]string) error {
+	tx, err := t.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	if _, err := tx.Exec(context.Background(), `DELETE FROM rating_answers WHERE "guild_id" = $1 AND "ticket_id" = $2;`, guildId, ticketId); err != nil {
+		return err
+	}
+
+	batch := &pgx.Batch{}
+	for questionId, answer := range answers {
+		batch.Queue(`INSERT INTO rating_answers("guild_id", "ticket_id", "question_id", "answer") VALUES($1, $2, $3, $4);`, guildId, ticketId, questionId, answer)
+	}
+
+	if err := tx.SendBatch(context.Background(), batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+// GetSummary returns the number of responses to each of the guild's current questions, and for yes / no questions, how
+// many of those were yes
+func (t *RatingAnswersTable) GetSummary(guildId uint64) ([]RatingQuestionSummary, error) {
+	query := `
+SELECT rating_questions."id", rating_questions."label", rating_questions."type", COUNT(rating_answers."answer"), COUNT(*) FILTER (WHERE rating_answers."answer" = $2)
+FROM rating_questions
+LEFT OUTER JOIN rating_answers ON rating_questions."id" = rating_answers."question_id"
+WHERE rating_questions."guild_id" = $1
+GROUP BY rating_questions."id"
+ORDER BY rating_questions."id" ASC;`
+
+	rows, err := t.Query(context.Background(), query, guildId, RatingAnswerYes)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var summaries []RatingQuestionSummary
+	for rows.Next() {
+		var summary RatingQuestionSummary
+		if err := rows.Scan(&summary.QuestionId, &summary.Label, &summary.Type, &summary.Responses, &summary.Yes); err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, rows.Err()
+}
@@ -0,0 +1,100 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// RatingFeedbackMessage records the feedback channel message posted for a ticket's rating, so that it can be edited
+// when the user changes their rating, and whether staff have already been alerted about the ticket. ChannelId and
+// MessageId are nil until the message has been sent.
+type RatingFeedbackMessage struct {
+	GuildId   uint64
+	TicketId  int
+	ChannelId *uint64
+	MessageId *uint64
+	Alerted   bool
+}
+
+type RatingFeedbackMessagesTable struct {
+	*pgxpool.Pool
+}
+
+func newRatingFeedbackMessagesTable(db *pgxpool.Pool) *RatingFeedbackMessagesTable {
+	return &RatingFeedbackMessagesTable{
+		db,
+	}
+}
+
+func (t RatingFeedbackMessagesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS rating_feedback_messages(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"channel_id" int8 DEFAULT NULL,
+	"message_id" int8 DEFAULT NULL,
+	"alerted" bool NOT NULL DEFAULT false,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+`
+}
+
+func (t *RatingFeedbackMessagesTable) Get(guildId uint64, ticketId int) (message RatingFeedbackMessage, ok bool, err error) {
+	query := `
+SELECT "guild_id", "ticket_id", "channel_id", "message_id", "alerted"
+FROM rating_feedback_messages
+WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	err = t.QueryRow(context.Background(), query, guildId, ticketId).Scan(&message.GuildId, &message.TicketId, &message.ChannelId, &message.MessageId, &message.Alerted)
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// Reserve returns true if this is the first rating of the ticket, in which case the caller should post the feedback
+// message. Only one of several concurrent calls for the same ticket will return true.
+func (t *RatingFeedbackMessagesTable) Reserve(guildId uint64, ticketId int) (bool, error) {
+	query := `
+INSERT INTO rating_feedback_messages("guild_id", "ticket_id")
+VALUES($1, $2)
+ON CONFLICT("guild_id", "ticket_id") DO NOTHING;`
+
+	res, err := t.Exec(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (t *RatingFeedbackMessagesTable) SetMessage(guildId uint64, ticketId int, channelId, messageId uint64) (err error) {
+	query := `
+UPDATE rating_feedback_messages
+SET "channel_id" = $3, "message_id" = $4
+WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, channelId, messageId)
+	return
+}
+
+// MarkAlerted returns true if staff had not yet been alerted about the ticket, in which case the caller should send
+// the alert
+func (t *RatingFeedbackMessagesTable) MarkAlerted(guildId uint64, ticketId int) (bool, error) {
+	query := `
+UPDATE rating_feedback_messages
+SET "alerted" = true
+WHERE "guild_id" = $1 AND "ticket_id" = $2 AND NOT "alerted";`
+
+	res, err := t.Exec(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,75 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type RatingFeedbackSettings struct {
+	// The channel that ratings and answers are posted to, if any
+	ChannelId *uint64
+	// Ratings at or below this value trigger an alert. Zero disables alerts.
+	LowRatingThreshold uint8
+	// If set, low rating alerts mention this role in the feedback channel. Otherwise, the ticket's claimer is sent a DM.
+	AlertRoleId *uint64
+}
+
+type RatingFeedbackSettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newRatingFeedbackSettingsTable(db *pgxpool.Pool) *RatingFeedbackSettingsTable {
+	return &RatingFeedbackSettingsTable{
+		db,
+	}
+}
+
+func (t RatingFeedbackSettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS rating_feedback_settings(
+	"guild_id" int8 NOT NULL,
+	"channel_id" int8 DEFAULT NULL,
+	"low_rating_threshold" int2 NOT NULL DEFAULT 0,
+	"alert_role_id" int8 DEFAULT NULL,
+	PRIMARY KEY("guild_id")
+);
+`
+}
+
+// Get returns the zero value if the guild has not configured any settings
+func (t *RatingFeedbackSettingsTable) Get(guildId uint64) (settings RatingFeedbackSettings, err error) {
+	query := `SELECT "channel_id", "low_rating_threshold", "alert_role_id" FROM rating_feedback_settings WHERE "guild_id" = $1;`
+
+	var threshold int16
+	if err = t.QueryRow(context.Background(), query, guildId).Scan(&settings.ChannelId, &threshold, &settings.AlertRoleId); err != nil {
+		if err == pgx.ErrNoRows {
+			err = nil
+		}
+
+		return
+	}
+
+	settings.LowRatingThreshold = uint8(threshold)
+	return
+}
+
+func (t *RatingFeedbackSettingsTable) SetChannel(guildId uint64, channelId *uint64) (err error) {
+	query := `
+INSERT INTO rating_feedback_settings("guild_id", "channel_id")
+VALUES($1, $2)
+ON CONFLICT("guild_id") DO UPDATE SET "channel_id" = $2;`
+
+	_, err = t.Exec(context.Background(), query, guildId, channelId)
+	return
+}
+
+func (t *RatingFeedbackSettingsTable) SetAlerts(guildId uint64, threshold uint8, roleId *uint64) (err error) {
+	query := `
+INSERT INTO rating_feedback_settings("guild_id", "low_rating_threshold", "alert_role_id")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id") DO UPDATE SET "low_rating_threshold" = $2, "alert_role_id" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, int16(threshold), roleId)
+	return
+}
@@ -0,0 +1,113 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type RatingQuestionType int16
+
+const (
+	RatingQuestionTypeText RatingQuestionType = iota
+	RatingQuestionTypeYesNo
+)
+
+// MaxRatingQuestions is the number of text inputs Discord allows in a single modal
+const MaxRatingQuestions = 5
+
+type RatingQuestion struct {
+	Id       int
+	GuildId  uint64
+	Label    string
+	Type     RatingQuestionType
+	Required bool
+}
+
+type RatingQuestionsTable struct {
+	*pgxpool.Pool
+}
+
+func newRatingQuestionsTable(db *pgxpool.Pool) *RatingQuestionsTable {
+	return &RatingQuestionsTable{
+		db,
+	}
+}
+
+func (t RatingQuestionsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS rating_questions(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"label" VARCHAR(45) NOT NULL,
+	"type" int2 NOT NULL,
+	"required" bool NOT NULL DEFAULT false,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS rating_questions_guild_id ON rating_questions("guild_id");
+`
+}
+
+func (t *RatingQuestionsTable) Get(id int) (question RatingQuestion, ok bool, err error) {
+	query := `SELECT "id", "guild_id", "label", "type", "required" FROM rating_questions WHERE "id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, id).Scan(
+		&question.Id,
+		&question.GuildId,
+		&question.Label,
+		&question.Type,
+		&question.Required,
+	)
+
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// GetByGuild returns the questions in the order they were created, which is the order they appear in the modal
+func (t *RatingQuestionsTable) GetByGuild(guildId uint64) ([]RatingQuestion, error) {
+	query := `
+SELECT "id", "guild_id", "label", "type", "required"
+FROM rating_questions
+WHERE "guild_id" = $1
+ORDER BY "id" ASC;`
+
+	rows, err := t.Query(context.Background(), query, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var questions []RatingQuestion
+	for rows.Next() {
+		var question RatingQuestion
+		if err := rows.Scan(&question.Id, &question.GuildId, &question.Label, &question.Type, &question.Required); err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, question)
+	}
+
+	return questions, rows.Err()
+}
+
+func (t *RatingQuestionsTable) Create(guildId uint64, label string, questionType RatingQuestionType, required bool) (id int, err error) {
+	query := `
+INSERT INTO rating_questions("guild_id", "label", "type", "required")
+VALUES($1, $2, $3, $4)
+RETURNING "id";`
+
+	err = t.QueryRow(context.Background(), query, guildId, label, questionType, required).Scan(&id)
+	return
+}
+
+func (t *RatingQuestionsTable) Delete(guildId uint64, id int) (err error) {
+	query := `DELETE FROM rating_questions WHERE "guild_id" = $1 AND "id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, id)
+	return
+}
@@ -0,0 +1,68 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// StaffRepliesTable records the real author of each message that was posted through a ticket's webhook on behalf of a
+// staff member, so that transcripts and audit data still show who sent it
+type StaffRepliesTable struct {
+	*pgxpool.Pool
+}
+
+func newStaffRepliesTable(db *pgxpool.Pool) *StaffRepliesTable {
+	return &StaffRepliesTable{
+		db,
+	}
+}
+
+func (t StaffRepliesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS staff_replies(
+	"message_id" int8 NOT NULL,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"author_id" int8 NOT NULL,
+	"sent_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("message_id")
+);
+CREATE INDEX IF NOT EXISTS staff_replies_guild_ticket ON staff_replies("guild_id", "ticket_id");
+`
+}
+
+// GetAuthors returns a map of message ID to the ID of the staff member who sent it
+func (t *StaffRepliesTable) GetAuthors(guildId uint64, ticketId int) (map[uint64]uint64, error) {
+	query := `SELECT "message_id", "author_id" FROM staff_replies WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	rows, err := t.Query(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	authors := make(map[uint64]uint64)
+	for rows.Next() {
+		var messageId, authorId uint64
+		if err := rows.Scan(&messageId, &authorId); err != nil {
+			return nil, err
+		}
+
+		authors[messageId] = authorId
+	}
+
+	return authors, rows.Err()
+}
+
+func (t *StaffRepliesTable) Create(guildId uint64, ticketId int, messageId, authorId uint64) (err error) {
+	query := `
+INSERT INTO staff_replies("message_id", "guild_id", "ticket_id", "author_id", "sent_at")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("message_id") DO NOTHING;`
+
+	_, err = t.Exec(context.Background(), query, messageId, guildId, ticketId, authorId, time.Now())
+	return
+}
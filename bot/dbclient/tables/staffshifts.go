@@ -0,0 +1,85 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type StaffShift struct {
+	UserId    uint64
+	StartedAt time.Time
+}
+
+// StaffShiftsTable stores which staff members are currently on shift, for the queue board
+type StaffShiftsTable struct {
+	*pgxpool.Pool
+}
+
+func newStaffShiftsTable(db *pgxpool.Pool) *StaffShiftsTable {
+	return &StaffShiftsTable{
+		db,
+	}
+}
+
+func (t StaffShiftsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS staff_shifts(
+	"guild_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"started_at" TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY("guild_id", "user_id")
+);
+`
+}
+
+// GetByGuild returns the guild's staff who are on shift, the longest serving first
+func (t *StaffShiftsTable) GetByGuild(guildId uint64) ([]StaffShift, error) {
+	query := `SELECT "user_id", "started_at" FROM staff_shifts WHERE "guild_id" = $1 ORDER BY "started_at" ASC;`
+
+	rows, err := t.Query(context.Background(), query, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var shifts []StaffShift
+	for rows.Next() {
+		var shift StaffShift
+		if err := rows.Scan(&shift.UserId, &shift.StartedAt); err != nil {
+			return nil, err
+		}
+
+		shifts = append(shifts, shift)
+	}
+
+	return shifts, rows.Err()
+}
+
+// Start returns false if the user was already on shift
+func (t *StaffShiftsTable) Start(guildId, userId uint64) (bool, error) {
+	query := `
+INSERT INTO staff_shifts("guild_id", "user_id", "started_at")
+VALUES($1, $2, NOW())
+ON CONFLICT("guild_id", "user_id") DO NOTHING;`
+
+	res, err := t.Exec(context.Background(), query, guildId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// End returns false if the user was not on shift
+func (t *StaffShiftsTable) End(guildId, userId uint64) (bool, error) {
+	query := `DELETE FROM staff_shifts WHERE "guild_id" = $1 AND "user_id" = $2;`
+
+	res, err := t.Exec(context.Background(), query, guildId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,59 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// StatsDigestDeliveriesTable records which periods a guild's digest has been sent for. As the primary key prevents a
+// period from being claimed twice, only one worker will send each digest.
+type StatsDigestDeliveriesTable struct {
+	*pgxpool.Pool
+}
+
+func newStatsDigestDeliveriesTable(db *pgxpool.Pool) *StatsDigestDeliveriesTable {
+	return &StatsDigestDeliveriesTable{
+		db,
+	}
+}
+
+func (t StatsDigestDeliveriesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS stats_digest_deliveries(
+	"guild_id" int8 NOT NULL,
+	"period_start" TIMESTAMPTZ NOT NULL,
+	"claimed_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("guild_id", "period_start")
+);
+`
+}
+
+// Claim returns true if the caller should deliver the digest, or false if another worker has already claimed it
+func (t *StatsDigestDeliveriesTable) Claim(guildId uint64, periodStart time.Time) (bool, error) {
+	query := `
+INSERT INTO stats_digest_deliveries("guild_id", "period_start")
+VALUES($1, $2)
+ON CONFLICT("guild_id", "period_start") DO NOTHING;`
+
+	res, err := t.Exec(context.Background(), query, guildId, periodStart)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Release allows the digest to be claimed again, if it could not be delivered
+func (t *StatsDigestDeliveriesTable) Release(guildId uint64, periodStart time.Time) (err error) {
+	query := `DELETE FROM stats_digest_deliveries WHERE "guild_id" = $1 AND "period_start" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, periodStart)
+	return
+}
+
+// DeleteBefore removes records that are no longer needed to prevent duplicate deliveries
+func (t *StatsDigestDeliveriesTable) DeleteBefore(before time.Time) (err error) {
+	query := `DELETE FROM stats_digest_deliveries WHERE "period_start" < $1;`
+	_, err = t.Exec(context.Background(), query, before)
+	return
+}
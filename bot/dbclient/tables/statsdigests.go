@@ -0,0 +1,103 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type DigestFrequency int16
+
+const (
+	DigestFrequencyWeekly DigestFrequency = iota
+	DigestFrequencyMonthly
+)
+
+// StatsDigest is posted to ChannelId if set, otherwise it is sent to UserId in DMs
+type StatsDigest struct {
+	GuildId   uint64
+	Frequency DigestFrequency
+	ChannelId *uint64
+	UserId    *uint64
+}
+
+type StatsDigestsTable struct {
+	*pgxpool.Pool
+}
+
+func newStatsDigestsTable(db *pgxpool.Pool) *StatsDigestsTable {
+	return &StatsDigestsTable{
+		db,
+	}
+}
+
+func (t StatsDigestsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS stats_digests(
+	"guild_id" int8 NOT NULL,
+	"frequency" int2 NOT NULL,
+	"channel_id" int8 DEFAULT NULL,
+	"user_id" int8 DEFAULT NULL,
+	PRIMARY KEY("guild_id")
+);
+`
+}
+
+func (t *StatsDigestsTable) Get(guildId uint64) (digest StatsDigest, ok bool, err error) {
+	query := `SELECT "guild_id", "frequency", "channel_id", "user_id" FROM stats_digests WHERE "guild_id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, guildId).Scan(&digest.GuildId, &digest.Frequency, &digest.ChannelId, &digest.UserId)
+	if err == nil {
+		ok = true
+	} else if err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+// GetDue returns the digests of the given frequency that have not been delivered for the period starting at periodStart
+func (t *StatsDigestsTable) GetDue(frequency DigestFrequency, periodStart time.Time) ([]StatsDigest, error) {
+	query := `
+SELECT d."guild_id", d."frequency", d."channel_id", d."user_id"
+FROM stats_digests d
+WHERE d."frequency" = $1 AND NOT EXISTS (
+	SELECT 1 FROM stats_digest_deliveries dd WHERE dd."guild_id" = d."guild_id" AND dd."period_start" = $2
+);`
+
+	rows, err := t.Query(context.Background(), query, frequency, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var digests []StatsDigest
+	for rows.Next() {
+		var digest StatsDigest
+		if err := rows.Scan(&digest.GuildId, &digest.Frequency, &digest.ChannelId, &digest.UserId); err != nil {
+			return nil, err
+		}
+
+		digests = append(digests, digest)
+	}
+
+	return digests, rows.Err()
+}
+
+func (t *StatsDigestsTable) Set(digest StatsDigest) (err error) {
+	query := `
+INSERT INTO stats_digests("guild_id", "frequency", "channel_id", "user_id")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id") DO UPDATE SET "frequency" = $2, "channel_id" = $3, "user_id" = $4;`
+
+	_, err = t.Exec(context.Background(), query, digest.GuildId, digest.Frequency, digest.ChannelId, digest.UserId)
+	return
+}
+
+func (t *StatsDigestsTable) Delete(guildId uint64) (err error) {
+	query := `DELETE FROM stats_digests WHERE "guild_id" = $1;`
+	_, err = t.Exec(context.Background(), query, guildId)
+	return
+}
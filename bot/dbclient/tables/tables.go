@@ -0,0 +1,144 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Tables holds the tables that are owned by the worker, rather than by github.com/TicketsBot/database
+type Tables struct {
+	FormInputValidation   *FormInputValidationTable
+	PanelRoutingRules     *PanelRoutingRulesTable
+	TicketPriority        *TicketPriorityTable
+	TicketSupportTeams    *TicketSupportTeamsTable
+	TicketOpenLimits      *TicketOpenLimitsTable
+	PanelCooldowns        *PanelCooldownsTable
+	BlacklistEntries      *BlacklistEntriesTable
+	PanelSchedules        *PanelSchedulesTable
+	PanelOpeningHours     *PanelOpeningHoursTable
+	PanelHolidays         *PanelHolidaysTable
+	StatsDigests          *StatsDigestsTable
+	StatsDigestDeliveries *StatsDigestDeliveriesTable
+	PeriodStats           *PeriodStatsQueries
+	Exports               *ExportQueries
+	Leaderboard           *LeaderboardQueries
+	RatingQuestions       *RatingQuestionsTable
+	RatingAnswers         *RatingAnswersTable
+	RatingFeedback        *RatingFeedbackSettingsTable
+	RatingMessages        *RatingFeedbackMessagesTable
+	TagSettings           *TagSettingsTable
+	TagAttachments        *TagAttachmentsTable
+	TagUsage              *TagUsageTable
+	TagVersions           *TagVersionsTable
+	ModmailSettings       *ModmailSettingsTable
+	ModmailSessions       *ModmailSessionsTable
+	PanelAnonymise        *PanelAnonymiseSettingsTable
+	StaffReplies          *StaffRepliesTable
+	TicketLabels          *TicketLabelsTable
+	BulkTicketJobs        *BulkTicketJobsTable
+	TicketSubjects        *TicketSubjectsTable
+	TicketFormAnswers     *TicketFormAnswersTable
+	TicketSearch          *TicketSearchQueries
+	TicketSearches        *TicketSearchesTable
+	OpenTicketList        *OpenTicketListQueries
+	QueueBoards           *QueueBoardsTable
+	StaffShifts           *StaffShiftsTable
+	ClaimLimits           *ClaimLimitsTable
+	ClaimActivity         *ClaimActivityTable
+	TransferRequests      *TransferRequestsTable
+}
+
+type table interface {
+	Schema() string
+}
+
+func NewTables(pool *pgxpool.Pool) *Tables {
+	return &Tables{
+		FormInputValidation:   newFormInputValidationTable(pool),
+		PanelRoutingRules:     newPanelRoutingRulesTable(pool),
+		TicketPriority:        newTicketPriorityTable(pool),
+		TicketSupportTeams:    newTicketSupportTeamsTable(pool),
+		TicketOpenLimits:      newTicketOpenLimitsTable(pool),
+		PanelCooldowns:        newPanelCooldownsTable(pool),
+		BlacklistEntries:      newBlacklistEntriesTable(pool),
+		PanelSchedules:        newPanelSchedulesTable(pool),
+		PanelOpeningHours:     newPanelOpeningHoursTable(pool),
+		PanelHolidays:         newPanelHolidaysTable(pool),
+		StatsDigests:          newStatsDigestsTable(pool),
+		StatsDigestDeliveries: newStatsDigestDeliveriesTable(pool),
+		PeriodStats:           newPeriodStatsQueries(pool),
+		Exports:               newExportQueries(pool),
+		Leaderboard:           newLeaderboardQueries(pool),
+		RatingQuestions:       newRatingQuestionsTable(pool),
+		RatingAnswers:         newRatingAnswersTable(pool),
+		RatingFeedback:        newRatingFeedbackSettingsTable(pool),
+		RatingMessages:        newRatingFeedbackMessagesTable(pool),
+		TagSettings:           newTagSettingsTable(pool),
+		TagAttachments:        newTagAttachmentsTable(pool),
+		TagUsage:              newTagUsageTable(pool),
+		TagVersions:           newTagVersionsTable(pool),
+		ModmailSettings:       newModmailSettingsTable(pool),
+		ModmailSessions:       newModmailSessionsTable(pool),
+		PanelAnonymise:        newPanelAnonymiseSettingsTable(pool),
+		StaffReplies:          newStaffRepliesTable(pool),
+		TicketLabels:          newTicketLabelsTable(pool),
+		BulkTicketJobs:        newBulkTicketJobsTable(pool),
+		TicketSubjects:        newTicketSubjectsTable(pool),
+		TicketFormAnswers:     newTicketFormAnswersTable(pool),
+		TicketSearch:          newTicketSearchQueries(pool),
+		TicketSearches:        newTicketSearchesTable(pool),
+		OpenTicketList:        newOpenTicketListQueries(pool),
+		QueueBoards:           newQueueBoardsTable(pool),
+		StaffShifts:           newStaffShiftsTable(pool),
+		ClaimLimits:           newClaimLimitsTable(pool),
+		ClaimActivity:         newClaimActivityTable(pool),
+		TransferRequests:      newTransferRequestsTable(pool),
+	}
+}
+
+func (t *Tables) CreateTables(pool *pgxpool.Pool) error {
+	tables := []table{
+		t.FormInputValidation,
+		t.PanelRoutingRules,
+		t.TicketPriority,
+		t.TicketSupportTeams,
+		t.TicketOpenLimits,
+		t.PanelCooldowns,
+		t.BlacklistEntries,
+		t.PanelSchedules,
+		t.PanelOpeningHours,
+		t.PanelHolidays,
+		t.StatsDigests,
+		t.StatsDigestDeliveries,
+		t.RatingQuestions,
+		t.RatingAnswers, // Must be created after rating_questions
+		t.RatingFeedback,
+		t.RatingMessages,
+		t.TagSettings,
+		t.TagAttachments,
+		t.TagUsage,
+		t.TagVersions,
+		t.ModmailSettings,
+		t.ModmailSessions,
+		t.PanelAnonymise,
+		t.StaffReplies,
+		t.TicketLabels,
+		t.BulkTicketJobs,
+		t.TicketSubjects,
+		t.TicketFormAnswers,
+		t.TicketSearches,
+		t.QueueBoards,
+		t.StaffShifts,
+		t.ClaimLimits,
+		t.ClaimActivity,
+		t.TransferRequests,
+	}
+
+	for _, table := range tables {
+		if _, err := pool.Exec(context.Background(), table.Schema()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// MaxTagAttachments is the number of files Discord allows on a single message
+const MaxTagAttachments = 10
+
+// MaxTagAttachmentSize is the total size of a tag's attachments, in bytes, as they are uploaded with each use
+const MaxTagAttachmentSize = 8 * 1024 * 1024
+
+// TagAttachment is a file sent with a tag. The file itself is stored, rather than the URL it was uploaded to, as
+// Discord's attachment URLs expire.
+type TagAttachment struct {
+	Id          int
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+type TagAttachmentsTable struct {
+	*pgxpool.Pool
+}
+
+func newTagAttachmentsTable(db *pgxpool.Pool) *TagAttachmentsTable {
+	return &TagAttachmentsTable{
+		db,
+	}
+}
+
+func (t TagAttachmentsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS tag_attachments(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"tag_id" VARCHAR(16) NOT NULL,
+	"file_name" VARCHAR(255) NOT NULL,
+	"content_type" VARCHAR(255) NOT NULL,
+	"data" bytea NOT NULL,
+	FOREIGN KEY("guild_id", "tag_id") REFERENCES tags("guild_id", "tag_id") ON DELETE CASCADE ON UPDATE CASCADE,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS tag_attachments_guild_tag ON tag_attachments("guild_id", "tag_id");
+`
+}
+
+func (t *TagAttachmentsTable) Get(guildId uint64, tagId string) ([]TagAttachment, error) {
+	query := `
+SELECT "id", "file_name", "content_type", "data"
+FROM tag_attachments
+WHERE "guild_id" = $1 AND "tag_id" = $2
+ORDER BY "id" ASC;`
+
+	rows, err := t.Query(context.Background(), query, guildId, tagId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var attachments []TagAttachment
+	for rows.Next() {
+		var attachment TagAttachment
+		if err := rows.Scan(&attachment.Id, &attachment.FileName, &attachment.ContentType, &attachment.Data); err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// GetUsage returns the number of attachments that the tag has, and their total size in bytes, without loading them
+func (t *TagAttachmentsTable) GetUsage(guildId uint64, tagId string) (count int, size int, err error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(octet_length("data")), 0) FROM tag_attachments WHERE "guild_id" = $1 AND "tag_id" = $2;`
+	err = t.QueryRow(context.Background(), query, guildId, tagId).Scan(&count, &size)
+	return
+}
+
+func (t *TagAttachmentsTable) Add(guildId uint64, tagId string, attachment TagAttachment) (err error) {
+	query := `
+INSERT INTO tag_attachments("guild_id", "tag_id", "file_name", "content_type", "data")
+VALUES($1, $2, $3, $4, $5);`
+
+	_, err = t.Exec(context.Background(), query, guildId, tagId, attachment.FileName, attachment.ContentType, attachment.Data)
+	return
+}
+
+func (t *TagAttachmentsTable) DeleteAll(guildId uint64, tagId string) (err error) {
+	query := `DELETE FROM tag_attachments WHERE "guild_id" = $1 AND "tag_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, tagId)
+	return
+}
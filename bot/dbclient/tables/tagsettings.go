@@ -0,0 +1,86 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TagPostAction int16
+
+const (
+	TagPostActionNone TagPostAction = iota
+	TagPostActionClose
+	TagPostActionClaim
+)
+
+// TagSettings extends the tags owned by github.com/TicketsBot/database. An empty PanelIds or RoleIds means the tag is
+// not restricted by panel or role respectively.
+type TagSettings struct {
+	PanelIds   []int
+	RoleIds    []uint64
+	PostAction TagPostAction
+}
+
+type TagSettingsTable struct {
+	*pgxpool.Pool
+}
+
+func newTagSettingsTable(db *pgxpool.Pool) *TagSettingsTable {
+	return &TagSettingsTable{
+		db,
+	}
+}
+
+func (t TagSettingsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS tag_settings(
+	"guild_id" int8 NOT NULL,
+	"tag_id" VARCHAR(16) NOT NULL,
+	"panel_ids" int4[] NOT NULL DEFAULT '{}',
+	"role_ids" int8[] NOT NULL DEFAULT '{}',
+	"post_action" int2 NOT NULL DEFAULT 0,
+	FOREIGN KEY("guild_id", "tag_id") REFERENCES tags("guild_id", "tag_id") ON DELETE CASCADE ON UPDATE CASCADE,
+	PRIMARY KEY("guild_id", "tag_id")
+);
+`
+}
+
+// Get returns the zero value if the tag has no settings
+func (t *TagSettingsTable) Get(guildId uint64, tagId string) (settings TagSettings, err error) {
+	query := `SELECT "panel_ids", "role_ids", "post_action" FROM tag_settings WHERE "guild_id" = $1 AND "tag_id" = $2;`
+
+	if err = t.QueryRow(context.Background(), query, guildId, tagId).Scan(&settings.PanelIds, &settings.RoleIds, &settings.PostAction); err == pgx.ErrNoRows {
+		err = nil
+	}
+
+	return
+}
+
+func (t *TagSettingsTable) AddRestrictions(guildId uint64, tagId string, panelIds []int, roleIds []uint64) (err error) {
+	query := `
+INSERT INTO tag_settings("guild_id", "tag_id", "panel_ids", "role_ids")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id", "tag_id") DO UPDATE SET
+	"panel_ids" = ARRAY(SELECT DISTINCT UNNEST(tag_settings."panel_ids" || $3)),
+	"role_ids" = ARRAY(SELECT DISTINCT UNNEST(tag_settings."role_ids" || $4));`
+
+	_, err = t.Exec(context.Background(), query, guildId, tagId, panelIds, roleIds)
+	return
+}
+
+func (t *TagSettingsTable) ClearRestrictions(guildId uint64, tagId string) (err error) {
+	query := `UPDATE tag_settings SET "panel_ids" = '{}', "role_ids" = '{}' WHERE "guild_id" = $1 AND "tag_id" = $2;`
+	_, err = t.Exec(context.Background(), query, guildId, tagId)
+	return
+}
+
+func (t *TagSettingsTable) SetPostAction(guildId uint64, tagId string, action TagPostAction) (err error) {
+	query := `
+INSERT INTO tag_settings("guild_id", "tag_id", "post_action")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "tag_id") DO UPDATE SET "post_action" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, tagId, action)
+	return
+}
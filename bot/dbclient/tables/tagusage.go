@@ -0,0 +1,64 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TagUsageTable struct {
+	*pgxpool.Pool
+}
+
+func newTagUsageTable(db *pgxpool.Pool) *TagUsageTable {
+	return &TagUsageTable{
+		db,
+	}
+}
+
+func (t TagUsageTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS tag_usage(
+	"guild_id" int8 NOT NULL,
+	"tag_id" VARCHAR(16) NOT NULL,
+	"uses" int4 NOT NULL DEFAULT 0,
+	"last_used" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	FOREIGN KEY("guild_id", "tag_id") REFERENCES tags("guild_id", "tag_id") ON DELETE CASCADE ON UPDATE CASCADE,
+	PRIMARY KEY("guild_id", "tag_id")
+);
+`
+}
+
+// GetAll returns the number of times each tag in the guild has been used, keyed by tag ID. Unused tags are omitted.
+func (t *TagUsageTable) GetAll(guildId uint64) (map[string]int, error) {
+	query := `SELECT "tag_id", "uses" FROM tag_usage WHERE "guild_id" = $1;`
+
+	rows, err := t.Query(context.Background(), query, guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	usage := make(map[string]int)
+	for rows.Next() {
+		var tagId string
+		var uses int
+		if err := rows.Scan(&tagId, &uses); err != nil {
+			return nil, err
+		}
+
+		usage[tagId] = uses
+	}
+
+	return usage, rows.Err()
+}
+
+func (t *TagUsageTable) Increment(guildId uint64, tagId string) (err error) {
+	query := `
+INSERT INTO tag_usage("guild_id", "tag_id", "uses", "last_used")
+VALUES($1, $2, 1, NOW())
+ON CONFLICT("guild_id", "tag_id") DO UPDATE SET "uses" = tag_usage."uses" + 1, "last_used" = NOW();`
+
+	_, err = t.Exec(context.Background(), query, guildId, tagId)
+	return
+}
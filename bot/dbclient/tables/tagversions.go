@@ -0,0 +1,131 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// MaxTagVersions is the number of versions retained for each tag, older versions are pruned on write
+const MaxTagVersions = 25
+
+type TagVersion struct {
+	Version  int
+	Content  *string
+	Embed    []byte // JSON encoded embed, nil if the tag has no embed
+	EditedBy *uint64
+	EditedAt time.Time
+}
+
+type TagVersionsTable struct {
+	*pgxpool.Pool
+}
+
+func newTagVersionsTable(db *pgxpool.Pool) *TagVersionsTable {
+	return &TagVersionsTable{
+		db,
+	}
+}
+
+func (t TagVersionsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS tag_versions(
+	"guild_id" int8 NOT NULL,
+	"tag_id" VARCHAR(16) NOT NULL,
+	"version" int4 NOT NULL,
+	"content" TEXT DEFAULT NULL,
+	"embed" JSONB DEFAULT NULL,
+	"edited_by" int8 DEFAULT NULL,
+	"edited_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	FOREIGN KEY("guild_id", "tag_id") REFERENCES tags("guild_id", "tag_id") ON DELETE CASCADE ON UPDATE CASCADE,
+	PRIMARY KEY("guild_id", "tag_id", "version")
+);
+`
+}
+
+// GetRecent returns the most recent versions of a tag, newest first
+func (t *TagVersionsTable) GetRecent(guildId uint64, tagId string, limit int) ([]TagVersion, error) {
+	query := `
+SELECT "version", "content", "embed", "edited_by", "edited_at"
+FROM tag_versions
+WHERE "guild_id" = $1 AND "tag_id" = $2
+ORDER BY "version" DESC
+LIMIT $3;`
+
+	rows, err := t.Query(context.Background(), query, guildId, tagId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var versions []TagVersion
+	for rows.Next() {
+		var version TagVersion
+		if err := rows.Scan(&version.Version, &version.Content, &version.Embed, &version.EditedBy, &version.EditedAt); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
+
+func (t *TagVersionsTable) Get(guildId uint64, tagId string, version int) (tagVersion TagVersion, ok bool, e error) {
+	query := `
+SELECT "version", "content", "embed", "edited_by", "edited_at"
+FROM tag_versions
+WHERE "guild_id" = $1 AND "tag_id" = $2 AND "version" = $3;`
+
+	err := t.QueryRow(context.Background(), query, guildId, tagId, version).Scan(
+		&tagVersion.Version, &tagVersion.Content, &tagVersion.Embed, &tagVersion.EditedBy, &tagVersion.EditedAt,
+	)
+
+	if err == nil {
+		ok = true
+	} else if err != pgx.ErrNoRows {
+		e = err
+	}
+
+	return
+}
+
+func (t *TagVersionsTable) HasAny(guildId uint64, tagId string) (exists bool, e error) {
+	query := `SELECT EXISTS(SELECT 1 FROM tag_versions WHERE "guild_id" = $1 AND "tag_id" = $2);`
+	e = t.QueryRow(context.Background(), query, guildId, tagId).Scan(&exists)
+	return
+}
+
+// Create records a new version of the tag, returning its version number, and prunes versions beyond MaxTagVersions
+func (t *TagVersionsTable) Create(guildId uint64, tagId string, content *string, embed []byte, editedBy *uint64) (version int, e error) {
+	tx, err := t.Begin(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback(context.Background())
+
+	insertQuery := `
+INSERT INTO tag_versions("guild_id", "tag_id", "version", "content", "embed", "edited_by", "edited_at")
+SELECT $1, $2, COALESCE(MAX("version"), 0) + 1, $3, $4, $5, NOW()
+FROM tag_versions
+WHERE "guild_id" = $1 AND "tag_id" = $2
+RETURNING "version";`
+
+	if err := tx.QueryRow(context.Background(), insertQuery, guildId, tagId, content, embed, editedBy).Scan(&version); err != nil {
+		return 0, err
+	}
+
+	pruneQuery := `DELETE FROM tag_versions WHERE "guild_id" = $1 AND "tag_id" = $2 AND "version" <= $3;`
+	if _, err := tx.Exec(context.Background(), pruneQuery, guildId, tagId, version-MaxTagVersions); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,81 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TicketFormAnswer struct {
+	FormInputId int
+	Label       string
+	Answer      string
+}
+
+// TicketFormAnswersTable stores the answers given to a panel's form when each ticket was opened. The label is copied,
+// so that answers still make sense after the form has been edited or deleted.
+type TicketFormAnswersTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketFormAnswersTable(db *pgxpool.Pool) *TicketFormAnswersTable {
+	return &TicketFormAnswersTable{
+		db,
+	}
+}
+
+func (t TicketFormAnswersTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_form_answers(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"form_input_id" int4 NOT NULL,
+	"label" varchar(255) NOT NULL,
+	"answer" text NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "form_input_id")
+);
+CREATE INDEX IF NOT EXISTS ticket_form_answers_search ON ticket_form_answers USING GIN(to_tsvector('simple', "answer"));
+`
+}
+
+func (t *TicketFormAnswersTable) GetByTicket(guildId uint64, ticketId int) ([]TicketFormAnswer, error) {
+	query := `
+SELECT "form_input_id", "label", "answer"
+FROM ticket_form_answers
+WHERE "guild_id" = $1 AND "ticket_id" = $2
+ORDER BY "form_input_id";`
+
+	rows, err := t.Query(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var answers []TicketFormAnswer
+	for rows.Next() {
+		var answer TicketFormAnswer
+		if err := rows.Scan(&answer.FormInputId, &answer.Label, &answer.Answer); err != nil {
+			return nil, err
+		}
+
+		answers = append(answers, answer)
+	}
+
+	return answers, rows.Err()
+}
+
+func (t *TicketFormAnswersTable) Create(guildId uint64, ticketId int, answers []TicketFormAnswer) error {
+	query := `
+INSERT INTO ticket_form_answers("guild_id", "ticket_id", "form_input_id", "label", "answer")
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT("guild_id", "ticket_id", "form_input_id") DO UPDATE SET "label" = $4, "answer" = $5;`
+
+	batch := &pgx.Batch{}
+	for _, answer := range answers {
+		batch.Queue(query, guildId, ticketId, answer.FormInputId, answer.Label, answer.Answer)
+	}
+
+	return t.SendBatch(context.Background(), batch).Close()
+}
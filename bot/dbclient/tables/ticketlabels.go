@@ -0,0 +1,88 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const MaxTicketLabelLength = 32
+
+type TicketLabelsTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketLabelsTable(db *pgxpool.Pool) *TicketLabelsTable {
+	return &TicketLabelsTable{
+		db,
+	}
+}
+
+func (t TicketLabelsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_labels(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"label" varchar(32) NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "label")
+);
+CREATE INDEX IF NOT EXISTS ticket_labels_guild_label ON ticket_labels("guild_id", "label");
+`
+}
+
+func (t *TicketLabelsTable) GetByTicket(guildId uint64, ticketId int) ([]string, error) {
+	query := `SELECT "label" FROM ticket_labels WHERE "guild_id" = $1 AND "ticket_id" = $2 ORDER BY "label";`
+
+	rows, err := t.Query(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// GetTicketsWithLabel returns the IDs of the guild's tickets that have the label. Labels are case-insensitive.
+func (t *TicketLabelsTable) GetTicketsWithLabel(guildId uint64, label string) ([]int, error) {
+	query := `SELECT "ticket_id" FROM ticket_labels WHERE "guild_id" = $1 AND "label" = LOWER($2);`
+
+	rows, err := t.Query(context.Background(), query, guildId, label)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var ticketIds []int
+	for rows.Next() {
+		var ticketId int
+		if err := rows.Scan(&ticketId); err != nil {
+			return nil, err
+		}
+
+		ticketIds = append(ticketIds, ticketId)
+	}
+
+	return ticketIds, rows.Err()
+}
+
+func (t *TicketLabelsTable) Add(guildId uint64, ticketId int, label string) (err error) {
+	query := `
+INSERT INTO ticket_labels("guild_id", "ticket_id", "label")
+VALUES($1, $2, LOWER($3))
+ON CONFLICT("guild_id", "ticket_id", "label") DO NOTHING;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, label)
+	return
+}
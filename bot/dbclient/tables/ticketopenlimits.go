@@ -0,0 +1,80 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type TicketOpenLimits struct {
+	// How many tickets may be opened in the guild, by anyone, within GuildInterval
+	GuildLimit    int
+	GuildInterval time.Duration
+	// How long a user must wait between opening tickets in the guild. Zero means no cooldown.
+	UserCooldown time.Duration
+}
+
+var DefaultTicketOpenLimits = TicketOpenLimits{
+	GuildLimit:    10,
+	GuildInterval: time.Second * 30,
+	UserCooldown:  0,
+}
+
+type TicketOpenLimitsTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketOpenLimitsTable(db *pgxpool.Pool) *TicketOpenLimitsTable {
+	return &TicketOpenLimitsTable{
+		db,
+	}
+}
+
+func (t TicketOpenLimitsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_open_limits(
+	"guild_id" int8 NOT NULL,
+	"guild_limit" int4 NOT NULL,
+	"guild_interval" int4 NOT NULL,
+	"user_cooldown" int4 NOT NULL DEFAULT 0,
+	PRIMARY KEY("guild_id")
+);
+`
+}
+
+// Get returns DefaultTicketOpenLimits if the guild has not configured any limits. Durations are stored in seconds.
+func (t *TicketOpenLimitsTable) Get(guildId uint64) (TicketOpenLimits, error) {
+	query := `SELECT "guild_limit", "guild_interval", "user_cooldown" FROM ticket_open_limits WHERE "guild_id" = $1;`
+
+	var guildLimit, guildInterval, userCooldown int
+	if err := t.QueryRow(context.Background(), query, guildId).Scan(&guildLimit, &guildInterval, &userCooldown); err != nil {
+		if err == pgx.ErrNoRows {
+			return DefaultTicketOpenLimits, nil
+		}
+
+		return TicketOpenLimits{}, err
+	}
+
+	return TicketOpenLimits{
+		GuildLimit:    guildLimit,
+		GuildInterval: time.Duration(guildInterval) * time.Second,
+		UserCooldown:  time.Duration(userCooldown) * time.Second,
+	}, nil
+}
+
+func (t *TicketOpenLimitsTable) Set(guildId uint64, limits TicketOpenLimits) (err error) {
+	query := `
+INSERT INTO ticket_open_limits("guild_id", "guild_limit", "guild_interval", "user_cooldown")
+VALUES($1, $2, $3, $4)
+ON CONFLICT("guild_id") DO UPDATE SET "guild_limit" = $2, "guild_interval" = $3, "user_cooldown" = $4;`
+
+	_, err = t.Exec(context.Background(), query,
+		guildId,
+		limits.GuildLimit,
+		int(limits.GuildInterval.Seconds()),
+		int(limits.UserCooldown.Seconds()),
+	)
+
+	return
+}
@@ -0,0 +1,59 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type TicketPriority int16
+
+const (
+	PriorityLow TicketPriority = iota
+	PriorityNormal
+	PriorityHigh
+	PriorityUrgent
+)
+
+type TicketPriorityTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketPriorityTable(db *pgxpool.Pool) *TicketPriorityTable {
+	return &TicketPriorityTable{
+		db,
+	}
+}
+
+func (t TicketPriorityTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_priority(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"priority" int2 NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+`
+}
+
+// Get returns PriorityNormal if no priority has been set
+func (t *TicketPriorityTable) Get(guildId uint64, ticketId int) (priority TicketPriority, err error) {
+	query := `SELECT "priority" FROM ticket_priority WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	if err = t.QueryRow(context.Background(), query, guildId, ticketId).Scan(&priority); err == pgx.ErrNoRows {
+		return PriorityNormal, nil
+	}
+
+	return
+}
+
+func (t *TicketPriorityTable) Set(guildId uint64, ticketId int, priority TicketPriority) (err error) {
+	query := `
+INSERT INTO ticket_priority("guild_id", "ticket_id", "priority")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "ticket_id") DO UPDATE SET "priority" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, priority)
+	return
+}
@@ -0,0 +1,127 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+// TicketSearchFilter is stored as JSON between pages of results, so every field must be serialisable
+type TicketSearchFilter struct {
+	OpenerId  *uint64    `json:"opener_id,omitempty"`
+	PanelId   *int       `json:"panel_id,omitempty"`
+	ClaimerId *uint64    `json:"claimer_id,omitempty"`
+	Text      *string    `json:"text,omitempty"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	Open      *bool      `json:"open,omitempty"`
+}
+
+type TicketSearchResult struct {
+	Id          int
+	OpenerId    uint64
+	ClaimerId   *uint64
+	ChannelId   *uint64
+	PanelTitle  *string
+	Open        bool
+	OpenTime    time.Time
+	CloseTime   *time.Time
+	Subject     *string
+	CloseReason *string
+}
+
+// TicketSearchQueries searches tickets across the tables owned by github.com/TicketsBot/database and the worker. It has
+// no schema of its own.
+type TicketSearchQueries struct {
+	*pgxpool.Pool
+}
+
+func newTicketSearchQueries(db *pgxpool.Pool) *TicketSearchQueries {
+	return &TicketSearchQueries{
+		db,
+	}
+}
+
+// Search returns up to limit tickets matching every filter that has been set, newest first. Text is matched against the
+// subject, the close reason and each form answer, with every word having to appear in the same one. The simple
+// configuration is used as guilds write in many languages, so words are matched without stemming. It must match the
+// configuration used by the indexes on ticket_subjects and ticket_form_answers, or they won't be used.
+func (q *TicketSearchQueries) Search(guildId uint64, filter TicketSearchFilter, offset, limit int) ([]TicketSearchResult, error) {
+	query := `
+SELECT
+	tickets."id",
+	tickets."user_id",
+	ticket_claims."user_id",
+	tickets."channel_id",
+	panels."title",
+	tickets."open",
+	tickets."open_time",
+	tickets."close_time",
+	ticket_subjects."subject",
+	close_reason."close_reason"
+FROM tickets
+LEFT OUTER JOIN ticket_claims
+ON tickets."guild_id" = ticket_claims."guild_id" AND tickets."id" = ticket_claims."ticket_id"
+LEFT OUTER JOIN panels
+ON tickets."panel_id" = panels."panel_id"
+LEFT OUTER JOIN ticket_subjects
+ON tickets."guild_id" = ticket_subjects."guild_id" AND tickets."id" = ticket_subjects."ticket_id"
+LEFT OUTER JOIN close_reason
+ON tickets."guild_id" = close_reason."guild_id" AND tickets."id" = close_reason."ticket_id"
+WHERE tickets."guild_id" = $1
+	AND ($2::int8 IS NULL OR tickets."user_id" = $2)
+	AND ($3::int4 IS NULL OR tickets."panel_id" = $3)
+	AND ($4::int8 IS NULL OR ticket_claims."user_id" = $4)
+	AND ($5::timestamptz IS NULL OR tickets."open_time" >= $5)
+	AND ($6::timestamptz IS NULL OR tickets."open_time" < $6)
+	AND ($7::bool IS NULL OR tickets."open" = $7)
+	AND ($8::text IS NULL OR tickets."id" IN (
+		SELECT ticket_subjects."ticket_id"
+		FROM ticket_subjects
+		WHERE ticket_subjects."guild_id" = $1 AND to_tsvector('simple', ticket_subjects."subject") @@ plainto_tsquery('simple', $8)
+		UNION
+		SELECT ticket_form_answers."ticket_id"
+		FROM ticket_form_answers
+		WHERE ticket_form_answers."guild_id" = $1 AND to_tsvector('simple', ticket_form_answers."answer") @@ plainto_tsquery('simple', $8)
+		UNION
+		SELECT close_reason."ticket_id"
+		FROM close_reason
+		WHERE close_reason."guild_id" = $1 AND to_tsvector('simple', close_reason."close_reason") @@ plainto_tsquery('simple', $8)
+	))
+ORDER BY tickets."id" DESC
+OFFSET $9
+LIMIT $10;`
+
+	rows, err := q.Query(context.Background(), query,
+		guildId, filter.OpenerId, filter.PanelId, filter.ClaimerId, filter.From, filter.To, filter.Open, filter.Text, offset, limit,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var results []TicketSearchResult
+	for rows.Next() {
+		var result TicketSearchResult
+		if err := rows.Scan(
+			&result.Id,
+			&result.OpenerId,
+			&result.ClaimerId,
+			&result.ChannelId,
+			&result.PanelTitle,
+			&result.Open,
+			&result.OpenTime,
+			&result.CloseTime,
+			&result.Subject,
+			&result.CloseReason,
+		); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
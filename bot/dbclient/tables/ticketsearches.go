@@ -0,0 +1,72 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type TicketSearch struct {
+	Id      int
+	GuildId uint64
+	UserId  uint64
+	Filter  TicketSearchFilter
+}
+
+// TicketSearchesTable stores the filters of each search, as they are too long to fit in the custom IDs of the
+// pagination buttons
+type TicketSearchesTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketSearchesTable(db *pgxpool.Pool) *TicketSearchesTable {
+	return &TicketSearchesTable{
+		db,
+	}
+}
+
+func (t TicketSearchesTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_searches(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"user_id" int8 NOT NULL,
+	"filter" jsonb NOT NULL,
+	"created_at" TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS ticket_searches_created_at ON ticket_searches("created_at");
+`
+}
+
+func (t *TicketSearchesTable) Get(id int) (search TicketSearch, ok bool, err error) {
+	query := `SELECT "id", "guild_id", "user_id", "filter" FROM ticket_searches WHERE "id" = $1;`
+
+	err = t.QueryRow(context.Background(), query, id).Scan(&search.Id, &search.GuildId, &search.UserId, &search.Filter)
+	if err == pgx.ErrNoRows {
+		return TicketSearch{}, false, nil
+	} else if err != nil {
+		return TicketSearch{}, false, err
+	}
+
+	return search, true, nil
+}
+
+// Create stores the search, returning its ID
+func (t *TicketSearchesTable) Create(guildId, userId uint64, filter TicketSearchFilter) (id int, err error) {
+	query := `
+INSERT INTO ticket_searches("guild_id", "user_id", "filter", "created_at")
+VALUES($1, $2, $3, NOW())
+RETURNING "id";`
+
+	err = t.QueryRow(context.Background(), query, guildId, userId, filter).Scan(&id)
+	return
+}
+
+// DeleteBefore removes searches that are old enough that their results will no longer be paged through
+func (t *TicketSearchesTable) DeleteBefore(before time.Time) (err error) {
+	query := `DELETE FROM ticket_searches WHERE "created_at" < $1;`
+	_, err = t.Exec(context.Background(), query, before)
+	return
+}
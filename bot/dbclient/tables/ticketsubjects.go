@@ -0,0 +1,54 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// TicketSubjectsTable stores the subject each ticket was opened with, which is otherwise only kept in the welcome
+// message and the channel topic
+type TicketSubjectsTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketSubjectsTable(db *pgxpool.Pool) *TicketSubjectsTable {
+	return &TicketSubjectsTable{
+		db,
+	}
+}
+
+func (t TicketSubjectsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_subjects(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"subject" varchar(256) NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id")
+);
+CREATE INDEX IF NOT EXISTS ticket_subjects_search ON ticket_subjects USING GIN(to_tsvector('simple', "subject"));
+`
+}
+
+func (t *TicketSubjectsTable) Get(guildId uint64, ticketId int) (subject string, ok bool, err error) {
+	query := `SELECT "subject" FROM ticket_subjects WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	if err = t.QueryRow(context.Background(), query, guildId, ticketId).Scan(&subject); err == pgx.ErrNoRows {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	return subject, true, nil
+}
+
+func (t *TicketSubjectsTable) Set(guildId uint64, ticketId int, subject string) (err error) {
+	query := `
+INSERT INTO ticket_subjects("guild_id", "ticket_id", "subject")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "ticket_id") DO UPDATE SET "subject" = $3;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, subject)
+	return
+}
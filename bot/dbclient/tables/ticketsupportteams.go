@@ -0,0 +1,70 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// TicketSupportTeamsTable stores support teams that have been assigned to an individual ticket, such as by a routing
+// rule. These replace the default team and the teams assigned to the ticket's panel.
+type TicketSupportTeamsTable struct {
+	*pgxpool.Pool
+}
+
+func newTicketSupportTeamsTable(db *pgxpool.Pool) *TicketSupportTeamsTable {
+	return &TicketSupportTeamsTable{
+		db,
+	}
+}
+
+func (t TicketSupportTeamsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS ticket_support_teams(
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"team_id" int4 NOT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	FOREIGN KEY("team_id") REFERENCES support_team("id") ON DELETE CASCADE,
+	PRIMARY KEY("guild_id", "ticket_id", "team_id")
+);
+`
+}
+
+func (t *TicketSupportTeamsTable) Get(guildId uint64, ticketId int) ([]int, error) {
+	query := `SELECT "team_id" FROM ticket_support_teams WHERE "guild_id" = $1 AND "ticket_id" = $2;`
+
+	rows, err := t.Query(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var teamIds []int
+	for rows.Next() {
+		var teamId int
+		if err := rows.Scan(&teamId); err != nil {
+			return nil, err
+		}
+
+		teamIds = append(teamIds, teamId)
+	}
+
+	return teamIds, rows.Err()
+}
+
+func (t *TicketSupportTeamsTable) Add(guildId uint64, ticketId, teamId int) (err error) {
+	query := `
+INSERT INTO ticket_support_teams("guild_id", "ticket_id", "team_id")
+VALUES($1, $2, $3)
+ON CONFLICT("guild_id", "ticket_id", "team_id") DO NOTHING;`
+
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, teamId)
+	return
+}
+
+func (t *TicketSupportTeamsTable) Remove(guildId uint64, ticketId, teamId int) (err error) {
+	query := `DELETE FROM ticket_support_teams WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "team_id" = $3;`
+	_, err = t.Exec(context.Background(), query, guildId, ticketId, teamId)
+	return
+}
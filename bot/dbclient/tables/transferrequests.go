@@ -0,0 +1,229 @@
+package tables
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
+)
+
+type TransferRequestStatus int16
+
+const (
+	TransferRequestPending TransferRequestStatus = iota
+	TransferRequestAccepted
+	TransferRequestDeclined
+	TransferRequestExpired
+	TransferRequestCancelled
+)
+
+// TransferRequest is a request for a ticket to be claimed by a staff member, or by anyone on a support team. Transfers
+// made without a request are stored as already accepted with Direct set, so that the table holds the full transfer
+// history of each ticket. ChannelId and MessageId point to the message with the accept and decline buttons, once it has been sent.
+type TransferRequest struct {
+	Id           int
+	GuildId      uint64
+	TicketId     int
+	RequesterId  uint64
+	TargetUserId *uint64
+	TargetTeamId *int
+	Direct       bool
+	ChannelId    *uint64
+	MessageId    *uint64
+	Status       TransferRequestStatus
+	ResolvedBy   *uint64
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	ResolvedAt   *time.Time
+}
+
+type TransferRequestsTable struct {
+	*pgxpool.Pool
+}
+
+func newTransferRequestsTable(db *pgxpool.Pool) *TransferRequestsTable {
+	return &TransferRequestsTable{
+		db,
+	}
+}
+
+func (t TransferRequestsTable) Schema() string {
+	return `
+CREATE TABLE IF NOT EXISTS transfer_requests(
+	"id" SERIAL NOT NULL UNIQUE,
+	"guild_id" int8 NOT NULL,
+	"ticket_id" int4 NOT NULL,
+	"requester_id" int8 NOT NULL,
+	"target_user_id" int8 DEFAULT NULL,
+	"target_team_id" int4 DEFAULT NULL,
+	"direct" bool NOT NULL,
+	"channel_id" int8 DEFAULT NULL,
+	"message_id" int8 DEFAULT NULL,
+	"status" int2 NOT NULL,
+	"resolved_by" int8 DEFAULT NULL,
+	"created_at" TIMESTAMPTZ NOT NULL,
+	"expires_at" TIMESTAMPTZ NOT NULL,
+	"resolved_at" TIMESTAMPTZ DEFAULT NULL,
+	FOREIGN KEY("guild_id", "ticket_id") REFERENCES tickets("guild_id", "id") ON DELETE CASCADE,
+	PRIMARY KEY("id")
+);
+CREATE INDEX IF NOT EXISTS transfer_requests_guild_ticket ON transfer_requests("guild_id", "ticket_id");
+CREATE INDEX IF NOT EXISTS transfer_requests_pending_expires_at ON transfer_requests("expires_at") WHERE "status" = 0;
+`
+}
+
+const transferRequestColumns = `"id", "guild_id", "ticket_id", "requester_id", "target_user_id", "target_team_id", "direct", "channel_id", "message_id", "status", "resolved_by", "created_at", "expires_at", "resolved_at"`
+
+func scanTransferRequest(row pgx.Row) (request TransferRequest, err error) {
+	err = row.Scan(
+		&request.Id,
+		&request.GuildId,
+		&request.TicketId,
+		&request.RequesterId,
+		&request.TargetUserId,
+		&request.TargetTeamId,
+		&request.Direct,
+		&request.ChannelId,
+		&request.MessageId,
+		&request.Status,
+		&request.ResolvedBy,
+		&request.CreatedAt,
+		&request.ExpiresAt,
+		&request.ResolvedAt,
+	)
+
+	return
+}
+
+func (t *TransferRequestsTable) Get(id int) (request TransferRequest, ok bool, err error) {
+	query := `SELECT ` + transferRequestColumns + ` FROM transfer_requests WHERE "id" = $1;`
+
+	request, err = scanTransferRequest(t.QueryRow(context.Background(), query, id))
+	if err == pgx.ErrNoRows {
+		return TransferRequest{}, false, nil
+	} else if err != nil {
+		return TransferRequest{}, false, err
+	}
+
+	return request, true, nil
+}
+
+// GetByTicket returns the ticket's transfer history, the oldest first
+func (t *TransferRequestsTable) GetByTicket(guildId uint64, ticketId int) ([]TransferRequest, error) {
+	query := `SELECT ` + transferRequestColumns + ` FROM transfer_requests WHERE "guild_id" = $1 AND "ticket_id" = $2 ORDER BY "created_at" ASC;`
+
+	rows, err := t.Query(context.Background(), query, guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var requests []TransferRequest
+	for rows.Next() {
+		request, err := scanTransferRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
+
+// HasPending returns whether the ticket has a request that has not yet been resolved or expired
+func (t *TransferRequestsTable) HasPending(guildId uint64, ticketId int) (exists bool, err error) {
+	query := `
+SELECT EXISTS(
+	SELECT 1 FROM transfer_requests
+	WHERE "guild_id" = $1 AND "ticket_id" = $2 AND "status" = $3 AND "expires_at" > NOW()
+);`
+
+	err = t.QueryRow(context.Background(), query, guildId, ticketId, TransferRequestPending).Scan(&exists)
+	return
+}
+
+// Create stores the request, returning its ID. The ID, channel ID, message ID and creation time are ignored.
+func (t *TransferRequestsTable) Create(request TransferRequest) (id int, err error) {
+	query := `
+INSERT INTO transfer_requests("guild_id", "ticket_id", "requester_id", "target_user_id", "target_team_id", "direct", "status", "resolved_by", "created_at", "expires_at", "resolved_at")
+VALUES($1, $2, $3, $4, $5, $6, $7, $8, NOW(), $9, $10)
+RETURNING "id";`
+
+	err = t.QueryRow(context.Background(), query,
+		request.GuildId,
+		request.TicketId,
+		request.RequesterId,
+		request.TargetUserId,
+		request.TargetTeamId,
+		request.Direct,
+		request.Status,
+		request.ResolvedBy,
+		request.ExpiresAt,
+		request.ResolvedAt,
+	).Scan(&id)
+	return
+}
+
+func (t *TransferRequestsTable) SetMessage(id int, channelId, messageId uint64) (err error) {
+	query := `UPDATE transfer_requests SET "channel_id" = $2, "message_id" = $3 WHERE "id" = $1;`
+	_, err = t.Exec(context.Background(), query, id, channelId, messageId)
+	return
+}
+
+// Resolve returns false if the request had already been resolved or had expired. As only one caller can resolve each
+// request, it is safe to act on the request when this returns true.
+func (t *TransferRequestsTable) Resolve(id int, status TransferRequestStatus, resolvedBy *uint64) (bool, error) {
+	query := `
+UPDATE transfer_requests
+SET "status" = $2, "resolved_by" = $3, "resolved_at" = NOW()
+WHERE "id" = $1 AND "status" = $4 AND "expires_at" > NOW();`
+
+	res, err := t.Exec(context.Background(), query, id, status, resolvedBy, TransferRequestPending)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Reopen returns an accepted request to pending, for when the transfer could not be completed
+func (t *TransferRequestsTable) Reopen(id int) (err error) {
+	query := `
+UPDATE transfer_requests
+SET "status" = $2, "resolved_by" = NULL, "resolved_at" = NULL
+WHERE "id" = $1 AND "status" = $3;`
+
+	_, err = t.Exec(context.Background(), query, id, TransferRequestPending, TransferRequestAccepted)
+	return
+}
+
+// ExpirePending marks pending requests that have passed their expiry as expired, returning them. Each request is only
+// returned to one worker.
+func (t *TransferRequestsTable) ExpirePending() ([]TransferRequest, error) {
+	query := `
+UPDATE transfer_requests
+SET "status" = $1, "resolved_at" = NOW()
+WHERE "status" = $2 AND "expires_at" <= NOW()
+RETURNING ` + transferRequestColumns + `;`
+
+	rows, err := t.Query(context.Background(), query, TransferRequestExpired, TransferRequestPending)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var requests []TransferRequest
+	for rows.Next() {
+		request, err := scanTransferRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
@@ -8,6 +8,7 @@ type WorkerErrorContext struct {
 	Guild       uint64
 	User        uint64
 	Channel     uint64
+	TraceId     string
 }
 
 func (w WorkerErrorContext) ToMap() map[string]string {
@@ -25,5 +26,9 @@ func (w WorkerErrorContext) ToMap() map[string]string {
 		m["channel"] = strconv.FormatUint(w.Channel, 10)
 	}
 
+	if w.TraceId != "" {
+		m["trace_id"] = w.TraceId
+	}
+
 	return m
 }
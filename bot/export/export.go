@@ -0,0 +1,134 @@
+package export
+
+import (
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"io"
+	"time"
+)
+
+// Tickets writes every ticket opened within [from, to) to w, returning the number of rows written
+func Tickets(w io.Writer, format Format, guildId uint64, from, to time.Time) (int, error) {
+	writer, err := newRecordWriter(w, format, []string{
+		"ticket_id", "opener_id", "claimer_id", "panel_id", "panel_title", "open_time", "close_time",
+		"close_reason", "closed_by", "rating", "first_responder_id", "first_response_time_seconds",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var count, afterId int
+	for {
+		page, err := dbclient.Tables.Exports.GetTicketsPage(guildId, from, to, afterId, pageSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, record := range page {
+			if err := writer.Write(
+				record.Id,
+				snowflake(record.OpenerId),
+				optionalSnowflake(record.ClaimerId),
+				optional(record.PanelId),
+				optional(record.PanelTitle),
+				timestamp(record.OpenTime),
+				optionalTimestamp(record.CloseTime),
+				optional(record.CloseReason),
+				optionalSnowflake(record.ClosedBy),
+				optional(record.Rating),
+				optionalSnowflake(record.FirstResponderId),
+				optional(record.FirstResponseTimeSeconds),
+			); err != nil {
+				return count, err
+			}
+
+			afterId = record.Id
+			count++
+		}
+
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	return count, writer.Close()
+}
+
+// Ratings writes every rating left on a ticket closed within [from, to) to w, returning the number of rows written
+func Ratings(w io.Writer, format Format, guildId uint64, from, to time.Time) (int, error) {
+	writer, err := newRecordWriter(w, format, []string{"ticket_id", "opener_id", "claimer_id", "rating", "close_time"})
+	if err != nil {
+		return 0, err
+	}
+
+	var count, afterId int
+	for {
+		page, err := dbclient.Tables.Exports.GetRatingsPage(guildId, from, to, afterId, pageSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, record := range page {
+			if err := writer.Write(
+				record.TicketId,
+				snowflake(record.OpenerId),
+				optionalSnowflake(record.ClaimerId),
+				record.Rating,
+				optionalTimestamp(record.CloseTime),
+			); err != nil {
+				return count, err
+			}
+
+			afterId = record.TicketId
+			count++
+		}
+
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	return count, writer.Close()
+}
+
+// Staff writes a summary of each staff member's activity on tickets opened within [from, to) to w, returning the
+// number of rows written
+func Staff(w io.Writer, format Format, guildId uint64, from, to time.Time) (int, error) {
+	writer, err := newRecordWriter(w, format, []string{
+		"user_id", "tickets_answered", "tickets_claimed", "rating_count", "rating_average",
+		"average_first_response_time_seconds",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	var afterUserId uint64
+	for {
+		page, err := dbclient.Tables.Exports.GetStaffPage(guildId, from, to, afterUserId, pageSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, record := range page {
+			if err := writer.Write(
+				snowflake(record.UserId),
+				record.TicketsAnswered,
+				record.TicketsClaimed,
+				record.RatingCount,
+				optional(record.RatingAverage),
+				optional(record.AverageFirstResponseTimeSeconds),
+			); err != nil {
+				return count, err
+			}
+
+			afterUserId = record.UserId
+			count++
+		}
+
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	return count, writer.Close()
+}
@@ -0,0 +1,184 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCsv  Format = "csv"
+	FormatJson Format = "json"
+)
+
+// MaxFileSize is the largest attachment Discord accepts from a bot in a guild without boosts
+const MaxFileSize = 8 * 1024 * 1024
+
+// pageSize is the number of rows fetched from the database at once
+const pageSize = 500
+
+func ParseFormat(raw string) (Format, bool) {
+	switch Format(raw) {
+	case FormatCsv, FormatJson:
+		return Format(raw), true
+	default:
+		return "", false
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatJson {
+		return "application/json"
+	}
+
+	return "text/csv"
+}
+
+// recordWriter writes rows one at a time, so that an export never holds more than a page of rows in memory
+type recordWriter interface {
+	Write(values ...interface{}) error
+	Close() error
+}
+
+func newRecordWriter(w io.Writer, format Format, columns []string) (recordWriter, error) {
+	if format == FormatJson {
+		return newJsonWriter(w, columns)
+	}
+
+	return newCsvWriter(w, columns)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCsvWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{w: writer}, nil
+}
+
+func (w *csvWriter) Write(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			record[i] = ""
+		case string:
+			record[i] = v
+		case int:
+			record[i] = strconv.Itoa(v)
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', 2, 64)
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+
+			record[i] = string(encoded)
+		}
+	}
+
+	return w.w.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	columns []string
+	written bool
+}
+
+func newJsonWriter(w io.Writer, columns []string) (*jsonWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+
+	return &jsonWriter{w: w, columns: columns}, nil
+}
+
+// Write encodes the row as an object, keeping the keys in column order
+func (w *jsonWriter) Write(values ...interface{}) error {
+	prefix := "\n\t{"
+	if w.written {
+		prefix = ",\n\t{"
+	}
+
+	if _, err := io.WriteString(w.w, prefix); err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		key, err := json.Marshal(w.columns[i])
+		if err != nil {
+			return err
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		separator := ","
+		if i == len(values)-1 {
+			separator = "}"
+		}
+
+		if _, err := io.WriteString(w.w, string(key)+":"+string(encoded)+separator); err != nil {
+			return err
+		}
+	}
+
+	w.written = true
+	return nil
+}
+
+func (w *jsonWriter) Close() error {
+	_, err := io.WriteString(w.w, "\n]\n")
+	return err
+}
+
+// Snowflakes are written as strings, as they do not fit in a JSON number without losing precision
+func snowflake(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func optionalSnowflake(id *uint64) interface{} {
+	if id == nil {
+		return nil
+	}
+
+	return snowflake(*id)
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func optionalTimestamp(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return timestamp(*t)
+}
+
+func optional[T any](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
@@ -2,13 +2,16 @@ package integrations
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/TicketsBot/database"
-	"github.com/TicketsBot/worker/bot/metrics/prometheus"
+	"github.com/TicketsBot/worker/bot/metrics"
+	"github.com/TicketsBot/worker/bot/tracing"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
+	"go.opentelemetry.io/otel/attribute"
 	"strconv"
 	"strings"
 )
@@ -25,13 +28,24 @@ var (
 )
 
 func Fetch(
+	ctx context.Context,
 	integration database.CustomIntegration,
 	ticket database.Ticket,
 	secrets []database.SecretWithValue,
 	headers []database.CustomIntegrationHeader,
 	placeholders []database.CustomIntegrationPlaceholder, // Only include placeholders that are actually used
 ) (map[string]string, error) {
-	prometheus.LogIntegrationRequest(integration.Id, ticket.GuildId)
+	metrics.Increment(metrics.IntegrationRequests, metrics.Tags{
+		"integration_id": strconv.Itoa(integration.Id),
+		"guild_id":       metrics.GuildTag(ticket.GuildId),
+	})
+
+	ctx, span := tracing.Start(ctx, "integration.Fetch",
+		attribute.Int("integration_id", integration.Id),
+		tracing.GuildId(ticket.GuildId),
+		tracing.TicketId(ticket.Id),
+	)
+	defer span.End()
 
 	url := strings.ReplaceAll(integration.WebhookUrl, "%user_id%", strconv.FormatUint(ticket.UserId, 10))
 	url = strings.ReplaceAll(url, "%guild_id%", strconv.FormatUint(ticket.GuildId, 10))
@@ -56,8 +70,9 @@ func Fetch(
 		headerMap[header.Name] = value
 	}
 
-	res, err := SecureProxy.DoRequest(integration.HttpMethod, url, headerMap)
+	res, err := SecureProxy.DoRequest(ctx, integration.HttpMethod, url, headerMap)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
@@ -66,6 +81,7 @@ func Fetch(
 
 	var jsonBody map[string]any
 	if err := decoder.Decode(&jsonBody); err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
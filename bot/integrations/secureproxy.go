@@ -2,10 +2,13 @@ package integrations
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/TicketsBot/common/sentry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"io/ioutil"
 	"net/http"
 )
@@ -28,7 +31,7 @@ type secureProxyRequest struct {
 	Headers map[string]string `json:"headers,omitempty"`
 }
 
-func (p *SecureProxyClient) DoRequest(method, url string, headers map[string]string) ([]byte, error) {
+func (p *SecureProxyClient) DoRequest(ctx context.Context, method, url string, headers map[string]string) ([]byte, error) {
 	body := secureProxyRequest{
 		Method:  method,
 		Url:     url,
@@ -40,7 +43,17 @@ func (p *SecureProxyClient) DoRequest(method, url string, headers map[string]str
 		return nil, err
 	}
 
-	res, err := p.client.Post(p.Url+"/proxy", "application/json", bytes.NewBuffer(encoded))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Url+"/proxy", bytes.NewBuffer(encoded))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// Allow the proxy to continue the trace
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	res, err := p.client.Do(req)
 	if err != nil {
 		sentry.Error(err)
 		return nil, errors.New("error proxying request")
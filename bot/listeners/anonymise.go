@@ -0,0 +1,55 @@
+package listeners
+
+import (
+	"context"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/gateway/payloads/events"
+	"time"
+)
+
+// Reposts a staff member's message through the ticket's webhook, if the ticket's panel anonymises staff
+func anonymiseStaffMessage(worker *worker.Context, e *events.MessageCreate, ticket database.Ticket) {
+	settings, err := logic.GetAnonymiseSettings(ticket)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	if !settings.Enabled {
+		return
+	}
+
+	if len(e.Attachments) > logic.MaxStaffReplyAttachments {
+		return
+	}
+
+	ctx, err := buildListenerContext(worker, ticket.GuildId, e.ChannelId, e.Author.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	// The original message will be deleted, so its attachments must be uploaded again
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	files, err := logic.FetchMessageAttachments(timeoutCtx, e.Message)
+	if err != nil {
+		// Leave the original message in place rather than losing the attachments
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	if _, err := logic.RepostStaffMessage(ctx, ticket, e.Author, e.Content, files); err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	if err := worker.DeleteMessage(e.ChannelId, e.Id); err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+	}
+}
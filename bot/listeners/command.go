@@ -12,8 +12,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
-	"github.com/TicketsBot/worker/bot/metrics/prometheus"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/gateway/payloads/events"
@@ -23,6 +22,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -414,9 +414,17 @@ func GetCommandListener() func(*worker.Context, *events.MessageCreate) {
 			valueArgs[i+1] = value
 		}
 
-		go reflect.ValueOf(c.GetExecutor()).Call(valueArgs)
-		statsd.Client.IncrementKey(statsd.KeyCommands)
-		prometheus.LogCommand(e.GuildId, rootCmd.Properties().Name)
+		go func() {
+			start := time.Now()
+			reflect.ValueOf(c.GetExecutor()).Call(valueArgs)
+			metrics.Timing(metrics.CommandDuration, time.Since(start), metrics.Tags{"command": rootCmd.Properties().Name})
+		}()
+
+		metrics.Increment(metrics.Commands, metrics.Tags{
+			"type":     "message",
+			"command":  rootCmd.Properties().Name,
+			"guild_id": metrics.GuildTag(e.GuildId),
+		})
 
 		utils.DeleteAfter(worker, e.ChannelId, e.Id, utils.DeleteAfterSeconds)
 	}
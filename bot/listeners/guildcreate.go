@@ -6,7 +6,7 @@ import (
 	"github.com/TicketsBot/worker"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/rxdn/gdl/gateway/payloads/events"
 	"github.com/rxdn/gdl/objects/auditlog"
 	"github.com/rxdn/gdl/objects/channel/embed"
@@ -31,7 +31,7 @@ func OnGuildCreate(worker *worker.Context, e *events.GuildCreate) {
 	}
 
 	if time.Now().Sub(e.JoinedAt) < time.Minute {
-		statsd.Client.IncrementKey(statsd.KeyJoins)
+		metrics.Increment(metrics.Joins, nil)
 
 		sendIntroMessage(worker, e.Guild, e.Guild.OwnerId)
 
@@ -4,7 +4,7 @@ import (
 	"github.com/TicketsBot/common/sentry"
 	"github.com/TicketsBot/worker"
 	"github.com/TicketsBot/worker/bot/dbclient"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/rxdn/gdl/gateway/payloads/events"
 )
 
@@ -15,7 +15,7 @@ import (
  */
 func OnGuildLeave(worker *worker.Context, e *events.GuildDelete) {
 	if e.Unavailable == nil {
-		statsd.Client.IncrementKey(statsd.KeyLeaves)
+		metrics.Increment(metrics.Leaves, nil)
 
 		if worker.IsWhitelabel {
 			if err := dbclient.Client.WhitelabelGuilds.Delete(worker.BotId, e.Guild.Id); err != nil {
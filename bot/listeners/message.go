@@ -8,7 +8,8 @@ import (
 	"github.com/TicketsBot/database"
 	"github.com/TicketsBot/worker"
 	"github.com/TicketsBot/worker/bot/dbclient"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/redis"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/rxdn/gdl/gateway/payloads/events"
@@ -17,10 +18,11 @@ import (
 
 // proxy messages to web UI + set last message id
 func OnMessage(worker *worker.Context, e *events.MessageCreate) {
-	statsd.Client.IncrementKey(statsd.KeyMessages)
+	metrics.Increment(metrics.Messages, nil)
 
-	// ignore DMs
+	// DMs are only relayed to modmail tickets
 	if e.GuildId == 0 {
+		onModmailDM(worker, e)
 		return
 	}
 
@@ -58,10 +60,20 @@ func OnMessage(worker *worker.Context, e *events.MessageCreate) {
 			if err != nil {
 				sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 			} else if permLevel > permission.Everyone { // check the user is staff
-				// We don't have to check for previous responses due to ON CONFLICT DO NOTHING
-				if err := dbclient.Client.FirstResponseTime.Set(e.GuildId, e.Author.Id, ticket.Id, time.Now().Sub(ticket.OpenTime)); err != nil {
+				if err := recordFirstResponse(e, ticket); err != nil {
 					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
 				}
+
+				// Replies from the claimer restart the claim timeout
+				if err := dbclient.Tables.ClaimActivity.RecordReply(e.GuildId, ticket.Id, e.Author.Id, time.Now()); err != nil {
+					sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+				}
+
+				// Modmail tickets are only visible to staff, so messages are anonymised when they are relayed to the
+				// user, if the guild's modmail settings ask for it, rather than being reposted in the channel
+				if isModmail := relayModmailStaffMessage(worker, e, ticket); !isModmail {
+					anonymiseStaffMessage(worker, e, ticket)
+				}
 			}
 		}
 	}
@@ -85,6 +97,25 @@ func OnMessage(worker *worker.Context, e *events.MessageCreate) {
 	}
 }
 
+// recordFirstResponse skips working out the response time, which may need the panel's business hours, if the ticket
+// has already had a response. The database ignores any later responses regardless, due to ON CONFLICT DO NOTHING.
+func recordFirstResponse(e *events.MessageCreate, ticket database.Ticket) error {
+	responded, err := redis.HasFirstResponse(e.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	if responded {
+		return nil
+	}
+
+	if err := dbclient.Client.FirstResponseTime.Set(e.GuildId, e.Author.Id, ticket.Id, logic.GetResponseTime(ticket, time.Now())); err != nil {
+		return err
+	}
+
+	return redis.SetFirstResponse(e.GuildId, ticket.Id)
+}
+
 func updateLastMessage(worker *worker.Context, msg *events.MessageCreate, ticket database.Ticket, permissionLevel permission.PermissionLevel) error {
 	// If last message was sent by staff, don't reset the timer
 	lastMessage, err := dbclient.Client.TicketLastMessage.Get(ticket.GuildId, ticket.Id)
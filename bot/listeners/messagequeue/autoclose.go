@@ -7,7 +7,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/redis"
 	"github.com/TicketsBot/worker/bot/utils"
 	gdlUtils "github.com/rxdn/gdl/utils"
@@ -20,7 +20,7 @@ func ListenAutoClose() {
 	go autoclose.Listen(redis.Client, ch)
 
 	for ticket := range ch {
-		statsd.Client.IncrementKey(statsd.AutoClose)
+		metrics.Increment(metrics.AutoClose, metrics.Tags{"reason": "inactivity"})
 
 		ticket := ticket
 		go func() {
@@ -8,7 +8,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/logic"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/redis"
 	"github.com/TicketsBot/worker/bot/utils"
 )
@@ -18,7 +18,7 @@ func ListenCloseRequestTimer() {
 	go closerequest.Listen(redis.Client, ch)
 
 	for request := range ch {
-		statsd.Client.IncrementKey(statsd.AutoClose)
+		metrics.Increment(metrics.AutoClose, metrics.Tags{"reason": "close_request"})
 
 		request := request
 		go func() {
@@ -0,0 +1,136 @@
+package listeners
+
+import (
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/gateway/payloads/events"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+)
+
+// Relays a DM into the user's modmail ticket, or prompts them to pick a server if they don't have one
+func onModmailDM(worker *worker.Context, e *events.MessageCreate) {
+	if e.Author.Id == worker.BotId || e.Author.Bot {
+		return
+	}
+
+	session, ok, err := dbclient.Tables.ModmailSessions.GetByUser(worker.BotId, e.Author.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	if ok {
+		ticket, err := dbclient.Client.Tickets.Get(session.TicketId, session.GuildId)
+		if err != nil {
+			sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+			return
+		}
+
+		if ticket.Open {
+			ctx, err := buildListenerContext(worker, session.GuildId, e.ChannelId, e.Author.Id)
+			if err != nil {
+				sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+				return
+			}
+
+			if err := logic.RelayModmailUserMessage(ctx, ticket, e.Message); err != nil {
+				ctx.HandleError(err)
+			}
+
+			return
+		}
+
+		// The ticket was closed without the session being cleaned up, e.g. the channel was deleted
+		if err := dbclient.Tables.ModmailSessions.DeleteByTicket(session.GuildId, session.TicketId); err != nil {
+			sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+			return
+		}
+	}
+
+	guilds, err := logic.ModmailGuilds(worker, e.Author.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return
+	}
+
+	// DMs have always been ignored, so only respond if the user has somewhere to send their message
+	if len(guilds) == 0 {
+		return
+	}
+
+	// The user has not chosen a server yet, so there is no guild language to use
+	promptEmbed := embed.NewEmbed().
+		SetTitle(i18n.GetMessage(i18n.English, i18n.TitleModmail)).
+		SetDescription(i18n.GetMessage(i18n.English, i18n.MessageModmailSelectServer)).
+		SetColor(customisation.GetDefaultColour(customisation.Green))
+
+	data := rest.CreateMessageData{
+		Embeds:     utils.Slice(promptEmbed),
+		Components: []component.Component{logic.BuildModmailGuildSelect(guilds, e.Id)},
+	}
+
+	if _, err := worker.CreateMessageComplex(e.ChannelId, data); err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+	}
+}
+
+// Relays a staff member's reply in a modmail ticket's channel to the user
+// relayModmailStaffMessage returns false if the ticket is not a modmail ticket, in which case the message is left for
+// the other staff message handlers
+func relayModmailStaffMessage(worker *worker.Context, e *events.MessageCreate, ticket database.Ticket) bool {
+	session, ok, err := dbclient.Tables.ModmailSessions.GetByTicket(ticket.GuildId, ticket.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return false
+	}
+
+	if !ok {
+		return false
+	}
+
+	// Only messages marked as replies are sent to the user, the rest are internal notes
+	content, isReply := logic.ParseModmailReply(e.Content)
+	if !isReply {
+		return true
+	}
+
+	settings, err := dbclient.Tables.ModmailSettings.Get(ticket.GuildId)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return true
+	}
+
+	ctx, err := buildListenerContext(worker, ticket.GuildId, e.ChannelId, e.Author.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, utils.MessageCreateErrorContext(e))
+		return true
+	}
+
+	relayed := e.Message
+	relayed.Content = content
+
+	if err := logic.RelayModmailStaffMessage(ctx, session, relayed, settings.AnonymiseStaff); err != nil {
+		ctx.HandleError(err)
+	}
+
+	return true
+}
+
+func buildListenerContext(worker *worker.Context, guildId, channelId, userId uint64) (*context.PanelContext, error) {
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(guildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.NewPanelContext(worker, guildId, channelId, userId, premiumTier)
+	return &ctx, nil
+}
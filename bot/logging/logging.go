@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"fmt"
+	"github.com/TicketsBot/worker/config"
+	"github.com/sirupsen/logrus"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const modulePath = "github.com/TicketsBot/worker"
+
+var (
+	formatter = &logrus.JSONFormatter{
+		TimestampFormat: time.RFC3339Nano,
+	}
+
+	defaultLevel  = logrus.InfoLevel
+	packageLevels = make(map[string]logrus.Level)
+
+	lock    sync.RWMutex
+	loggers = make(map[string]*logrus.Logger) // package path -> logger
+)
+
+// Init reads the log levels from the config. Loggers can be created before Init is called, their levels are updated.
+func Init() error {
+	level, err := logrus.ParseLevel(config.Conf.Logging.Level)
+	if err != nil {
+		return err
+	}
+
+	// In the form bot/logic=debug
+	levels := make(map[string]logrus.Level)
+	for _, raw := range config.Conf.Logging.PackageLevels {
+		split := strings.SplitN(raw, "=", 2)
+		if len(split) != 2 {
+			return fmt.Errorf("invalid package log level: %s", raw)
+		}
+
+		packageLevel, err := logrus.ParseLevel(split[1])
+		if err != nil {
+			return err
+		}
+
+		levels[strings.Trim(split[0], "/ ")] = packageLevel
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	defaultLevel = level
+	packageLevels = levels
+
+	for pkg, logger := range loggers {
+		logger.SetLevel(levelFor(pkg))
+	}
+
+	// Anything still using the global logger, e.g. libraries
+	logrus.SetFormatter(formatter)
+	logrus.SetLevel(level)
+
+	return nil
+}
+
+// For returns a logger for the package with the given path relative to the module root, e.g. bot/logic
+func For(pkg string) *logrus.Entry {
+	return getLogger(pkg).WithField("package", pkg)
+}
+
+// WithCaller returns a logger for the package that the function skip frames above the caller belongs to, so that
+// helpers such as CommandContext.Logger use the level of the package that is logging, rather than their own.
+func WithCaller(skip int, fields logrus.Fields) *logrus.Entry {
+	return For(callerPackage(skip + 1)).WithFields(fields)
+}
+
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	// e.g. github.com/TicketsBot/worker/bot/command/context.(*SlashCommandContext).Logger
+	name := fn.Name()
+	lastSlash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[lastSlash+1:], "."); dot != -1 {
+		name = name[:lastSlash+1+dot]
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(name, modulePath), "/")
+}
+
+func getLogger(pkg string) *logrus.Logger {
+	lock.RLock()
+	logger, ok := loggers[pkg]
+	lock.RUnlock()
+
+	if ok {
+		return logger
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if logger, ok := loggers[pkg]; ok {
+		return logger
+	}
+
+	logger = logrus.New()
+	logger.SetFormatter(formatter)
+	logger.SetLevel(levelFor(pkg))
+	loggers[pkg] = logger
+
+	return logger
+}
+
+// levelFor returns the level of the most specific configured parent package, e.g. bot/command for
+// bot/command/impl/tickets. The lock must be held.
+func levelFor(pkg string) logrus.Level {
+	for pkg != "" {
+		if level, ok := packageLevels[pkg]; ok {
+			return level
+		}
+
+		i := strings.LastIndex(pkg, "/")
+		if i == -1 {
+			break
+		}
+
+		pkg = pkg[:i]
+	}
+
+	return defaultLevel
+}
@@ -0,0 +1,122 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/metrics"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest"
+	"strings"
+)
+
+const blacklistPerPage = 10
+
+func BuildBlacklistMessage(ctx registry.CommandContext, page int) (*embed.Embed, bool) {
+	self, _ := ctx.Worker().Self()
+	embed := embed.NewEmbed().
+		SetColor(ctx.GetColour(customisation.Green)).
+		SetTitle(ctx.GetMessage(i18n.TitleBlacklist)).
+		SetFooter(fmt.Sprintf("Page %d", page+1), self.AvatarUrl(256))
+
+	entries, err := dbclient.Tables.BlacklistEntries.GetPage(ctx.GuildId(), blacklistPerPage, blacklistPerPage*page)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	}
+
+	if len(entries) == 0 {
+		embed.SetDescription(ctx.GetMessage(i18n.MessageBlacklistListEmpty))
+		return embed, true
+	}
+
+	var content string
+	for _, entry := range entries {
+		if entry.IsRole {
+			content += fmt.Sprintf("• <@&%d> (`%d`)", entry.TargetId, entry.TargetId)
+		} else {
+			content += fmt.Sprintf("• <@%d> (`%d`)", entry.TargetId, entry.TargetId)
+		}
+
+		if entry.ExpiresAt == nil {
+			content += " - Permanent"
+		} else {
+			content += fmt.Sprintf(" - Expires %s", message.BuildTimestamp(*entry.ExpiresAt, message.TimestampStyleRelativeTime))
+		}
+
+		if entry.Reason != nil {
+			content += fmt.Sprintf("\n  %s", utils.StringMax(*entry.Reason, 100, "..."))
+		}
+
+		content += "\n"
+	}
+
+	embed.SetDescription(strings.TrimSuffix(content, "\n"))
+
+	return embed, false
+}
+
+// NotifyBlacklisted sends the user a DM explaining why their ticket could not be opened. Errors are not returned, as the
+// user has already been told that they are blacklisted in the channel.
+func NotifyBlacklisted(ctx registry.CommandContext) {
+	member, err := ctx.Member()
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	entry, ok, err := dbclient.Tables.BlacklistEntries.GetForUser(ctx.GuildId(), ctx.UserId(), member.Roles)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	// Nothing to explain, e.g. a global blacklist or one added through the dashboard
+	if !ok || (entry.Reason == nil && entry.ExpiresAt == nil) {
+		return
+	}
+
+	shouldNotify, err := redis.TakeBlacklistNotification(ctx.GuildId(), ctx.UserId())
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	if !shouldNotify {
+		return
+	}
+
+	guild, err := ctx.Guild()
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	dmChannel, ok := getDmChannel(ctx, ctx.UserId())
+	if !ok {
+		return
+	}
+
+	reason := ctx.GetMessage(i18n.MessageBlacklistNoReason)
+	if entry.Reason != nil {
+		reason = *entry.Reason
+	}
+
+	expires := ctx.GetMessage(i18n.MessageBlacklistPermanent)
+	if entry.ExpiresAt != nil {
+		expires = message.BuildTimestamp(*entry.ExpiresAt, message.TimestampStyleShortDateTime)
+	}
+
+	msgEmbed := utils.BuildEmbed(ctx, customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklistedDm, nil, guild.Name, reason, expires)
+
+	metrics.Increment(metrics.DirectMessages, metrics.Tags{"type": "blacklist"})
+
+	if _, err := ctx.Worker().CreateMessageComplex(dmChannel, rest.CreateMessageData{Embeds: utils.Slice(msgEmbed)}); err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	}
+}
@@ -0,0 +1,288 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"strconv"
+	"time"
+)
+
+const (
+	// BulkPreviewTimeout is how long a preview can be confirmed for. Interaction tokens expire after 15 minutes, after
+	// which the preview message can no longer be edited to show progress.
+	BulkPreviewTimeout = time.Minute * 14
+
+	// Ratelimits are handled by the REST client, but spacing out tickets stops a large job from using up the guild's
+	// buckets and delaying everyone else's commands
+	bulkTicketInterval = time.Second
+
+	bulkProgressInterval = 5
+)
+
+type BulkTicketFilter struct {
+	PanelId     *int
+	OpenerId    *uint64
+	OlderThan   *time.Duration
+	InactiveFor *time.Duration
+	Label       *string
+}
+
+type BulkResult struct {
+	Succeeded, Skipped, Failed int
+	Cancelled                  bool
+}
+
+// FindBulkTickets returns the guild's open tickets that match every filter that has been set
+func FindBulkTickets(guildId uint64, filter BulkTicketFilter) ([]database.Ticket, error) {
+	tickets, err := dbclient.Client.Tickets.GetGuildOpenTickets(guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	var labelled map[int]bool
+	if filter.Label != nil {
+		ticketIds, err := dbclient.Tables.TicketLabels.GetTicketsWithLabel(guildId, *filter.Label)
+		if err != nil {
+			return nil, err
+		}
+
+		labelled = make(map[int]bool, len(ticketIds))
+		for _, ticketId := range ticketIds {
+			labelled[ticketId] = true
+		}
+	}
+
+	now := time.Now()
+
+	var inactive map[int]bool
+	if filter.InactiveFor != nil {
+		ticketIds, err := dbclient.Tables.OpenTicketList.GetInactive(guildId, now.Add(-*filter.InactiveFor))
+		if err != nil {
+			return nil, err
+		}
+
+		inactive = make(map[int]bool, len(ticketIds))
+		for _, ticketId := range ticketIds {
+			inactive[ticketId] = true
+		}
+	}
+
+	var matched []database.Ticket
+	for _, ticket := range tickets {
+		if ticket.ChannelId == nil {
+			continue
+		}
+
+		if filter.PanelId != nil && (ticket.PanelId == nil || *ticket.PanelId != *filter.PanelId) {
+			continue
+		}
+
+		if filter.OpenerId != nil && ticket.UserId != *filter.OpenerId {
+			continue
+		}
+
+		if filter.OlderThan != nil && now.Sub(ticket.OpenTime) < *filter.OlderThan {
+			continue
+		}
+
+		if labelled != nil && !labelled[ticket.Id] {
+			continue
+		}
+
+		if inactive != nil && !inactive[ticket.Id] {
+			continue
+		}
+
+		matched = append(matched, ticket)
+	}
+
+	return matched, nil
+}
+
+// RunBulkJob applies the job's action to each of its tickets in turn, stopping early if the job is cancelled. Permission
+// checks are made against actor, while ticketContext returns the context to act on each ticket's channel with.
+// onProgress is called periodically, and once all tickets have been processed.
+func RunBulkJob(
+	actor registry.CommandContext,
+	job tables.BulkTicketJob,
+	ticketContext func(ticket database.Ticket) registry.CommandContext,
+	onProgress func(done int, result BulkResult),
+) (result BulkResult, err error) {
+	var panel database.Panel
+	if job.Action == tables.BulkActionMove {
+		if panel, err = getBulkTargetPanel(job); err != nil {
+			return
+		}
+	}
+
+	var done int
+	for i, ticketId := range job.TicketIds {
+		// The job may have been cancelled from another worker, so check the database rather than any local state
+		status, err := dbclient.Tables.BulkTicketJobs.GetStatus(job.Id)
+		if err != nil {
+			return result, err
+		}
+
+		if status == tables.BulkJobCancelled {
+			result.Cancelled = true
+			break
+		}
+
+		if i > 0 {
+			time.Sleep(bulkTicketInterval)
+		}
+
+		ticket, err := dbclient.Client.Tickets.Get(ticketId, job.GuildId)
+		if err != nil {
+			return result, err
+		}
+
+		// The ticket may have been closed since the preview was generated
+		if ticket.Id == 0 || !ticket.Open || ticket.ChannelId == nil {
+			result.Skipped++
+		} else if applied, err := applyBulkAction(actor, ticketContext(ticket), job, ticket, panel); err != nil {
+			actor.HandleWarning(err)
+			result.Failed++
+		} else if applied {
+			result.Succeeded++
+		} else {
+			result.Skipped++
+		}
+
+		done++
+		if done%bulkProgressInterval == 0 && done < len(job.TicketIds) {
+			onProgress(done, result)
+		}
+	}
+
+	if !result.Cancelled {
+		if err := dbclient.Tables.BulkTicketJobs.Complete(job.Id); err != nil {
+			return result, err
+		}
+	}
+
+	onProgress(done, result)
+	return result, nil
+}
+
+func getBulkTargetPanel(job tables.BulkTicketJob) (database.Panel, error) {
+	if job.Argument == nil {
+		return database.Panel{}, errors.New("bulk move job has no panel")
+	}
+
+	panelId, err := strconv.Atoi(*job.Argument)
+	if err != nil {
+		return database.Panel{}, err
+	}
+
+	panel, err := dbclient.Client.Panel.GetById(panelId)
+	if err != nil {
+		return database.Panel{}, err
+	}
+
+	if panel.PanelId == 0 || panel.GuildId != job.GuildId {
+		return database.Panel{}, errors.New("bulk move target panel no longer exists")
+	}
+
+	return panel, nil
+}
+
+// applyBulkAction returns false if the ticket was skipped because the action did not apply to it
+func applyBulkAction(actor, ctx registry.CommandContext, job tables.BulkTicketJob, ticket database.Ticket, panel database.Panel) (bool, error) {
+	switch job.Action {
+	case tables.BulkActionClose:
+		if !utils.CanClose(actor, ticket) {
+			return false, nil
+		}
+
+		// CloseTicket handles its own errors, so check whether the ticket was actually closed
+		CloseTicket(ctx, job.Argument)
+
+		ticket, err := dbclient.Client.Tickets.Get(ticket.Id, ticket.GuildId)
+		if err != nil {
+			return false, err
+		}
+
+		if ticket.Open {
+			return false, fmt.Errorf("ticket %d was not closed", ticket.Id)
+		}
+
+		return true, nil
+	case tables.BulkActionClaim:
+		claimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
+		if err != nil {
+			return false, err
+		}
+
+		if claimer != 0 {
+			return false, nil
+		}
+
+		if err := ClaimTicket(ctx, ticket, actor.UserId()); err != nil {
+			// Once the actor has reached the limit, the remaining tickets are skipped
+			var limitErr ClaimLimitError
+			if errors.As(err, &limitErr) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		claimedEmbed := utils.BuildEmbed(ctx, customisation.Green, i18n.TitleClaimed, i18n.MessageClaimed, nil, fmt.Sprintf("<@%d>", actor.UserId()))
+		if _, err := ctx.Worker().CreateMessageEmbed(*ticket.ChannelId, claimedEmbed); err != nil {
+			ctx.HandleWarning(err)
+		}
+
+		return true, nil
+	case tables.BulkActionLabel:
+		if job.Argument == nil {
+			return false, errors.New("bulk label job has no label")
+		}
+
+		if err := dbclient.Tables.TicketLabels.Add(ticket.GuildId, ticket.Id, *job.Argument); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	case tables.BulkActionMove:
+		if ticket.PanelId != nil && *ticket.PanelId == panel.PanelId {
+			return false, nil
+		}
+
+		if err := SwitchPanel(ctx, ticket, panel); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown bulk action %d", job.Action)
+	}
+}
+
+func BuildBulkProgressEmbed(ctx registry.CommandContext, done, total int, result BulkResult) *embed.Embed {
+	switch {
+	case result.Cancelled:
+		return utils.BuildEmbed(ctx, customisation.Orange, i18n.TitleBulk, i18n.MessageBulkCancelled, nil, done, total, result.Succeeded, result.Skipped, result.Failed)
+	case done >= total:
+		return utils.BuildEmbed(ctx, customisation.Green, i18n.TitleBulk, i18n.MessageBulkComplete, nil, total, result.Succeeded, result.Skipped, result.Failed)
+	default:
+		return utils.BuildEmbed(ctx, customisation.Blue, i18n.TitleBulk, i18n.MessageBulkProgress, nil, done, total, result.Succeeded, result.Skipped, result.Failed)
+	}
+}
+
+func BuildBulkCancelButton(ctx registry.CommandContext, jobId int) component.Component {
+	return component.BuildActionRow(component.BuildButton(component.Button{
+		Label:    ctx.GetMessage(i18n.MessageBulkCancelButton),
+		CustomId: fmt.Sprintf("bulk_cancel_%d", jobId),
+		Style:    component.ButtonStyleSecondary,
+	}))
+}
@@ -0,0 +1,222 @@
+package logic
+
+import (
+	"fmt"
+	permcache "github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"time"
+)
+
+// Opening hours repeat weekly, so if a panel isn't open within this long, it has no opening hours at all
+const scheduleSearchDays = 7 + 366
+
+type BusinessSchedule struct {
+	Location *time.Location
+	Mode     tables.OutOfHoursMode
+	hours    map[time.Weekday][]tables.OpeningHours
+	holidays map[string]bool
+}
+
+// OutOfHours is passed to the welcome message when a ticket is opened outside of business hours
+type OutOfHours struct {
+	NextOpen *time.Time
+}
+
+// GetBusinessSchedule returns nil if the panel does not have business hours
+func GetBusinessSchedule(panelId int) (*BusinessSchedule, error) {
+	schedule, ok, err := dbclient.Tables.PanelSchedules.Get(panelId)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		// Misconfiguration, fall back to UTC rather than leaving the panel unusable
+		sentry.Error(fmt.Errorf("invalid timezone %s for panel %d: %w", schedule.Timezone, panelId, err))
+		location = time.UTC
+	}
+
+	openingHours, err := dbclient.Tables.PanelOpeningHours.GetByPanel(panelId)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := dbclient.Tables.PanelHolidays.GetByPanel(panelId)
+	if err != nil {
+		return nil, err
+	}
+
+	businessSchedule := &BusinessSchedule{
+		Location: location,
+		Mode:     schedule.Mode,
+		hours:    make(map[time.Weekday][]tables.OpeningHours),
+		holidays: make(map[string]bool),
+	}
+
+	for _, hours := range openingHours {
+		businessSchedule.hours[hours.Weekday] = append(businessSchedule.hours[hours.Weekday], hours)
+	}
+
+	for _, date := range holidays {
+		businessSchedule.holidays[date.Format("2006-01-02")] = true
+	}
+
+	return businessSchedule, nil
+}
+
+// CheckBusinessHours returns whether a ticket may be opened from the panel right now, replying to the user with the
+// next opening time if not. If the ticket may be opened despite being outside of business hours, a non-nil OutOfHours
+// is also returned, so that the user can be warned in the welcome message. Staff are never refused.
+func CheckBusinessHours(ctx registry.CommandContext, panel *database.Panel) (*OutOfHours, bool, error) {
+	if panel == nil {
+		return nil, true, nil
+	}
+
+	schedule, err := GetBusinessSchedule(panel.PanelId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	if schedule == nil || schedule.IsOpen(now) {
+		return nil, true, nil
+	}
+
+	var outOfHours OutOfHours
+	if nextOpen, ok := schedule.NextOpen(now); ok {
+		outOfHours.NextOpen = &nextOpen
+	}
+
+	if schedule.Mode == tables.OutOfHoursModeAccept {
+		return &outOfHours, true, nil
+	}
+
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if permissionLevel >= permcache.Support {
+		return &outOfHours, true, nil
+	}
+
+	if outOfHours.NextOpen == nil {
+		ctx.Reply(customisation.Red, i18n.TitleOutOfHours, i18n.MessageOutOfHoursNoSchedule)
+	} else {
+		ctx.Reply(customisation.Red, i18n.TitleOutOfHours, i18n.MessageOutOfHours, message.BuildTimestamp(*outOfHours.NextOpen, message.TimestampStyleShortDateTime))
+	}
+
+	return nil, false, nil
+}
+
+func (s *BusinessSchedule) IsOpen(t time.Time) bool {
+	// Overnight hours that started the day before may still be running
+	day := s.startOfDay(t)
+	for _, interval := range append(s.intervals(day.AddDate(0, 0, -1)), s.intervals(day)...) {
+		if !t.Before(interval[0]) && t.Before(interval[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOpen returns the next time at or after t that the panel is open
+func (s *BusinessSchedule) NextOpen(t time.Time) (time.Time, bool) {
+	// Start from the day before, in case t falls within overnight hours that started then
+	day := s.startOfDay(t).AddDate(0, 0, -1)
+	for i := 0; i <= scheduleSearchDays; i++ {
+		for _, interval := range s.intervals(day) {
+			if interval[1].After(t) {
+				if interval[0].After(t) {
+					return interval[0], true
+				} else {
+					return t, true
+				}
+			}
+		}
+
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, false
+}
+
+// BusinessDuration returns how much of the time between from and to fell within business hours
+func (s *BusinessSchedule) BusinessDuration(from, to time.Time) time.Duration {
+	var total time.Duration
+	// Start from the day before, in case from falls within overnight hours that started then
+	for day := s.startOfDay(from).AddDate(0, 0, -1); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, interval := range s.intervals(day) {
+			start, end := interval[0], interval[1]
+			if start.Before(from) {
+				start = from
+			}
+
+			if end.After(to) {
+				end = to
+			}
+
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+
+	return total
+}
+
+func (s *BusinessSchedule) startOfDay(t time.Time) time.Time {
+	local := t.In(s.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.Location)
+}
+
+// intervals returns the [open, close) ranges that start on the day that t falls on, in the schedule's timezone. Hours
+// that close at or before they open run overnight, closing on the following day. A holiday only cancels the hours that
+// start on it.
+func (s *BusinessSchedule) intervals(t time.Time) [][2]time.Time {
+	local := t.In(s.Location)
+	if s.holidays[local.Format("2006-01-02")] {
+		return nil
+	}
+
+	var intervals [][2]time.Time
+	for _, hours := range s.hours[local.Weekday()] {
+		openTime := time.Date(local.Year(), local.Month(), local.Day(), hours.OpenMinute/60, hours.OpenMinute%60, 0, 0, s.Location)
+		closeTime := time.Date(local.Year(), local.Month(), local.Day(), hours.CloseMinute/60, hours.CloseMinute%60, 0, 0, s.Location)
+		if hours.IsOvernight() {
+			closeTime = time.Date(local.Year(), local.Month(), local.Day()+1, hours.CloseMinute/60, hours.CloseMinute%60, 0, 0, s.Location)
+		}
+
+		intervals = append(intervals, [2]time.Time{openTime, closeTime})
+	}
+
+	return intervals
+}
+
+// GetResponseTime returns how long after the ticket was opened t is, only counting business hours if the ticket's panel
+// has a schedule
+func GetResponseTime(ticket database.Ticket, t time.Time) time.Duration {
+	if ticket.PanelId == nil {
+		return t.Sub(ticket.OpenTime)
+	}
+
+	schedule, err := GetBusinessSchedule(*ticket.PanelId)
+	if err != nil {
+		sentry.Error(err)
+		return t.Sub(ticket.OpenTime)
+	}
+
+	if schedule == nil {
+		return t.Sub(ticket.OpenTime)
+	}
+
+	return schedule.BusinessDuration(ticket.OpenTime, t)
+}
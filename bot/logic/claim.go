@@ -14,7 +14,41 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// ClaimTicket TODO: Keep /add members
+// ClaimLimitError is returned by ClaimTicket when the user has already claimed as many tickets as the guild allows
+type ClaimLimitError struct {
+	Limit int
+}
+
+func (e ClaimLimitError) Error() string {
+	return fmt.Sprintf("user has already claimed %d tickets", e.Limit)
+}
+
+// CheckClaimLimit returns a ClaimLimitError if claiming the ticket would take the user over the guild's claim limit
+func CheckClaimLimit(ticket database.Ticket, userId uint64) error {
+	settings, err := dbclient.Tables.ClaimLimits.Get(ticket.GuildId)
+	if err != nil {
+		return err
+	}
+
+	if settings.MaxConcurrentClaims == nil {
+		return nil
+	}
+
+	// Re-claiming a ticket doesn't count towards the limit
+	claimed, err := dbclient.Tables.ClaimLimits.CountClaimedBy(ticket.GuildId, userId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	if claimed >= *settings.MaxConcurrentClaims {
+		return ClaimLimitError{Limit: *settings.MaxConcurrentClaims}
+	}
+
+	return nil
+}
+
+// ClaimTicket assigns the ticket to the user, updating its channel's permissions for the claim while keeping any
+// members that were added to the ticket
 func ClaimTicket(ctx registry.CommandContext, ticket database.Ticket, userId uint64) error {
 	if ticket.ChannelId == nil {
 		return errors.New("channel ID is nil")
@@ -33,35 +67,54 @@ func ClaimTicket(ctx registry.CommandContext, ticket database.Ticket, userId uin
 		}
 	}
 
+	if err := CheckClaimLimit(ticket, userId); err != nil {
+		return err
+	}
+
+	// The ticket may be being transferred from another user, who may need to lose access
+	previousClaimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
 	// Set to claimed in DB
 	if err := dbclient.Client.TicketClaims.Set(ticket.GuildId, ticket.Id, userId); err != nil {
 		return err
 	}
 
-	newOverwrites, err := GenerateClaimedOverwrites(ctx.Worker(), ticket, userId)
+	if err := dbclient.Tables.ClaimActivity.Set(ticket.GuildId, ticket.Id, userId); err != nil {
+		return err
+	}
+
+	RequestQueueBoardUpdate(ctx)
+
+	claimSettings, err := dbclient.Client.ClaimSettings.Get(ticket.GuildId)
 	if err != nil {
 		return err
 	}
 
-	// If newOverwrites = nil, no changes to permissions should be made
-	if newOverwrites != nil {
-		channelName, err := GenerateChannelName(ctx, panel, ticket.Id, ticket.UserId, &userId)
-		if err != nil {
-			return err
-		}
+	// If support reps can still view and type, no changes to the channel should be made
+	if claimSettings.SupportCanView && claimSettings.SupportCanType {
+		return nil
+	}
 
-		// Update channel
-		data := rest.ModifyChannelData{
-			Name:                 channelName,
-			PermissionOverwrites: newOverwrites,
-		}
+	channelName, err := GenerateChannelName(ctx, panel, ticket.Id, ticket.UserId, &userId)
+	if err != nil {
+		return err
+	}
 
-		if _, err = ctx.Worker().ModifyChannel(*ticket.ChannelId, data); err != nil {
-			return err
-		}
+	if _, err := ctx.Worker().ModifyChannel(*ticket.ChannelId, rest.ModifyChannelData{Name: channelName}); err != nil {
+		return err
 	}
 
-	return nil
+	staffOnly, err := isStaffOnly(ticket)
+	if err != nil {
+		return err
+	}
+
+	previous := OverwriteState{Panel: panel, Claimer: previousClaimer, StaffOnly: staffOnly}
+	current := OverwriteState{Panel: panel, Claimer: userId, StaffOnly: staffOnly}
+	return ReconcileTicketOverwrites(ctx.Worker(), ticket, previous, current)
 }
 
 // GenerateClaimedOverwrites If support reps can still view and type, returns (nil, nil)
@@ -93,22 +146,31 @@ func GenerateClaimedOverwrites(worker *worker.Context, ticket database.Ticket, c
 
 	// Support can't view the ticket, and therefore can't type either
 	if !claimSettings.SupportCanView {
-		return overwritesCantView(claimer, worker.BotId, ticket.UserId, ticket.GuildId, adminUsers, adminRoles, additionalPermissions), nil
+		return overwritesCantView(claimer, worker.BotId, ticket.UserId, ticket.GuildId, otherUsers, adminUsers, adminRoles, additionalPermissions), nil
 	}
 
 	// Support can view the ticket, but can't type
 	if !claimSettings.SupportCanType {
-		supportUsers, err := dbclient.Client.Permissions.GetSupportOnly(ticket.GuildId)
+		// Teams that the ticket was routed to replace the default team and the panel's teams
+		routed, err := isRoutedToTeam(ticket.GuildId, ticket.Id)
 		if err != nil {
 			return nil, err
 		}
 
-		supportRoles, err := dbclient.Client.RolePermissions.GetSupportRolesOnly(ticket.GuildId)
-		if err != nil {
-			return nil, err
+		var supportUsers, supportRoles []uint64
+		if !routed {
+			supportUsers, err = dbclient.Client.Permissions.GetSupportOnly(ticket.GuildId)
+			if err != nil {
+				return nil, err
+			}
+
+			supportRoles, err = dbclient.Client.RolePermissions.GetSupportRolesOnly(ticket.GuildId)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		if ticket.PanelId != nil {
+		if ticket.PanelId != nil && !routed {
 			group, _ := errgroup.WithContext(context.Background())
 
 			// Get users for support teams of panel
@@ -138,7 +200,15 @@ func GenerateClaimedOverwrites(worker *worker.Context, ticket database.Ticket, c
 			}
 		}
 
-		return overwritesCantType(claimer, worker.BotId, ticket.UserId, ticket.GuildId, supportUsers, supportRoles, adminUsers, adminRoles, additionalPermissions), nil
+		teamUsers, teamRoles, err := getTicketTeamUsersRoles(ticket.GuildId, ticket.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		supportUsers = append(supportUsers, teamUsers...)
+		supportRoles = append(supportRoles, teamRoles...)
+
+		return overwritesCantType(claimer, worker.BotId, ticket.UserId, ticket.GuildId, otherUsers, supportUsers, supportRoles, adminUsers, adminRoles, additionalPermissions), nil
 	}
 
 	// Unreachable
@@ -147,7 +217,7 @@ func GenerateClaimedOverwrites(worker *worker.Context, ticket database.Ticket, c
 
 // We should build new overwrites from scratch
 // TODO: Instead of append(), set indices
-func overwritesCantView(claimer, selfId, openerId, guildId uint64, adminUsers, adminRoles []uint64, additionalPermissions database.TicketPermissions) (overwrites []channel.PermissionOverwrite) {
+func overwritesCantView(claimer, selfId, openerId, guildId uint64, members, adminUsers, adminRoles []uint64, additionalPermissions database.TicketPermissions) (overwrites []channel.PermissionOverwrite) {
 	overwrites = append(overwrites, BuildUserOverwrite(openerId, additionalPermissions),
 		channel.PermissionOverwrite{ // @everyone
 			Id:    guildId,
@@ -175,6 +245,11 @@ func overwritesCantView(claimer, selfId, openerId, guildId uint64, adminUsers, a
 		})
 	}
 
+	// Members added to the ticket keep the same access as the opener
+	for _, userId := range members {
+		overwrites = append(overwrites, BuildUserOverwrite(userId, additionalPermissions))
+	}
+
 	return
 }
 
@@ -182,7 +257,7 @@ var readOnlyAllowed = []permission.Permission{permission.ViewChannel, permission
 var readOnlyDenied = []permission.Permission{permission.SendMessages, permission.AddReactions}
 
 // support & admins are not mutually exclusive due to support teams
-func overwritesCantType(claimerId, selfId, openerId, guildId uint64, supportUsers, supportRoles, adminUsers, adminRoles []uint64, additionalPermissions database.TicketPermissions) (overwrites []channel.PermissionOverwrite) {
+func overwritesCantType(claimerId, selfId, openerId, guildId uint64, members, supportUsers, supportRoles, adminUsers, adminRoles []uint64, additionalPermissions database.TicketPermissions) (overwrites []channel.PermissionOverwrite) {
 	overwrites = append(overwrites, BuildUserOverwrite(openerId, additionalPermissions),
 		channel.PermissionOverwrite{ // @everyone
 			Id:    guildId,
@@ -210,6 +285,10 @@ func overwritesCantType(claimerId, selfId, openerId, guildId uint64, supportUser
 		})
 	}
 
+	for _, userId := range members {
+		overwrites = append(overwrites, BuildUserOverwrite(userId, additionalPermissions))
+	}
+
 	for _, userId := range supportUsers {
 		// Don't exclude claimer, self or admins
 		if userId == claimerId || userId == selfId {
@@ -0,0 +1,96 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest"
+	"strings"
+)
+
+// NotifyClaimTimeout tells the ticket's support team that the ticket was unclaimed because its claimer stopped replying
+func NotifyClaimTimeout(ctx registry.CommandContext, ticket database.Ticket, claimerId uint64, timeoutHours int) error {
+	roles, err := getSupportTeamRoles(ticket)
+	if err != nil {
+		return err
+	}
+
+	mentions := make([]string, 0, len(roles))
+	for _, roleId := range roles {
+		// Don't ping @everyone
+		if roleId != ticket.GuildId {
+			mentions = append(mentions, fmt.Sprintf("<@&%d>", roleId))
+		}
+	}
+
+	msgEmbed := utils.BuildEmbed(ctx, customisation.Orange, i18n.TitleUnclaimed, i18n.MessageClaimTimedOut, nil, claimerId, timeoutHours)
+
+	_, err = ctx.Worker().CreateMessageComplex(*ticket.ChannelId, rest.CreateMessageData{
+		Content: utils.StringMax(strings.Join(mentions, " "), 2000),
+		Embeds:  utils.Slice(msgEmbed),
+		AllowedMentions: message.AllowedMention{
+			Parse: []message.AllowedMentionType{
+				message.ROLES,
+			},
+		},
+	})
+
+	return err
+}
+
+// getSupportTeamRoles returns the roles of the teams that can see the ticket, without duplicates
+func getSupportTeamRoles(ticket database.Ticket) ([]uint64, error) {
+	var panel *database.Panel
+	if ticket.PanelId != nil {
+		tmp, err := dbclient.Client.Panel.GetById(*ticket.PanelId)
+		if err != nil {
+			return nil, err
+		}
+
+		if tmp.PanelId != 0 {
+			panel = &tmp
+		}
+	}
+
+	var roles []uint64
+	if panel == nil || panel.WithDefaultTeam {
+		supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ticket.GuildId)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, supportRoles...)
+	}
+
+	if panel != nil {
+		panelRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(panel.PanelId)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, panelRoles...)
+	}
+
+	_, ticketTeamRoles, err := getTicketTeamUsersRoles(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	roles = append(roles, ticketTeamRoles...)
+
+	seen := make(map[uint64]bool)
+	unique := make([]uint64, 0, len(roles))
+	for _, roleId := range roles {
+		if !seen[roleId] {
+			seen[roleId] = true
+			unique = append(unique, roleId)
+		}
+	}
+
+	return unique, nil
+}
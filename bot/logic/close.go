@@ -8,7 +8,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/redis"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
@@ -20,6 +20,7 @@ import (
 	"github.com/rxdn/gdl/objects/member"
 	"github.com/rxdn/gdl/rest"
 	"github.com/rxdn/gdl/rest/request"
+	"github.com/sirupsen/logrus"
 	"strconv"
 	"time"
 )
@@ -42,6 +43,8 @@ func CloseTicket(ctx registry.CommandContext, reason *string) {
 		return
 	}
 
+	ctx.Worker().AddLogFields(logrus.Fields{"ticket_id": ticket.Id})
+
 	defer func() {
 		if !success {
 			if err := dbclient.Client.AutoCloseExclude.Exclude(ticket.GuildId, ticket.Id); err != nil {
@@ -105,6 +108,10 @@ func CloseTicket(ctx registry.CommandContext, reason *string) {
 			msgs[i], msgs[j] = msgs[j], msgs[i]
 		}
 
+		if err := RestoreStaffReplyAuthors(ctx, ticket, msgs); err != nil {
+			sentry.ErrorWithContext(err, errorContext)
+		}
+
 		err := utils.ArchiverClient.Store(msgs, ctx.GuildId(), ticket.Id, ctx.PremiumTier() > premium.None)
 		if err == nil {
 			if err := dbclient.Client.Tickets.SetHasTranscript(ctx.GuildId(), ticket.Id, true); err != nil {
@@ -122,6 +129,7 @@ func CloseTicket(ctx registry.CommandContext, reason *string) {
 	}
 
 	success = true
+	RequestQueueBoardUpdate(ctx)
 
 	// set close reason
 	if reason != nil {
@@ -150,6 +158,15 @@ func CloseTicket(ctx registry.CommandContext, reason *string) {
 		sentry.ErrorWithContext(err, ctx.ToErrorContext())
 	}
 
+	// Further DMs from the user will start a new modmail conversation
+	if err := dbclient.Tables.ModmailSessions.DeleteByTicket(ticket.GuildId, ticket.Id); err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	}
+
+	if err := dbclient.Tables.ClaimActivity.Delete(ticket.GuildId, ticket.Id); err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+	}
+
 	sendCloseEmbed(ctx, errorContext, member, settings, ticket, reason)
 }
 
@@ -196,7 +213,7 @@ func sendCloseEmbed(ctx registry.CommandContext, errorContext sentry.ErrorContex
 			return
 		}
 
-		statsd.Client.IncrementKey(statsd.KeyDirectMessage)
+		metrics.Increment(metrics.DirectMessages, metrics.Tags{"type": "close"})
 
 		if !feedbackEnabled || !hasSentMessage {
 			data := rest.CreateMessageData{
@@ -260,7 +277,7 @@ func buildCloseEmbed(ctx registry.CommandContext, ticket database.Ticket, settin
 	var components []component.Component
 	if settings.StoreTranscripts {
 		title := formatTitle("Transcript", utils.EmojiTranscript, ctx.Worker().IsWhitelabel)
-		transcriptLink := fmt.Sprintf("https://panel.ticketsbot.net/manage/%d/transcripts/view/%d", ticket.GuildId, ticket.Id)
+		transcriptLink := TranscriptUrl(ticket.GuildId, ticket.Id)
 		closeEmbed.AddField(title, fmt.Sprintf("[Click here](%s)", transcriptLink), true)
 
 		var transcriptEmoji *emoji.Emoji
@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/redis"
+	"time"
+)
+
+// reserveOpenCooldowns starts the user's guild and panel cooldowns before the ticket is opened, so that several opens
+// at once can't all pass the check. If the user is already on cooldown, ok is false and remaining is how long they must
+// wait, taking the longest of the two. Otherwise, release must be called if the ticket isn't opened.
+func reserveOpenCooldowns(guildId, userId uint64, panel *database.Panel, limits tables.TicketOpenLimits) (ok bool, remaining time.Duration, release func(), err error) {
+	var releases []func() error
+
+	release = func() {
+		for _, f := range releases {
+			if err := f(); err != nil {
+				sentry.Error(err)
+			}
+		}
+	}
+
+	if limits.UserCooldown > 0 {
+		reserved, userRemaining, err := redis.ReserveUserOpenCooldown(redis.Client, guildId, userId, limits.UserCooldown)
+		if err != nil {
+			return false, 0, nil, err
+		}
+
+		if !reserved {
+			return false, userRemaining, nil, nil
+		}
+
+		releases = append(releases, func() error {
+			return redis.ReleaseUserOpenCooldown(redis.Client, guildId, userId)
+		})
+	}
+
+	if panel != nil {
+		cooldown, err := dbclient.Tables.PanelCooldowns.Get(panel.PanelId)
+		if err != nil {
+			release()
+			return false, 0, nil, err
+		}
+
+		if cooldown > 0 {
+			reserved, panelRemaining, err := redis.ReservePanelOpenCooldown(redis.Client, panel.PanelId, userId, cooldown)
+			if err != nil {
+				release()
+				return false, 0, nil, err
+			}
+
+			if !reserved {
+				release()
+				return false, panelRemaining, nil, nil
+			}
+
+			releases = append(releases, func() error {
+				return redis.ReleasePanelOpenCooldown(redis.Client, panel.PanelId, userId)
+			})
+		}
+	}
+
+	return true, 0, release, nil
+}
@@ -0,0 +1,116 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/i18n"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type FormValidationError struct {
+	MessageId i18n.MessageId
+	Format    []interface{}
+}
+
+// ValidateFormAnswer checks an answer against the validation rules configured for its input. The label of the input
+// is always the first format argument of the returned message.
+func ValidateFormAnswer(label string, rules tables.FormInputValidation, answer string) *FormValidationError {
+	answer = strings.TrimSpace(answer)
+
+	// Optional inputs that were left blank have nothing to validate
+	if answer == "" {
+		return nil
+	}
+
+	length := utf8.RuneCountInString(answer)
+	if rules.MinLength != nil && length < *rules.MinLength {
+		return newFormValidationError(i18n.MessageFormValidationTooShort, label, *rules.MinLength)
+	}
+
+	if rules.MaxLength != nil && length > *rules.MaxLength {
+		return newFormValidationError(i18n.MessageFormValidationTooLong, label, *rules.MaxLength)
+	}
+
+	switch rules.Format {
+	case tables.ValidationFormatInteger:
+		value, err := strconv.ParseInt(answer, 10, 64)
+		if err != nil {
+			return newFormValidationError(i18n.MessageFormValidationInteger, label)
+		}
+
+		if err := validateRange(label, float64(value), rules); err != nil {
+			return err
+		}
+	case tables.ValidationFormatDecimal:
+		value, err := strconv.ParseFloat(answer, 64)
+		if err != nil {
+			return newFormValidationError(i18n.MessageFormValidationDecimal, label)
+		}
+
+		if err := validateRange(label, value, rules); err != nil {
+			return err
+		}
+	case tables.ValidationFormatEmail:
+		// ParseAddress also accepts display names, e.g. "Name <user@example.com>"
+		address, err := mail.ParseAddress(answer)
+		if err != nil || address.Address != answer {
+			return newFormValidationError(i18n.MessageFormValidationEmail, label)
+		}
+	case tables.ValidationFormatUrl:
+		parsed, err := url.ParseRequestURI(answer)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return newFormValidationError(i18n.MessageFormValidationUrl, label)
+		}
+	}
+
+	if rules.Pattern != nil && *rules.Pattern != "" {
+		pattern, err := regexp.Compile(*rules.Pattern)
+		if err != nil {
+			// Misconfiguration, don't punish the user for it
+			sentry.Error(fmt.Errorf("invalid validation pattern for form input %d: %w", rules.FormInputId, err))
+		} else if !pattern.MatchString(answer) {
+			return newFormValidationError(i18n.MessageFormValidationPattern, label)
+		}
+	}
+
+	if len(rules.AllowedValues) > 0 {
+		var allowed bool
+		for _, value := range rules.AllowedValues {
+			if strings.EqualFold(value, answer) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return newFormValidationError(i18n.MessageFormValidationChoice, label, strings.Join(rules.AllowedValues, ", "))
+		}
+	}
+
+	return nil
+}
+
+func validateRange(label string, value float64, rules tables.FormInputValidation) *FormValidationError {
+	if rules.MinValue != nil && value < *rules.MinValue {
+		return newFormValidationError(i18n.MessageFormValidationTooSmall, label, strconv.FormatFloat(*rules.MinValue, 'f', -1, 64))
+	}
+
+	if rules.MaxValue != nil && value > *rules.MaxValue {
+		return newFormValidationError(i18n.MessageFormValidationTooLarge, label, strconv.FormatFloat(*rules.MaxValue, 'f', -1, 64))
+	}
+
+	return nil
+}
+
+func newFormValidationError(messageId i18n.MessageId, format ...interface{}) *FormValidationError {
+	return &FormValidationError{
+		MessageId: messageId,
+		Format:    format,
+	}
+}
@@ -0,0 +1,236 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/guild/emoji"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const leaderboardPageSize = 10
+
+type LeaderboardPeriod string
+
+const (
+	LeaderboardPeriodWeek  LeaderboardPeriod = "week"
+	LeaderboardPeriodMonth LeaderboardPeriod = "month"
+	LeaderboardPeriodAll   LeaderboardPeriod = "all"
+)
+
+var LeaderboardPeriods = []LeaderboardPeriod{LeaderboardPeriodWeek, LeaderboardPeriodMonth, LeaderboardPeriodAll}
+
+func ParseLeaderboardPeriod(raw string) (LeaderboardPeriod, bool) {
+	for _, period := range LeaderboardPeriods {
+		if string(period) == raw {
+			return period, true
+		}
+	}
+
+	return "", false
+}
+
+func ParseLeaderboardMetric(raw string) (tables.LeaderboardMetric, bool) {
+	for _, metric := range tables.LeaderboardMetrics {
+		if string(metric) == raw {
+			return metric, true
+		}
+	}
+
+	return "", false
+}
+
+func (p LeaderboardPeriod) Start(now time.Time) time.Time {
+	switch p {
+	case LeaderboardPeriodWeek:
+		return now.AddDate(0, 0, -7)
+	case LeaderboardPeriodMonth:
+		return now.AddDate(0, -1, 0)
+	default:
+		return time.Unix(0, 0)
+	}
+}
+
+type LeaderboardOptions struct {
+	Metric  tables.LeaderboardMetric
+	Period  LeaderboardPeriod
+	PanelId *int
+}
+
+var leaderboardCustomIdPattern = regexp.MustCompile(`^leaderboard_([a-z_]+)_([a-z]+)_(\d+)_(\d+)$`)
+
+// CustomId encodes the options in the custom ID of the pagination buttons, with a panel ID of 0 meaning all panels
+func (o LeaderboardOptions) CustomId(page int) string {
+	var panelId int
+	if o.PanelId != nil {
+		panelId = *o.PanelId
+	}
+
+	return fmt.Sprintf("leaderboard_%s_%s_%d_%d", o.Metric, o.Period, panelId, page)
+}
+
+func ParseLeaderboardCustomId(customId string) (options LeaderboardOptions, page int, ok bool) {
+	groups := leaderboardCustomIdPattern.FindStringSubmatch(customId)
+	if len(groups) < 5 {
+		return
+	}
+
+	if options.Metric, ok = ParseLeaderboardMetric(groups[1]); !ok {
+		return
+	}
+
+	if options.Period, ok = ParseLeaderboardPeriod(groups[2]); !ok {
+		return
+	}
+
+	panelId, err := strconv.Atoi(groups[3])
+	if err != nil {
+		return options, 0, false
+	}
+
+	if panelId != 0 {
+		options.PanelId = &panelId
+	}
+
+	page, err = strconv.Atoi(groups[4])
+	if err != nil || page < 0 {
+		return options, 0, false
+	}
+
+	return options, page, true
+}
+
+var leaderboardMetricNames = map[tables.LeaderboardMetric]string{
+	tables.LeaderboardMetricAnswered:     "Tickets Answered",
+	tables.LeaderboardMetricClaimed:      "Tickets Claimed",
+	tables.LeaderboardMetricRating:       "Average Rating",
+	tables.LeaderboardMetricResponseTime: "First Response Time",
+}
+
+var leaderboardPeriodNames = map[LeaderboardPeriod]string{
+	LeaderboardPeriodWeek:  "Past 7 Days",
+	LeaderboardPeriodMonth: "Past Month",
+	LeaderboardPeriodAll:   "All Time",
+}
+
+// BuildLeaderboardMessage returns the embed for the given page, and whether there is a page after it
+func BuildLeaderboardMessage(ctx registry.CommandContext, options LeaderboardOptions, page int) (*embed.Embed, bool, error) {
+	from := options.Period.Start(time.Now())
+
+	// Fetch an extra entry to find out whether there is another page
+	entries, err := dbclient.Tables.Leaderboard.Get(ctx.GuildId(), options.PanelId, from, options.Metric, page*leaderboardPageSize, leaderboardPageSize+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasNext := len(entries) > leaderboardPageSize
+	if hasNext {
+		entries = entries[:leaderboardPageSize]
+	}
+
+	title := "Staff Leaderboard"
+	var description string
+
+	if options.PanelId != nil {
+		panel, err := dbclient.Client.Panel.GetById(*options.PanelId)
+		if err != nil {
+			return nil, false, err
+		}
+
+		totals, err := dbclient.Tables.Leaderboard.GetPanelTotals(ctx.GuildId(), *options.PanelId, from)
+		if err != nil {
+			return nil, false, err
+		}
+
+		title = fmt.Sprintf("Panel Statistics: %s", panel.Title)
+		description = fmt.Sprintf("**Tickets Opened:** %d\n**Tickets Closed:** %d\n\n", totals.Opened, totals.Closed)
+	}
+
+	if len(entries) == 0 {
+		description += "No staff activity in this period"
+	} else {
+		lines := make([]string, len(entries))
+		for i, entry := range entries {
+			lines[i] = formatLeaderboardEntry(page*leaderboardPageSize+i+1, entry, options.Metric)
+		}
+
+		description += strings.Join(lines, "\n")
+	}
+
+	self, _ := ctx.Worker().Self()
+
+	msgEmbed := embed.NewEmbed().
+		SetColor(ctx.GetColour(customisation.Green)).
+		SetTitle(title).
+		SetDescription(description).
+		AddField("Ranked By", leaderboardMetricNames[options.Metric], true).
+		AddField("Period", leaderboardPeriodNames[options.Period], true).
+		SetFooter(fmt.Sprintf("Page %d", page+1), self.AvatarUrl(256))
+
+	return msgEmbed, hasNext, nil
+}
+
+// formatLeaderboardEntry shows every statistic for the entry, with the one it is ranked by in bold
+func formatLeaderboardEntry(rank int, entry tables.LeaderboardEntry, metric tables.LeaderboardMetric) string {
+	rating := "No ratings"
+	if entry.RatingAverage != nil {
+		rating = fmt.Sprintf("%.1f ⭐ (%d)", *entry.RatingAverage, entry.RatingCount)
+	}
+
+	var responseTime *time.Duration
+	if entry.AverageFirstResponseTimeSeconds != nil {
+		responseTime = utils.Ptr(time.Duration(*entry.AverageFirstResponseTimeSeconds * float64(time.Second)))
+	}
+
+	stats := []struct {
+		metric tables.LeaderboardMetric
+		value  string
+	}{
+		{tables.LeaderboardMetricAnswered, fmt.Sprintf("%d answered", entry.TicketsAnswered)},
+		{tables.LeaderboardMetricClaimed, fmt.Sprintf("%d claimed", entry.TicketsClaimed)},
+		{tables.LeaderboardMetricRating, rating},
+		{tables.LeaderboardMetricResponseTime, fmt.Sprintf("%s response", utils.FormatNullableTime(responseTime))},
+	}
+
+	values := make([]string, len(stats))
+	for i, stat := range stats {
+		if stat.metric == metric {
+			values[i] = fmt.Sprintf("**%s**", stat.value)
+		} else {
+			values[i] = stat.value
+		}
+	}
+
+	return fmt.Sprintf("`%d.` <@%d> • %s", rank, entry.UserId, strings.Join(values, " • "))
+}
+
+func BuildLeaderboardComponents(options LeaderboardOptions, page int, hasNext bool) []component.Component {
+	return []component.Component{
+		component.BuildActionRow(
+			component.BuildButton(component.Button{
+				CustomId: options.CustomId(page - 1),
+				Style:    component.ButtonStylePrimary,
+				Emoji: &emoji.Emoji{
+					Name: "◀️",
+				},
+				Disabled: page <= 0,
+			}),
+			component.BuildButton(component.Button{
+				CustomId: options.CustomId(page + 1),
+				Style:    component.ButtonStylePrimary,
+				Emoji: &emoji.Emoji{
+					Name: "▶️",
+				},
+				Disabled: !hasNext,
+			}),
+		),
+	}
+}
@@ -0,0 +1,227 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/guild"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CanUseModmail returns whether the user may open a modmail ticket in the guild: the guild must have modmail enabled,
+// be served by this bot, and the user must be a member. Membership is only checked against the cache, as checking
+// every enabled guild over REST each time a user sends a DM would be far too slow.
+func CanUseModmail(worker *worker.Context, guildId, userId uint64) (bool, error) {
+	settings, err := dbclient.Tables.ModmailSettings.Get(guildId)
+	if err != nil {
+		return false, err
+	}
+
+	if !settings.Enabled {
+		return false, nil
+	}
+
+	return isModmailCandidate(worker, guildId, userId)
+}
+
+// ModmailGuilds returns up to 25 guilds, the limit of a select menu, that the user may open a modmail ticket in
+func ModmailGuilds(worker *worker.Context, userId uint64) ([]guild.Guild, error) {
+	guildIds, err := dbclient.Tables.ModmailSettings.GetEnabledGuilds()
+	if err != nil {
+		return nil, err
+	}
+
+	var guilds []guild.Guild
+	for _, guildId := range guildIds {
+		ok, err := isModmailCandidate(worker, guildId, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if cachedGuild, found := worker.Cache.GetGuild(guildId, false); found {
+			guilds = append(guilds, cachedGuild)
+		}
+
+		if len(guilds) >= 25 {
+			break
+		}
+	}
+
+	return guilds, nil
+}
+
+func isModmailCandidate(worker *worker.Context, guildId, userId uint64) (bool, error) {
+	if _, found := worker.Cache.GetMember(guildId, userId); !found {
+		return false, nil
+	}
+
+	// Whitelabel bots share the cache with the public bot, so make sure we are the bot serving this guild
+	botId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(guildId)
+	if err != nil {
+		return false, err
+	}
+
+	if isWhitelabel {
+		return botId == worker.BotId, nil
+	} else {
+		return !worker.IsWhitelabel, nil
+	}
+}
+
+// BuildModmailGuildSelect builds the server select menu. The ID of the user's original DM is carried through the
+// custom IDs, so that it can be relayed into the ticket once it has been opened.
+func BuildModmailGuildSelect(guilds []guild.Guild, messageId uint64) component.Component {
+	options := make([]component.SelectOption, len(guilds))
+	for i, g := range guilds {
+		options[i] = component.SelectOption{
+			Label: utils.StringMax(g.Name, 100),
+			Value: strconv.FormatUint(g.Id, 10),
+		}
+	}
+
+	return component.BuildActionRow(component.BuildSelectMenu(component.SelectMenu{
+		CustomId:    fmt.Sprintf("modmail_guild_%d", messageId),
+		Options:     options,
+		Placeholder: "Select a server",
+	}))
+}
+
+func BuildModmailPanelSelect(guildId uint64, panels []database.Panel, messageId uint64) component.Component {
+	if len(panels) > 25 {
+		panels = panels[:25]
+	}
+
+	options := make([]component.SelectOption, len(panels))
+	for i, panel := range panels {
+		options[i] = component.SelectOption{
+			Label: utils.StringMax(panel.Title, 100),
+			Value: strconv.Itoa(panel.PanelId),
+		}
+	}
+
+	return component.BuildActionRow(component.BuildSelectMenu(component.SelectMenu{
+		CustomId:    fmt.Sprintf("modmail_panel_%d_%d", guildId, messageId),
+		Options:     options,
+		Placeholder: "Select a category",
+	}))
+}
+
+// RelayModmailUserMessage posts a message that the user sent to the bot in DMs into their ticket's channel
+func RelayModmailUserMessage(ctx registry.CommandContext, ticket database.Ticket, msg message.Message) error {
+	if ticket.ChannelId == nil {
+		return nil
+	}
+
+	relayEmbed := buildModmailEmbed(ctx, msg, customisation.Blue).
+		SetAuthor(msg.Author.Username, "", msg.Author.AvatarUrl(256))
+
+	relayed, err := ctx.Worker().CreateMessageEmbed(*ticket.ChannelId, relayEmbed)
+	if err != nil {
+		return err
+	}
+
+	// The user never sends a message in the channel themselves, so record their activity for autoclose and feedback
+	if err := dbclient.Client.Participants.Set(ticket.GuildId, ticket.Id, msg.Author.Id); err != nil {
+		return err
+	}
+
+	return dbclient.Client.TicketLastMessage.Set(ticket.GuildId, ticket.Id, relayed.Id, msg.Author.Id, false)
+}
+
+// ModmailReplyPrefix marks a message in a modmail ticket's channel as a reply to the user. Other messages in the channel
+// are not relayed, so that staff can discuss the ticket without the user seeing.
+const ModmailReplyPrefix = "!reply"
+
+// ParseModmailReply returns the content of a message in a modmail ticket's channel with the reply prefix removed, or
+// false if the message is not a reply to the user
+func ParseModmailReply(content string) (string, bool) {
+	if !strings.HasPrefix(content, ModmailReplyPrefix) {
+		return "", false
+	}
+
+	remainder := strings.TrimPrefix(content, ModmailReplyPrefix)
+	if remainder != "" && !unicode.IsSpace([]rune(remainder)[0]) {
+		return "", false
+	}
+
+	return strings.TrimSpace(remainder), true
+}
+
+// RelayModmailStaffMessage sends a staff member's message in a modmail ticket's channel to the user's DMs. If the
+// guild has chosen to anonymise staff, the message is sent under the server's name instead.
+func RelayModmailStaffMessage(ctx registry.CommandContext, session tables.ModmailSession, msg message.Message, anonymise bool) error {
+	relayEmbed := buildModmailEmbed(ctx, msg, customisation.Green)
+
+	if anonymise {
+		g, err := ctx.Guild()
+		if err != nil {
+			return err
+		}
+
+		relayEmbed.SetAuthor(fmt.Sprintf("%s Staff", g.Name), "", "")
+	} else {
+		relayEmbed.SetAuthor(fmt.Sprintf("%s (Staff)", msg.Author.Username), "", msg.Author.AvatarUrl(256))
+	}
+
+	dmChannel, ok := getDmChannel(ctx, session.UserId)
+	if ok {
+		if _, err := ctx.Worker().CreateMessageEmbed(dmChannel, relayEmbed); err == nil {
+			return nil
+		}
+	}
+
+	// Let staff know that the user won't see their message
+	failedEmbed := utils.BuildEmbed(ctx, customisation.Red, i18n.Error, i18n.MessageModmailUndeliverable, nil)
+	_, err := ctx.Worker().CreateMessageEmbed(ctx.ChannelId(), failedEmbed)
+	return err
+}
+
+func buildModmailEmbed(ctx registry.CommandContext, msg message.Message, colour customisation.Colour) *embed.Embed {
+	relayEmbed := embed.NewEmbed().
+		SetDescription(msg.Content).
+		SetColor(ctx.GetColour(colour)).
+		SetTimestamp(time.Now())
+
+	// Attachments are linked rather than reuploaded
+	if len(msg.Attachments) > 0 {
+		links := make([]string, len(msg.Attachments))
+		for i, attachment := range msg.Attachments {
+			links[i] = fmt.Sprintf("[%s](%s)", attachment.Filename, attachment.Url)
+		}
+
+		relayEmbed.AddField("Attachments", utils.StringMax(strings.Join(links, "\n"), 1024), false)
+	}
+
+	if len(msg.Attachments) > 0 && isImage(msg.Attachments[0].Filename) {
+		relayEmbed.SetImage(msg.Attachments[0].Url)
+	}
+
+	return relayEmbed
+}
+
+func isImage(fileName string) bool {
+	fileName = strings.ToLower(fileName)
+	for _, extension := range []string{".png", ".jpg", ".jpeg", ".gif", ".webp"} {
+		if strings.HasSuffix(fileName, extension) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MoveMessagesCounts are the numbers of messages that staff may choose to move into a ticket opened from a message
+var MoveMessagesCounts = []int{5, 10, 25, 50}
+
+// MaxMoveMessagesRange is the most messages that can be moved by choosing a range, as they are reposted one at a time
+const MaxMoveMessagesRange = 100
+
+var ErrTooManyMessages = errors.New("too many messages in range")
+
+// BuildMoveMessagesSelect builds the select menu offering to copy, or move, the conversation leading up to a message
+// into a ticket. Values take the form "copy_<count>" or "move_<count>", where moving also deletes the originals, or
+// "copy_range" or "move_range", which ask for the first message of the conversation.
+func BuildMoveMessagesSelect(ticketId int, messageId uint64) component.Component {
+	var options []component.SelectOption
+	for _, count := range MoveMessagesCounts {
+		options = append(options, component.SelectOption{
+			Label: fmt.Sprintf("Copy the last %d messages", count),
+			Value: fmt.Sprintf("copy_%d", count),
+		})
+	}
+
+	options = append(options, component.SelectOption{
+		Label:       "Copy from a chosen message",
+		Description: "Copy every message from a message of your choice up to this one",
+		Value:       "copy_range",
+	})
+
+	for _, count := range MoveMessagesCounts {
+		options = append(options, component.SelectOption{
+			Label:       fmt.Sprintf("Move the last %d messages", count),
+			Description: "The original messages will be deleted",
+			Value:       fmt.Sprintf("move_%d", count),
+		})
+	}
+
+	options = append(options, component.SelectOption{
+		Label:       "Move from a chosen message",
+		Description: "The original messages will be deleted",
+		Value:       "move_range",
+	})
+
+	return component.BuildActionRow(component.BuildSelectMenu(component.SelectMenu{
+		CustomId:    fmt.Sprintf("move_messages_%d_%d", ticketId, messageId),
+		Options:     options,
+		Placeholder: "Move the conversation into the ticket",
+	}))
+}
+
+// ParseMessageReference accepts either a message ID, or a link to a message
+func ParseMessageReference(raw string) (uint64, bool) {
+	raw = strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	if index := strings.LastIndex(raw, "/"); index != -1 {
+		raw = raw[index+1:]
+	}
+
+	messageId, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || messageId == 0 {
+		return 0, false
+	}
+
+	return messageId, true
+}
+
+// FetchMessagesBetween returns the messages from firstId up to and including lastId, oldest first. If there are more
+// than MaxMoveMessagesRange, ErrTooManyMessages is returned.
+func FetchMessagesBetween(worker *worker.Context, channelId, firstId, lastId uint64) ([]message.Message, error) {
+	first, err := worker.GetChannelMessage(channelId, firstId)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := []message.Message{first}
+	for after := firstId; after < lastId; {
+		page, err := worker.GetChannelMessages(channelId, rest.GetChannelMessagesData{
+			After: after,
+			Limit: 100,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		// Messages are returned newest first
+		for i := len(page) - 1; i >= 0; i-- {
+			if page[i].Id > lastId {
+				break
+			}
+
+			msgs = append(msgs, page[i])
+		}
+
+		if len(msgs) > MaxMoveMessagesRange {
+			return nil, ErrTooManyMessages
+		}
+
+		after = page[0].Id
+	}
+
+	return msgs, nil
+}
+
+// FetchMessagesUpTo returns up to count messages, ending with and including messageId, oldest first
+func FetchMessagesUpTo(worker *worker.Context, channelId, messageId uint64, count int) ([]message.Message, error) {
+	last, err := worker.GetChannelMessage(channelId, messageId)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []message.Message
+	if count > 1 {
+		msgs, err = worker.GetChannelMessages(channelId, rest.GetChannelMessagesData{
+			Before: messageId,
+			Limit:  count - 1,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Messages are returned newest first
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+
+	return append(msgs, last), nil
+}
+
+// CopyMessagesToTicket reposts the messages in the ticket's channel through the ticket's webhook, under each author's
+// name and avatar. Returns the messages that were copied, as messages without any content are skipped.
+func CopyMessagesToTicket(ctx registry.CommandContext, ticket database.Ticket, msgs []message.Message) ([]message.Message, error) {
+	var copied []message.Message
+	for _, msg := range msgs {
+		if msg.Content == "" && len(msg.Embeds) == 0 && len(msg.Attachments) == 0 {
+			continue
+		}
+
+		data := rest.WebhookBody{
+			Content:         msg.Content,
+			Username:        msg.Author.Username,
+			AvatarUrl:       msg.Author.AvatarUrl(256),
+			Embeds:          utils.PtrElems(msg.Embeds),
+			AllowedMentions: message.AllowedMention{},
+		}
+
+		if len(msg.Attachments) > 0 {
+			timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+			files, err := FetchMessageAttachments(timeoutCtx, msg)
+			cancel()
+
+			// Fall back to linking the attachments if they can't be uploaded again
+			if err == nil {
+				data.Attachments = files
+			} else {
+				links := make([]string, len(msg.Attachments))
+				for i, attachment := range msg.Attachments {
+					links[i] = attachment.Url
+				}
+
+				data.Content = utils.StringMax(strings.TrimSpace(data.Content+"\n"+strings.Join(links, "\n")), 2000)
+			}
+		}
+
+		if _, err := executeTicketWebhook(ctx, ticket, data); err != nil {
+			return copied, err
+		}
+
+		copied = append(copied, msg)
+	}
+
+	return copied, nil
+}
+
+// DeleteMessages deletes the messages, using a bulk delete where Discord allows it
+func DeleteMessages(worker *worker.Context, channelId uint64, msgs []message.Message) error {
+	ids := make([]uint64, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.Id
+	}
+
+	// Bulk deletes require at least 2 messages, none of which are older than 2 weeks
+	if len(ids) >= 2 {
+		if err := worker.BulkDeleteMessages(channelId, ids); err == nil {
+			return nil
+		}
+	}
+
+	for _, id := range ids {
+		if err := worker.DeleteMessage(channelId, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
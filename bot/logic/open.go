@@ -13,10 +13,10 @@ import (
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/errorcontext"
-	"github.com/TicketsBot/worker/bot/metrics/prometheus"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/permissionwrapper"
 	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/tracing"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel"
 	"github.com/rxdn/gdl/objects/channel/message"
@@ -26,6 +26,8 @@ import (
 	"github.com/rxdn/gdl/permission"
 	"github.com/rxdn/gdl/rest"
 	"github.com/rxdn/gdl/rest/request"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 	"strconv"
 	"strings"
@@ -33,6 +35,40 @@ import (
 )
 
 func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject string, formData map[database.FormInput]string) (database.Ticket, error) {
+	_, span := tracing.Start(ctx.Worker().TraceContext(), "OpenTicket", tracing.GuildId(ctx.GuildId()), tracing.UserId(ctx.UserId()))
+	defer span.End()
+
+	if panel != nil {
+		span.SetAttributes(attribute.Int("panel_id", panel.PanelId))
+	}
+
+	ticket, err := openTicket(ctx, panel, subject, formData, false)
+	if err != nil {
+		tracing.RecordError(span, err)
+	} else if ticket.Id != 0 {
+		span.SetAttributes(tracing.TicketId(ticket.Id))
+	}
+
+	return ticket, err
+}
+
+// OpenModmailTicket opens a ticket on behalf of a user who is messaging the bot in DMs. The ticket channel is only
+// visible to staff, as the user's messages are relayed into it by the bot.
+func OpenModmailTicket(ctx registry.CommandContext, panel *database.Panel, subject string) (database.Ticket, error) {
+	_, span := tracing.Start(ctx.Worker().TraceContext(), "OpenModmailTicket", tracing.GuildId(ctx.GuildId()), tracing.UserId(ctx.UserId()))
+	defer span.End()
+
+	ticket, err := openTicket(ctx, panel, subject, nil, true)
+	if err != nil {
+		tracing.RecordError(span, err)
+	} else if ticket.Id != 0 {
+		span.SetAttributes(tracing.TicketId(ticket.Id))
+	}
+
+	return ticket, err
+}
+
+func openTicket(ctx registry.CommandContext, panel *database.Panel, subject string, formData map[database.FormInput]string, staffOnly bool) (database.Ticket, error) {
 	// Make sure ticket count is within ticket limit
 	// Check ticket limit before ratelimit token to prevent 1 person from stopping everyone opening tickets
 	violatesTicketLimit, limit := getTicketLimit(ctx)
@@ -48,7 +84,56 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 		return database.Ticket{}, fmt.Errorf("ticket limit reached")
 	}
 
-	ok, err := redis.TakeTicketRateLimitToken(redis.Client, ctx.GuildId())
+	limits, err := dbclient.Tables.TicketOpenLimits.Get(ctx.GuildId())
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, err
+	}
+
+	// Set once the ticket's channel has been created, after which the cooldowns are kept
+	opened := false
+
+	// Staff are exempt from cooldowns
+	permissionLevel, err := ctx.UserPermissionLevel()
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, err
+	}
+
+	isStaff := permissionLevel >= permcache.Support
+	if !isStaff {
+		ok, remaining, releaseCooldowns, err := reserveOpenCooldowns(ctx.GuildId(), ctx.UserId(), panel, limits)
+		if err != nil {
+			ctx.HandleError(err)
+			return database.Ticket{}, err
+		}
+
+		if !ok {
+			openAt := message.BuildTimestamp(time.Now().Add(remaining), message.TimestampStyleShortDateTime)
+			ctx.Reply(customisation.Red, i18n.Error, i18n.MessageOpenCooldown, openAt)
+			return database.Ticket{}, fmt.Errorf("user is on cooldown")
+		}
+
+		// Let the user try again straight away if the ticket isn't opened
+		defer func() {
+			if !opened {
+				releaseCooldowns()
+			}
+		}()
+	}
+
+	// Panels may only accept tickets during business hours
+	outOfHours, allowed, err := CheckBusinessHours(ctx, panel)
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, err
+	}
+
+	if !allowed {
+		return database.Ticket{}, fmt.Errorf("panel is outside of business hours")
+	}
+
+	ok, err := redis.TakeTicketRateLimitToken(redis.Client, ctx.GuildId(), limits.GuildLimit, limits.GuildInterval)
 	if err != nil {
 		ctx.HandleError(err)
 		return database.Ticket{}, err
@@ -59,9 +144,24 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 		return database.Ticket{}, nil
 	}
 
+	// Routing rules may send the ticket to a different category, team or naming scheme than the panel's own
+	route, err := ResolveRoute(panel, formData)
+	if err != nil {
+		ctx.HandleError(err)
+		return database.Ticket{}, err
+	}
+
+	if panel != nil && route.NamingScheme != nil {
+		routedPanel := *panel
+		routedPanel.NamingScheme = route.NamingScheme
+		panel = &routedPanel
+	}
+
 	// If we're using a panel, then we need to create the ticket in the specified category
 	var category uint64
-	if panel != nil && panel.TargetCategory != 0 {
+	if route.TargetCategory != nil {
+		category = *route.TargetCategory
+	} else if panel != nil && panel.TargetCategory != 0 {
 		category = panel.TargetCategory
 	} else { // else we can just use the default category
 		var err error
@@ -165,6 +265,27 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 		return database.Ticket{}, err
 	}
 
+	ctx.Worker().AddLogFields(logrus.Fields{"ticket_id": ticketId})
+
+	if route.SupportTeamId != nil {
+		if err := dbclient.Tables.TicketSupportTeams.Add(ctx.GuildId(), ticketId, *route.SupportTeamId); err != nil {
+			ctx.HandleError(err)
+			return database.Ticket{}, err
+		}
+	}
+
+	if route.Priority != nil {
+		if err := dbclient.Tables.TicketPriority.Set(ctx.GuildId(), ticketId, *route.Priority); err != nil {
+			ctx.HandleError(err)
+			return database.Ticket{}, err
+		}
+	}
+
+	// Only used for searching, so don't prevent the ticket from being opened
+	if err := recordSearchableDetails(ctx.GuildId(), ticketId, subject, formData); err != nil {
+		ctx.HandleWarning(err)
+	}
+
 	name, err := GenerateChannelName(ctx, panel, ticketId, ctx.UserId(), nil)
 	if err != nil {
 		ctx.HandleError(err)
@@ -178,19 +299,29 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 	}
 
 	var ch channel.Channel
-	if settings.UseThreads && guild.PremiumTier >= model.PremiumTier2 {
+	// Private threads are created in the channel the ticket was opened from, which is a DM for staff only tickets
+	if settings.UseThreads && guild.PremiumTier >= model.PremiumTier2 && !staffOnly {
 		ch, err = ctx.Worker().CreatePrivateThread(ctx.ChannelId(), name, uint16(settings.ThreadArchiveDuration), true)
 		if err != nil {
 			ctx.HandleError(err)
 			return database.Ticket{}, err
 		}
 
-		allowedUsers, allowedRoles, err := getAllowedUsersRoles(ctx.GuildId(), ctx.Worker().BotId, panel)
+		allowedUsers, allowedRoles, err := getAllowedUsersRoles(ctx.GuildId(), ctx.Worker().BotId, panel, route.SupportTeamId == nil)
+		if err != nil {
+			ctx.HandleError(err)
+			return database.Ticket{}, err
+		}
+
+		teamUsers, teamRoles, err := getTicketTeamUsersRoles(ctx.GuildId(), ticketId)
 		if err != nil {
 			ctx.HandleError(err)
 			return database.Ticket{}, err
 		}
 
+		allowedUsers = append(allowedUsers, teamUsers...)
+		allowedRoles = append(allowedRoles, teamRoles...)
+
 		var content string
 		for _, roleId := range allowedRoles {
 			content += fmt.Sprintf(" <@&%d>", roleId)
@@ -227,12 +358,25 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 			ctx.HandleError(err)
 		}*/
 	} else {
-		overwrites, err := CreateOverwrites(ctx.Worker(), ctx.GuildId(), ctx.UserId(), ctx.Worker().BotId, panel)
+		// A team chosen by a routing rule replaces the panel's teams
+		overwrites, err := createOverwrites(ctx.Worker(), ctx.GuildId(), ctx.UserId(), ctx.Worker().BotId, panel, route.SupportTeamId == nil)
+		if err != nil {
+			ctx.HandleError(err)
+			return database.Ticket{}, err
+		}
+
+		teamOverwrites, err := TicketTeamOverwrites(ctx.GuildId(), ticketId)
 		if err != nil {
 			ctx.HandleError(err)
 			return database.Ticket{}, err
 		}
 
+		overwrites = append(overwrites, teamOverwrites...)
+
+		if staffOnly {
+			overwrites = removeOverwrite(overwrites, ctx.UserId())
+		}
+
 		data := rest.CreateChannelData{
 			Name:                 name,
 			Type:                 channel.ChannelTypeGuildText,
@@ -258,6 +402,7 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 		return database.Ticket{}, err
 	}
 
+	opened = true
 	ctx.Accept()
 
 	var panelId *int
@@ -276,7 +421,7 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 		PanelId:          panelId,
 	}
 
-	welcomeMessageId, err := SendWelcomeMessage(ctx, ticket, subject, panel, formData)
+	welcomeMessageId, err := SendWelcomeMessage(ctx, ticket, subject, panel, formData, outOfHours)
 	if err != nil {
 		ctx.HandleError(err)
 	}
@@ -343,14 +488,20 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 
 	// Let the user know the ticket has been opened
 	// Ephemeral reply is ok
-	ctx.Reply(customisation.Green, i18n.Ticket, i18n.MessageTicketOpened, ch.Mention())
+	if !staffOnly {
+		ctx.Reply(customisation.Green, i18n.Ticket, i18n.MessageTicketOpened, ch.Mention())
+	}
 
-	prometheus.LogTicketCreated(ctx.GuildId())
-	statsd.Client.IncrementKey(statsd.KeyTickets)
+	source := "panel"
 	if panel == nil {
-		statsd.Client.IncrementKey(statsd.KeyOpenCommand)
+		source = "command"
 	}
 
+	metrics.Increment(metrics.TicketsCreated, metrics.Tags{
+		"source":   source,
+		"guild_id": metrics.GuildTag(ctx.GuildId()),
+	})
+
 	if ctx.PremiumTier() > premium.None {
 		go createWebhook(ctx.Worker(), ticketId, ctx.GuildId(), ch.Id)
 	}
@@ -369,6 +520,8 @@ func OpenTicket(ctx registry.CommandContext, panel *database.Panel, subject stri
 		}
 	}()
 
+	RequestQueueBoardUpdate(ctx)
+
 	return ticket, nil
 }
 
@@ -443,6 +596,12 @@ func createWebhook(worker *worker.Context, ticketId int, guildId, channelId uint
 }
 
 func CreateOverwrites(worker *worker.Context, guildId, userId, selfId uint64, panel *database.Panel, otherUsers ...uint64) ([]channel.PermissionOverwrite, error) {
+	return createOverwrites(worker, guildId, userId, selfId, panel, true, otherUsers...)
+}
+
+// createOverwrites leaves out the default team and the panel's teams if withPanelTeams is false, for tickets that
+// have been routed to a different team
+func createOverwrites(worker *worker.Context, guildId, userId, selfId uint64, panel *database.Panel, withPanelTeams bool, otherUsers ...uint64) ([]channel.PermissionOverwrite, error) {
 	overwrites := []channel.PermissionOverwrite{ // @everyone
 		{
 			Id:    guildId,
@@ -464,7 +623,7 @@ func CreateOverwrites(worker *worker.Context, guildId, userId, selfId uint64, pa
 	}
 
 	// Create list of members & roles who should be added to the ticket
-	allowedUsers, allowedRoles, err := getAllowedUsersRoles(guildId, selfId, panel)
+	allowedUsers, allowedRoles, err := getAllowedUsersRoles(guildId, selfId, panel, withPanelTeams)
 	if err != nil {
 		return nil, err
 	}
@@ -500,7 +659,18 @@ func CreateOverwrites(worker *worker.Context, guildId, userId, selfId uint64, pa
 	return overwrites, nil
 }
 
-func getAllowedUsersRoles(guildId, selfId uint64, panel *database.Panel) ([]uint64, []uint64, error) {
+func removeOverwrite(overwrites []channel.PermissionOverwrite, id uint64) []channel.PermissionOverwrite {
+	filtered := make([]channel.PermissionOverwrite, 0, len(overwrites))
+	for _, overwrite := range overwrites {
+		if overwrite.Id != id {
+			filtered = append(filtered, overwrite)
+		}
+	}
+
+	return filtered
+}
+
+func getAllowedUsersRoles(guildId, selfId uint64, panel *database.Panel, withPanelTeams bool) ([]uint64, []uint64, error) {
 	errorContext := errorcontext.WorkerErrorContext{
 		Guild: guildId,
 	}
@@ -510,6 +680,10 @@ func getAllowedUsersRoles(guildId, selfId uint64, panel *database.Panel) ([]uint
 	allowedUsers := []uint64{selfId}
 	allowedRoles := make([]uint64, 0)
 
+	if !withPanelTeams {
+		return allowedUsers, allowedRoles, nil
+	}
+
 	// Should we add the default team
 	if panel == nil || panel.WithDefaultTeam {
 		// Get support reps & admins
@@ -0,0 +1,201 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/guild/emoji"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const openTicketsPageSize = 10
+
+var TicketPriorityNames = map[tables.TicketPriority]string{
+	tables.PriorityLow:    "Low",
+	tables.PriorityNormal: "Normal",
+	tables.PriorityHigh:   "High",
+	tables.PriorityUrgent: "Urgent",
+}
+
+// OpenTicketsOptions are the filters of an open ticket list. Lists of the user's own tickets only ever show the user
+// who is viewing them, and have no quick actions.
+type OpenTicketsOptions struct {
+	UserId        *uint64
+	PanelId       *int
+	UnclaimedOnly bool
+	Mine          bool
+}
+
+var openTicketsCustomIdPattern = regexp.MustCompile(`^open_tickets_page_(\d+)_(\d+)_([01])_([01])_(\d+)$`)
+
+// CustomId encodes the options in the custom ID of the pagination buttons, with IDs of 0 meaning no filter
+func (o OpenTicketsOptions) CustomId(page int) string {
+	var userId uint64
+	if o.UserId != nil && !o.Mine {
+		userId = *o.UserId
+	}
+
+	var panelId int
+	if o.PanelId != nil {
+		panelId = *o.PanelId
+	}
+
+	return fmt.Sprintf("open_tickets_page_%d_%d_%d_%d_%d", userId, panelId, boolToInt(o.UnclaimedOnly), boolToInt(o.Mine), page)
+}
+
+func ParseOpenTicketsCustomId(customId string) (options OpenTicketsOptions, page int, ok bool) {
+	groups := openTicketsCustomIdPattern.FindStringSubmatch(customId)
+	if len(groups) < 6 {
+		return
+	}
+
+	// Errors are impossible
+	if userId, _ := strconv.ParseUint(groups[1], 10, 64); userId != 0 {
+		options.UserId = &userId
+	}
+
+	if panelId, _ := strconv.Atoi(groups[2]); panelId != 0 {
+		options.PanelId = &panelId
+	}
+
+	options.UnclaimedOnly = groups[3] == "1"
+	options.Mine = groups[4] == "1"
+	page, _ = strconv.Atoi(groups[5])
+
+	return options, page, true
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// BuildOpenTicketsMessage returns the embed and components for the given page of open tickets. For the user's own
+// tickets, options.UserId must already be set to the user viewing the list.
+func BuildOpenTicketsMessage(ctx registry.CommandContext, options OpenTicketsOptions, page int) (*embed.Embed, []component.Component, error) {
+	// Fetch an extra entry to find out whether there is another page
+	entries, err := dbclient.Tables.OpenTicketList.Get(ctx.GuildId(), options.UserId, options.PanelId, options.UnclaimedOnly, page*openTicketsPageSize, openTicketsPageSize+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hasNext := len(entries) > openTicketsPageSize
+	if hasNext {
+		entries = entries[:openTicketsPageSize]
+	}
+
+	title := "Open Tickets"
+	if options.Mine {
+		title = "My Tickets"
+	}
+
+	var description string
+	if len(entries) == 0 {
+		description = "There are no open tickets"
+	} else {
+		lines := make([]string, len(entries))
+		for i, entry := range entries {
+			lines[i] = formatOpenTicketEntry(entry, options.Mine)
+		}
+
+		description = strings.Join(lines, "\n\n")
+	}
+
+	self, _ := ctx.Worker().Self()
+
+	msgEmbed := embed.NewEmbed().
+		SetColor(ctx.GetColour(customisation.Green)).
+		SetTitle(title).
+		SetDescription(utils.StringMax(description, 4096)).
+		SetFooter(fmt.Sprintf("Page %d", page+1), self.AvatarUrl(256))
+
+	var components []component.Component
+	if !options.Mine && len(entries) > 0 {
+		components = append(components, buildOpenTicketsSelect(entries))
+	}
+
+	components = append(components, component.BuildActionRow(
+		component.BuildButton(component.Button{
+			CustomId: options.CustomId(page - 1),
+			Style:    component.ButtonStylePrimary,
+			Emoji: &emoji.Emoji{
+				Name: "◀️",
+			},
+			Disabled: page <= 0,
+		}),
+		component.BuildButton(component.Button{
+			CustomId: options.CustomId(page + 1),
+			Style:    component.ButtonStylePrimary,
+			Emoji: &emoji.Emoji{
+				Name: "▶️",
+			},
+			Disabled: !hasNext,
+		}),
+	))
+
+	return msgEmbed, components, nil
+}
+
+func formatOpenTicketEntry(entry tables.OpenTicketListEntry, mine bool) string {
+	parts := []string{fmt.Sprintf("`#%d`", entry.Id), fmt.Sprintf("<#%d>", *entry.ChannelId)}
+
+	if !mine {
+		parts = append(parts, fmt.Sprintf("<@%d>", entry.OpenerId))
+	}
+
+	if entry.PanelTitle != nil {
+		parts = append(parts, utils.StringMax(*entry.PanelTitle, 40, "..."))
+	}
+
+	if entry.Priority != tables.PriorityNormal {
+		parts = append(parts, fmt.Sprintf("**%s**", TicketPriorityNames[entry.Priority]))
+	}
+
+	line := strings.Join(parts, " • ")
+
+	details := []string{fmt.Sprintf("Opened %s", message.BuildTimestamp(entry.OpenTime, message.TimestampStyleRelativeTime))}
+
+	if entry.LastMessageTime != nil {
+		details = append(details, fmt.Sprintf("last active %s", message.BuildTimestamp(*entry.LastMessageTime, message.TimestampStyleRelativeTime)))
+	}
+
+	if entry.ClaimerId != nil {
+		details = append(details, fmt.Sprintf("claimed by <@%d>", *entry.ClaimerId))
+	} else if !mine {
+		details = append(details, "unclaimed")
+	}
+
+	return line + "\n> " + strings.Join(details, ", ")
+}
+
+func buildOpenTicketsSelect(entries []tables.OpenTicketListEntry) component.Component {
+	options := make([]component.SelectOption, len(entries))
+	for i, entry := range entries {
+		label := fmt.Sprintf("#%d", entry.Id)
+		if entry.PanelTitle != nil {
+			label = fmt.Sprintf("#%d • %s", entry.Id, *entry.PanelTitle)
+		}
+
+		options[i] = component.SelectOption{
+			Label: utils.StringMax(label, 100),
+			Value: strconv.Itoa(entry.Id),
+		}
+	}
+
+	return component.BuildActionRow(component.BuildSelectMenu(component.SelectMenu{
+		CustomId:    "open_tickets_select",
+		Options:     options,
+		Placeholder: "Select a ticket to jump to, claim or close",
+	}))
+}
@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"errors"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/rxdn/gdl/objects/channel"
+)
+
+// OverwriteState is the part of a ticket's state that decides its permissions, other than its members and support
+// teams, which are read from the database
+type OverwriteState struct {
+	Panel   *database.Panel
+	Claimer uint64
+	// StaffOnly is set for modmail tickets, which the opener is never given access to
+	StaffOnly bool
+}
+
+// ReconcileTicketOverwrites updates the ticket channel's overwrites to match the ticket's state, changing only the
+// overwrites that differ. Overwrites the bot does not manage, such as roles given access by hand, are left alone.
+// previous is the state the overwrites were last built from, so that users and roles that no longer apply, such as
+// a previous claimer or the support teams of a previous panel, are removed.
+func ReconcileTicketOverwrites(worker *worker.Context, ticket database.Ticket, previous, current OverwriteState) error {
+	if ticket.ChannelId == nil {
+		return errors.New("channel ID is nil")
+	}
+
+	members, err := dbclient.Client.TicketMembers.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	desired, err := ticketOverwrites(worker, ticket, current, members)
+	if err != nil {
+		return err
+	}
+
+	managed, err := managedOverwriteIds(worker, ticket, members, previous, current)
+	if err != nil {
+		return err
+	}
+
+	ch, err := worker.GetChannel(*ticket.ChannelId)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[uint64]channel.PermissionOverwrite)
+	for _, overwrite := range ch.PermissionOverwrites {
+		existing[overwrite.Id] = overwrite
+	}
+
+	wanted := make(map[uint64]bool)
+	for _, overwrite := range desired {
+		wanted[overwrite.Id] = true
+
+		if existingOverwrite, ok := existing[overwrite.Id]; ok && overwritesEqual(existingOverwrite, overwrite) {
+			continue
+		}
+
+		if err := worker.EditChannelPermissions(*ticket.ChannelId, overwrite); err != nil {
+			return err
+		}
+	}
+
+	for _, overwrite := range ch.PermissionOverwrites {
+		if managed[overwrite.Id] && !wanted[overwrite.Id] {
+			if err := worker.DeleteChannelPermissions(*ticket.ChannelId, overwrite.Id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ticketOverwrites builds the overwrites that the ticket should have in the given state
+func ticketOverwrites(worker *worker.Context, ticket database.Ticket, state OverwriteState, members []uint64) ([]channel.PermissionOverwrite, error) {
+	// GenerateClaimedOverwrites reads the panel's teams from the ticket
+	if state.Panel != nil {
+		ticket.PanelId = &state.Panel.PanelId
+	} else {
+		ticket.PanelId = nil
+	}
+
+	if state.Claimer != 0 {
+		overwrites, err := GenerateClaimedOverwrites(worker, ticket, state.Claimer, members...)
+		if err != nil {
+			return nil, err
+		}
+
+		// nil means that the ticket has the same permissions as when it is unclaimed
+		if overwrites != nil {
+			return withoutOpener(ticket, state, dedupeOverwrites(overwrites)), nil
+		}
+	}
+
+	routed, err := isRoutedToTeam(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	overwrites, err := createOverwrites(worker, ticket.GuildId, ticket.UserId, worker.BotId, state.Panel, !routed, members...)
+	if err != nil {
+		return nil, err
+	}
+
+	teamOverwrites, err := TicketTeamOverwrites(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutOpener(ticket, state, dedupeOverwrites(append(overwrites, teamOverwrites...))), nil
+}
+
+// withoutOpener removes the opener's overwrite from staff only tickets
+func withoutOpener(ticket database.Ticket, state OverwriteState, overwrites []channel.PermissionOverwrite) []channel.PermissionOverwrite {
+	if !state.StaffOnly {
+		return overwrites
+	}
+
+	return removeOverwrite(overwrites, ticket.UserId)
+}
+
+// managedOverwriteIds returns the IDs of the users and roles that the bot may have created overwrites for, in either
+// state. Overwrites for anyone else were added by hand, and should be preserved.
+func managedOverwriteIds(worker *worker.Context, ticket database.Ticket, members []uint64, previous, current OverwriteState) (map[uint64]bool, error) {
+	managed := map[uint64]bool{
+		ticket.GuildId:   true, // @everyone
+		ticket.UserId:    true,
+		worker.BotId:     true,
+		previous.Claimer: true,
+		current.Claimer:  true,
+	}
+
+	ids := append([]uint64{}, members...)
+
+	supportUsers, err := dbclient.Client.Permissions.GetSupport(ticket.GuildId)
+	if err != nil {
+		return nil, err
+	}
+
+	supportRoles, err := dbclient.Client.RolePermissions.GetSupportRoles(ticket.GuildId)
+	if err != nil {
+		return nil, err
+	}
+
+	ids = append(ids, supportUsers...)
+	ids = append(ids, supportRoles...)
+
+	for _, panel := range []*database.Panel{previous.Panel, current.Panel} {
+		if panel == nil {
+			continue
+		}
+
+		teamUsers, err := dbclient.Client.SupportTeamMembers.GetAllSupportMembersForPanel(panel.PanelId)
+		if err != nil {
+			return nil, err
+		}
+
+		teamRoles, err := dbclient.Client.SupportTeamRoles.GetAllSupportRolesForPanel(panel.PanelId)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, teamUsers...)
+		ids = append(ids, teamRoles...)
+	}
+
+	ticketTeamUsers, ticketTeamRoles, err := getTicketTeamUsersRoles(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids = append(ids, ticketTeamUsers...)
+	ids = append(ids, ticketTeamRoles...)
+
+	for _, id := range ids {
+		managed[id] = true
+	}
+
+	// A claimer of 0 means the ticket is unclaimed
+	delete(managed, 0)
+
+	// The opener of a staff only ticket was never given an overwrite, so any they have was added by hand
+	if current.StaffOnly {
+		delete(managed, ticket.UserId)
+	}
+
+	return managed, nil
+}
+
+// isStaffOnly returns whether the ticket is a modmail ticket, which is hidden from its opener
+func isStaffOnly(ticket database.Ticket) (bool, error) {
+	_, ok, err := dbclient.Tables.ModmailSessions.GetByTicket(ticket.GuildId, ticket.Id)
+	return ok, err
+}
+
+// dedupeOverwrites keeps the first overwrite for each user or role, as Discord only allows one per channel
+func dedupeOverwrites(overwrites []channel.PermissionOverwrite) []channel.PermissionOverwrite {
+	seen := make(map[uint64]bool)
+	deduped := make([]channel.PermissionOverwrite, 0, len(overwrites))
+	for _, overwrite := range overwrites {
+		if seen[overwrite.Id] {
+			continue
+		}
+
+		seen[overwrite.Id] = true
+		deduped = append(deduped, overwrite)
+	}
+
+	return deduped
+}
+
+func overwritesEqual(a, b channel.PermissionOverwrite) bool {
+	return a.Type == b.Type && a.Allow == b.Allow && a.Deny == b.Deny
+}
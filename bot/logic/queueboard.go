@@ -0,0 +1,143 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest"
+	"github.com/rxdn/gdl/rest/request"
+	"strings"
+	"time"
+)
+
+// queueBoardDebounce is how long to wait for further ticket activity before editing the queue board
+const queueBoardDebounce = time.Second * 5
+
+// RequestQueueBoardUpdate schedules an edit of the guild's queue board, if it has one. Activity before the edit is
+// made is covered by it, so only the first request in each window schedules anything.
+func RequestQueueBoardUpdate(ctx registry.CommandContext) {
+	guildId := ctx.GuildId()
+
+	taken, err := redis.TakeQueueBoardUpdate(guildId)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	if !taken {
+		return
+	}
+
+	go func() {
+		time.Sleep(queueBoardDebounce)
+
+		// Release before reading the queue, so that activity from this point onwards schedules another update
+		if err := redis.ReleaseQueueBoardUpdate(guildId); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+
+		if err := UpdateQueueBoard(ctx.Worker(), guildId); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+	}()
+}
+
+// UpdateQueueBoard edits the guild's queue board to show the current queue. If the message has been deleted, the board
+// is removed.
+func UpdateQueueBoard(worker *worker.Context, guildId uint64) error {
+	board, ok, err := dbclient.Tables.QueueBoards.Get(guildId)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	msgEmbed, err := BuildQueueBoardEmbed(guildId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := worker.EditMessage(board.ChannelId, board.MessageId, rest.EditMessageData{
+		Embeds: utils.Slice(msgEmbed),
+	}); err != nil {
+		if restError, ok := err.(request.RestError); ok && restError.StatusCode == 404 {
+			return dbclient.Tables.QueueBoards.Delete(guildId, board.MessageId)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func BuildQueueBoardEmbed(guildId uint64) (*embed.Embed, error) {
+	summaries, err := dbclient.Tables.OpenTicketList.GetQueueSummary(guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	oldest, hasOldest, err := dbclient.Tables.OpenTicketList.GetOldestUnclaimed(guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts, err := dbclient.Tables.StaffShifts.GetByGuild(guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	var open, unclaimed int
+	panelLines := make([]string, len(summaries))
+	for i, summary := range summaries {
+		open += summary.Open
+		unclaimed += summary.Unclaimed
+
+		title := "No Panel"
+		if summary.PanelTitle != nil {
+			title = utils.StringMax(*summary.PanelTitle, 40, "...")
+		}
+
+		panelLines[i] = fmt.Sprintf("**%s**: %d open, %d unclaimed", title, summary.Open, summary.Unclaimed)
+	}
+
+	panels := "There are no open tickets"
+	if len(panelLines) > 0 {
+		panels = utils.StringMax(strings.Join(panelLines, "\n"), 1024)
+	}
+
+	oldestWaiting := "No tickets are waiting to be claimed"
+	if hasOldest {
+		oldestWaiting = fmt.Sprintf("`#%d` <#%d>, opened %s", oldest.Id, *oldest.ChannelId, message.BuildTimestamp(oldest.OpenTime, message.TimestampStyleRelativeTime))
+	}
+
+	onShift := "Nobody is on shift"
+	if len(shifts) > 0 {
+		mentions := make([]string, len(shifts))
+		for i, shift := range shifts {
+			mentions[i] = fmt.Sprintf("<@%d>", shift.UserId)
+		}
+
+		onShift = utils.StringMax(strings.Join(mentions, " "), 1024)
+	}
+
+	msgEmbed := embed.NewEmbed().
+		SetColor(customisation.GetColourOrDefault(guildId, customisation.Green)).
+		SetTitle("Ticket Queue").
+		SetDescription(fmt.Sprintf("**%d** open, **%d** unclaimed", open, unclaimed)).
+		AddField("Panels", panels, false).
+		AddField("Oldest Waiting", oldestWaiting, false).
+		AddField("On Shift", onShift, false).
+		SetFooter("Last updated", "").
+		SetTimestamp(time.Now())
+
+	return msgEmbed, nil
+}
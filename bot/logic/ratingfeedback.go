@@ -0,0 +1,201 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest"
+	"github.com/rxdn/gdl/rest/request"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseYesNoAnswer returns the stored form of a yes / no answer, as typed by the user
+func ParseYesNoAnswer(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "y", "yes":
+		return tables.RatingAnswerYes, true
+	case "n", "no":
+		return tables.RatingAnswerNo, true
+	default:
+		return "", false
+	}
+}
+
+// HandleRatingFeedback posts the rating, along with any answers, to the feedback channel, and sends an alert if the
+// rating is low. If the user rates the ticket again, the existing message is edited rather than a new one being posted,
+// and staff are only ever alerted once per ticket. ctx may be a DM context, so the guild ID must be passed explicitly.
+func HandleRatingFeedback(ctx registry.CommandContext, guildId uint64, ticket database.Ticket, rating uint8, questions []tables.RatingQuestion, answers map[int]string) {
+	settings, err := dbclient.Tables.RatingFeedback.Get(guildId)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	claimerId, err := dbclient.Client.TicketClaims.Get(guildId, ticket.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	first, err := dbclient.Tables.RatingMessages.Reserve(guildId, ticket.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	alert := false
+	if settings.LowRatingThreshold > 0 && rating <= settings.LowRatingThreshold {
+		if alert, err = dbclient.Tables.RatingMessages.MarkAlerted(guildId, ticket.Id); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			return
+		}
+	}
+
+	feedbackEmbed := buildRatingFeedbackEmbed(ctx, ticket, claimerId, rating, questions, answers)
+
+	if settings.ChannelId != nil {
+		// An alert needs a new message to mention the role, so it is the only reason to post more than once
+		posted := false
+		if !first && !(alert && settings.AlertRoleId != nil) {
+			if posted, err = editRatingFeedbackMessage(ctx, guildId, ticket.Id, feedbackEmbed); err != nil {
+				// Rather than risking a duplicate message
+				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+				posted = true
+			}
+		}
+
+		if !posted {
+			data := rest.CreateMessageData{
+				Embeds: []*embed.Embed{feedbackEmbed},
+			}
+
+			if alert && settings.AlertRoleId != nil {
+				data.Content = fmt.Sprintf("<@&%d>", *settings.AlertRoleId)
+				data.AllowedMentions = message.AllowedMention{
+					Roles: []uint64{*settings.AlertRoleId},
+				}
+			}
+
+			msg, err := ctx.Worker().CreateMessageComplex(*settings.ChannelId, data)
+			if err != nil {
+				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			} else if err := dbclient.Tables.RatingMessages.SetMessage(guildId, ticket.Id, msg.ChannelId, msg.Id); err != nil {
+				sentry.ErrorWithContext(err, ctx.ToErrorContext())
+			}
+		}
+	}
+
+	// Without an alert role, the claimer is notified directly instead
+	if alert && settings.AlertRoleId == nil && claimerId != 0 {
+		dmChannel, ok := getDmChannel(ctx, claimerId)
+		if !ok {
+			return
+		}
+
+		alertEmbed := *feedbackEmbed
+		alertEmbed.SetTitle("Low Rating Received").SetColor(ctx.GetColour(customisation.Red))
+
+		if _, err := ctx.Worker().CreateMessageEmbed(dmChannel, &alertEmbed); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+	}
+}
+
+// AddRatingFeedbackAnswers edits the message posted by HandleRatingFeedback to include the answers to the follow-up
+// questions. Staff have already been alerted about the rating, so they are not alerted again.
+func AddRatingFeedbackAnswers(ctx registry.CommandContext, guildId uint64, ticket database.Ticket, rating uint8, questions []tables.RatingQuestion, answers map[int]string) {
+	settings, err := dbclient.Tables.RatingFeedback.Get(guildId)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	if settings.ChannelId == nil {
+		return
+	}
+
+	claimerId, err := dbclient.Client.TicketClaims.Get(guildId, ticket.Id)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	feedbackEmbed := buildRatingFeedbackEmbed(ctx, ticket, claimerId, rating, questions, answers)
+
+	edited, err := editRatingFeedbackMessage(ctx, guildId, ticket.Id, feedbackEmbed)
+	if err != nil {
+		sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		return
+	}
+
+	// The message has been deleted, so post the answers again
+	if !edited {
+		msg, err := ctx.Worker().CreateMessageEmbed(*settings.ChannelId, feedbackEmbed)
+		if err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		} else if err := dbclient.Tables.RatingMessages.SetMessage(guildId, ticket.Id, msg.ChannelId, msg.Id); err != nil {
+			sentry.ErrorWithContext(err, ctx.ToErrorContext())
+		}
+	}
+}
+
+// editRatingFeedbackMessage returns false if the message has been deleted, in which case a new one should be posted. If
+// the first rating is still posting the message, there is nothing to edit, but true is returned as it will be posted.
+func editRatingFeedbackMessage(ctx registry.CommandContext, guildId uint64, ticketId int, feedbackEmbed *embed.Embed) (bool, error) {
+	existing, ok, err := dbclient.Tables.RatingMessages.Get(guildId, ticketId)
+	if err != nil {
+		return false, err
+	}
+
+	// The first rating is still posting the message
+	if !ok || existing.MessageId == nil {
+		return true, nil
+	}
+
+	if _, err := ctx.Worker().EditMessage(*existing.ChannelId, *existing.MessageId, rest.EditMessageData{
+		Embeds: []*embed.Embed{feedbackEmbed},
+	}); err != nil {
+		if restError, ok := err.(request.RestError); ok && restError.StatusCode == 404 {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func buildRatingFeedbackEmbed(ctx registry.CommandContext, ticket database.Ticket, claimerId uint64, rating uint8, questions []tables.RatingQuestion, answers map[int]string) *embed.Embed {
+	claimedBy := "Not claimed"
+	if claimerId != 0 {
+		claimedBy = fmt.Sprintf("<@%d>", claimerId)
+	}
+
+	feedbackEmbed := embed.NewEmbed().
+		SetTitle("Ticket Feedback").
+		SetColor(ctx.GetColour(customisation.Green)).
+		SetTimestamp(time.Now()).
+		AddField("Ticket ID", strconv.Itoa(ticket.Id), true).
+		AddField("Opened By", fmt.Sprintf("<@%d>", ticket.UserId), true).
+		AddField("Claimed By", claimedBy, true).
+		AddField("Rating", fmt.Sprintf("%s (%d / 5)", strings.Repeat("⭐", int(rating)), rating), false)
+
+	for _, question := range questions {
+		answer, ok := answers[question.Id]
+		if !ok || strings.TrimSpace(answer) == "" {
+			answer = "No answer"
+		}
+
+		feedbackEmbed.AddField(question.Label, answer, false)
+	}
+
+	return feedbackEmbed
+}
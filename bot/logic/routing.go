@@ -0,0 +1,157 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/rxdn/gdl/objects/channel"
+	"github.com/rxdn/gdl/permission"
+	"regexp"
+	"strings"
+)
+
+// TicketRoute is the outcome of a panel's routing rules. Nil fields should fall back to the panel's settings.
+type TicketRoute struct {
+	TargetCategory *uint64
+	SupportTeamId  *int
+	NamingScheme   *string
+	Priority       *tables.TicketPriority
+}
+
+// ResolveRoute evaluates the routing rules of a panel in order, returning the outcome of the first rule that matches
+func ResolveRoute(panel *database.Panel, formData map[database.FormInput]string) (TicketRoute, error) {
+	if panel == nil {
+		return TicketRoute{}, nil
+	}
+
+	rules, err := dbclient.Tables.PanelRoutingRules.GetByPanel(panel.PanelId)
+	if err != nil {
+		return TicketRoute{}, err
+	}
+
+	if len(rules) == 0 {
+		return TicketRoute{}, nil
+	}
+
+	// form_input_id -> answer
+	answers := make(map[int]string)
+	for input, answer := range formData {
+		answers[input.Id] = strings.TrimSpace(answer)
+	}
+
+	for _, rule := range rules {
+		if routingRuleMatches(rule, answers) {
+			return TicketRoute{
+				TargetCategory: rule.TargetCategory,
+				SupportTeamId:  rule.SupportTeamId,
+				NamingScheme:   rule.NamingScheme,
+				Priority:       rule.Priority,
+			}, nil
+		}
+	}
+
+	return TicketRoute{}, nil
+}
+
+func routingRuleMatches(rule tables.PanelRoutingRule, answers map[int]string) bool {
+	if rule.Operator == tables.RoutingOperatorAlways {
+		return true
+	}
+
+	if rule.FormInputId == nil {
+		return false
+	}
+
+	answer, ok := answers[*rule.FormInputId]
+	if !ok {
+		return false
+	}
+
+	switch rule.Operator {
+	case tables.RoutingOperatorEquals:
+		return strings.EqualFold(answer, strings.TrimSpace(rule.Value))
+	case tables.RoutingOperatorContains:
+		return strings.Contains(strings.ToLower(answer), strings.ToLower(rule.Value))
+	case tables.RoutingOperatorMatches:
+		pattern, err := regexp.Compile(rule.Value)
+		if err != nil {
+			sentry.Error(fmt.Errorf("invalid pattern for routing rule %d: %w", rule.Id, err))
+			return false
+		}
+
+		return pattern.MatchString(answer)
+	default:
+		return false
+	}
+}
+
+// isRoutedToTeam returns whether the ticket was routed to a support team, in which case the team replaces the default
+// team and the panel's teams
+func isRoutedToTeam(guildId uint64, ticketId int) (bool, error) {
+	teamIds, err := dbclient.Tables.TicketSupportTeams.Get(guildId, ticketId)
+	if err != nil {
+		return false, err
+	}
+
+	return len(teamIds) > 0, nil
+}
+
+// getTicketTeamUsersRoles returns the members of support teams that were assigned to the ticket directly, rather than
+// through its panel
+func getTicketTeamUsersRoles(guildId uint64, ticketId int) ([]uint64, []uint64, error) {
+	teamIds, err := dbclient.Tables.TicketSupportTeams.Get(guildId, ticketId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users, roles []uint64
+	for _, teamId := range teamIds {
+		teamUsers, err := dbclient.Client.SupportTeamMembers.Get(teamId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		teamRoles, err := dbclient.Client.SupportTeamRoles.Get(teamId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		users = append(users, teamUsers...)
+		roles = append(roles, teamRoles...)
+	}
+
+	return users, roles, nil
+}
+
+// TicketTeamOverwrites builds the overwrites for support teams that were assigned to the ticket directly, for example
+// by a routing rule. These are not included by CreateOverwrites, as it is not aware of the ticket, and replace the
+// default team and the panel's teams.
+func TicketTeamOverwrites(guildId uint64, ticketId int) ([]channel.PermissionOverwrite, error) {
+	users, roles, err := getTicketTeamUsersRoles(guildId, ticketId)
+	if err != nil {
+		return nil, err
+	}
+
+	var overwrites []channel.PermissionOverwrite
+	for _, userId := range users {
+		overwrites = append(overwrites, channel.PermissionOverwrite{
+			Id:    userId,
+			Type:  channel.PermissionTypeMember,
+			Allow: permission.BuildPermissions(StandardPermissions[:]...),
+			Deny:  0,
+		})
+	}
+
+	for _, roleId := range roles {
+		overwrites = append(overwrites, channel.PermissionOverwrite{
+			Id:    roleId,
+			Type:  channel.PermissionTypeRole,
+			Allow: permission.BuildPermissions(StandardPermissions[:]...),
+			Deny:  0,
+		})
+	}
+
+	return overwrites, nil
+}
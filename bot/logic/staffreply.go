@@ -0,0 +1,228 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/user"
+	"github.com/rxdn/gdl/rest"
+	"github.com/rxdn/gdl/rest/request"
+	"time"
+)
+
+var ErrNoTicketWebhook = errors.New("ticket webhook could not be created")
+
+// GetAnonymiseSettings returns the anonymise settings of the ticket's panel. Tickets opened without a panel are never
+// anonymised by default.
+func GetAnonymiseSettings(ticket database.Ticket) (tables.PanelAnonymiseSettings, error) {
+	if ticket.PanelId == nil {
+		return tables.PanelAnonymiseSettings{}, nil
+	}
+
+	return dbclient.Tables.PanelAnonymise.Get(*ticket.PanelId)
+}
+
+// MaxStaffReplyAttachments is the number of files Discord allows on a single webhook message, so messages with more
+// attachments can't be reposted
+const MaxStaffReplyAttachments = 10
+
+// SendStaffReply posts new content in the ticket's channel through the ticket's webhook. If anonymous, the message is
+// sent under the panel's team name and avatar, otherwise under the author's own. The real author is always recorded,
+// so that transcripts still show who sent the message.
+func SendStaffReply(ctx registry.CommandContext, ticket database.Ticket, author user.User, content string, anonymous bool) (*message.Message, error) {
+	// Webhooks aren't limited by the author's permission to mention roles or everyone, so only users may be mentioned
+	mentions := message.AllowedMention{
+		Parse: []message.AllowedMentionType{
+			message.USERS,
+		},
+	}
+
+	return sendStaffReply(ctx, ticket, author, content, nil, anonymous, mentions)
+}
+
+// RepostStaffMessage posts a copy of a staff member's message anonymously through the ticket's webhook, so that the
+// original can be deleted
+func RepostStaffMessage(ctx registry.CommandContext, ticket database.Ticket, author user.User, content string, files []request.Attachment) (*message.Message, error) {
+	// The original message has already notified anyone it mentions
+	return sendStaffReply(ctx, ticket, author, content, files, true, message.AllowedMention{})
+}
+
+func sendStaffReply(
+	ctx registry.CommandContext,
+	ticket database.Ticket,
+	author user.User,
+	content string,
+	files []request.Attachment,
+	anonymous bool,
+	mentions message.AllowedMention,
+) (*message.Message, error) {
+	if ticket.ChannelId == nil {
+		return nil, errors.New("ticket has no channel")
+	}
+
+	data := rest.WebhookBody{
+		Content:         content,
+		Username:        author.Username,
+		AvatarUrl:       author.AvatarUrl(256),
+		Attachments:     files,
+		AllowedMentions: mentions,
+	}
+
+	if anonymous {
+		settings, err := GetAnonymiseSettings(ticket)
+		if err != nil {
+			return nil, err
+		}
+
+		if data.Username, data.AvatarUrl, err = getTeamIdentity(ctx, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := executeTicketWebhook(ctx, ticket, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbclient.Tables.StaffReplies.Create(ticket.GuildId, ticket.Id, msg.Id, author.Id); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func getTeamIdentity(ctx registry.CommandContext, settings tables.PanelAnonymiseSettings) (name, avatarUrl string, err error) {
+	if settings.TeamName != nil {
+		name = *settings.TeamName
+	} else {
+		guild, err := ctx.Guild()
+		if err != nil {
+			return "", "", err
+		}
+
+		name = fmt.Sprintf("%s Staff", guild.Name)
+	}
+
+	if settings.AvatarUrl != nil {
+		avatarUrl = *settings.AvatarUrl
+	} else if self, err := ctx.Worker().Self(); err == nil {
+		avatarUrl = self.AvatarUrl(256)
+	}
+
+	return
+}
+
+func executeTicketWebhook(ctx registry.CommandContext, ticket database.Ticket, data rest.WebhookBody) (*message.Message, error) {
+	webhook, err := getTicketWebhook(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := ctx.Worker().ExecuteWebhook(webhook.Id, webhook.Token, true, data)
+	if err == nil {
+		return msg, nil
+	}
+
+	// The webhook may have been deleted by a user, so create a new one and try once more
+	if restError, ok := err.(request.RestError); !ok || restError.StatusCode != 404 {
+		return nil, err
+	}
+
+	if err := dbclient.Client.Webhooks.Delete(ticket.GuildId, ticket.Id); err != nil {
+		return nil, err
+	}
+
+	if webhook, err = getTicketWebhook(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	return ctx.Worker().ExecuteWebhook(webhook.Id, webhook.Token, true, data)
+}
+
+// Webhooks are only created when premium tickets are opened, so create one on demand for other tickets
+func getTicketWebhook(ctx registry.CommandContext, ticket database.Ticket) (database.Webhook, error) {
+	webhook, err := dbclient.Client.Webhooks.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return database.Webhook{}, err
+	}
+
+	if webhook.Id != 0 {
+		return webhook, nil
+	}
+
+	createWebhook(ctx.Worker(), ticket.Id, ticket.GuildId, *ticket.ChannelId)
+
+	webhook, err = dbclient.Client.Webhooks.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return database.Webhook{}, err
+	}
+
+	if webhook.Id == 0 {
+		return database.Webhook{}, ErrNoTicketWebhook
+	}
+
+	return webhook, nil
+}
+
+// RecordStaffReply updates the ticket's participants, first response time and last message for a reply that was
+// never sent by the staff member themselves
+func RecordStaffReply(ticket database.Ticket, messageId, authorId uint64) error {
+	if err := dbclient.Client.Participants.Set(ticket.GuildId, ticket.Id, authorId); err != nil {
+		return err
+	}
+
+	// ON CONFLICT DO NOTHING, so only the first response is recorded
+	if err := dbclient.Client.FirstResponseTime.Set(ticket.GuildId, authorId, ticket.Id, GetResponseTime(ticket, time.Now())); err != nil {
+		return err
+	}
+
+	// If the last message was sent by staff, don't reset the autoclose timer
+	lastMessage, err := dbclient.Client.TicketLastMessage.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	if lastMessage.UserId != nil && lastMessage.UserIsStaff {
+		return nil
+	}
+
+	return dbclient.Client.TicketLastMessage.Set(ticket.GuildId, ticket.Id, messageId, authorId, true)
+}
+
+// RestoreStaffReplyAuthors replaces the webhook author of staff replies with the staff member who sent them, so that
+// transcripts show the real author
+func RestoreStaffReplyAuthors(ctx registry.CommandContext, ticket database.Ticket, msgs []message.Message) error {
+	authors, err := dbclient.Tables.StaffReplies.GetAuthors(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(authors) == 0 {
+		return nil
+	}
+
+	users := make(map[uint64]user.User)
+	for i, msg := range msgs {
+		authorId, ok := authors[msg.Id]
+		if !ok {
+			continue
+		}
+
+		author, ok := users[authorId]
+		if !ok {
+			if author, err = ctx.Worker().GetUser(authorId); err != nil {
+				return err
+			}
+
+			users[authorId] = author
+		}
+
+		msgs[i].Author = author
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"golang.org/x/sync/errgroup"
+	"strings"
+	"time"
+)
+
+const digestTopStaffCount = 5
+
+// DigestPeriodStart returns the start of the period that t falls in. Periods start at midnight UTC on Monday for weekly
+// digests, and on the 1st for monthly digests.
+func DigestPeriodStart(frequency tables.DigestFrequency, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if frequency == tables.DigestFrequencyMonthly {
+		return day.AddDate(0, 0, 1-day.Day())
+	} else {
+		// time.Weekday starts on Sunday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+}
+
+// PreviousDigestPeriod returns the period before the one that starts at periodStart
+func PreviousDigestPeriod(frequency tables.DigestFrequency, periodStart time.Time) time.Time {
+	if frequency == tables.DigestFrequencyMonthly {
+		return periodStart.AddDate(0, -1, 0)
+	} else {
+		return periodStart.AddDate(0, 0, -7)
+	}
+}
+
+// BuildStatsDigest summarises the period that ended at periodEnd, comparing it to the period before
+func BuildStatsDigest(guildId uint64, frequency tables.DigestFrequency, periodEnd time.Time) (*embed.Embed, error) {
+	periodStart := PreviousDigestPeriod(frequency, periodEnd)
+	previousStart := PreviousDigestPeriod(frequency, periodStart)
+
+	group, _ := errgroup.WithContext(context.Background())
+
+	var current, previous tables.PeriodStats
+	group.Go(func() (err error) {
+		current, err = dbclient.Tables.PeriodStats.Get(guildId, periodStart, periodEnd)
+		return
+	})
+
+	group.Go(func() (err error) {
+		previous, err = dbclient.Tables.PeriodStats.Get(guildId, previousStart, periodStart)
+		return
+	})
+
+	var topStaff []tables.StaffTicketCount
+	group.Go(func() (err error) {
+		topStaff, err = dbclient.Tables.PeriodStats.GetTopStaff(guildId, periodStart, periodEnd, digestTopStaffCount)
+		return
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	title := "Weekly Statistics"
+	if frequency == tables.DigestFrequencyMonthly {
+		title = "Monthly Statistics"
+	}
+
+	// The period ends at midnight, so show the last day that it covers
+	description := fmt.Sprintf("%s - %s",
+		message.BuildTimestamp(periodStart, message.TimestampStyleShortDate),
+		message.BuildTimestamp(periodEnd.Add(-time.Second), message.TimestampStyleShortDate),
+	)
+
+	rating := "No data"
+	if current.RatingAverage != nil {
+		rating = fmt.Sprintf("%.1f / 5 ⭐ from %d ratings", *current.RatingAverage, current.RatingCount)
+		if previous.RatingAverage != nil {
+			rating += " " + formatDelta(*current.RatingAverage-*previous.RatingAverage, "%.1f")
+		}
+	}
+
+	msgEmbed := embed.NewEmbed().
+		SetTitle(title).
+		SetDescription(description).
+		SetColor(customisation.GetColourOrDefault(guildId, customisation.Green)).
+		AddField("Tickets Opened", fmt.Sprintf("%d %s", current.Opened, formatDelta(float64(current.Opened-previous.Opened), "%.0f")), true).
+		AddField("Tickets Closed", fmt.Sprintf("%d %s", current.Closed, formatDelta(float64(current.Closed-previous.Closed), "%.0f")), true).
+		AddField("Feedback Rating", rating, true).
+		AddField("Average First Response Time", formatDurationWithDelta(current.AverageResponseTime, previous.AverageResponseTime), true).
+		AddField("Average Ticket Duration", formatDurationWithDelta(current.AverageDuration, previous.AverageDuration), true).
+		AddBlankField(true).
+		AddField("Top Staff", formatTopStaff(topStaff), false)
+
+	return msgEmbed, nil
+}
+
+func formatDelta(delta float64, format string) string {
+	if delta > 0 {
+		return fmt.Sprintf("(▲ "+format+")", delta)
+	} else if delta < 0 {
+		return fmt.Sprintf("(▼ "+format+")", -delta)
+	} else {
+		return "(-)"
+	}
+}
+
+func formatDurationWithDelta(current, previous *time.Duration) string {
+	if current == nil {
+		return "No data"
+	}
+
+	formatted := utils.FormatTime(*current)
+	if previous == nil {
+		return formatted
+	}
+
+	delta := *current - *previous
+	if delta > 0 {
+		return fmt.Sprintf("%s (▲ %s)", formatted, utils.FormatTime(delta))
+	} else if delta < 0 {
+		return fmt.Sprintf("%s (▼ %s)", formatted, utils.FormatTime(-delta))
+	} else {
+		return formatted + " (-)"
+	}
+}
+
+func formatTopStaff(topStaff []tables.StaffTicketCount) string {
+	if len(topStaff) == 0 {
+		return "No data"
+	}
+
+	var content string
+	for i, staff := range topStaff {
+		content += fmt.Sprintf("%d. <@%d> - %d tickets\n", i+1, staff.UserId, staff.Tickets)
+	}
+
+	return strings.TrimSuffix(content, "\n")
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"errors"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/rest"
+)
+
+// SwitchPanel moves the ticket to the panel, updating the welcome message, channel name, category and permissions
+func SwitchPanel(ctx registry.CommandContext, ticket database.Ticket, panel database.Panel) error {
+	if ticket.ChannelId == nil {
+		return errors.New("channel ID is nil")
+	}
+
+	// The support teams of the previous panel may need to lose access
+	var previousPanel *database.Panel
+	if ticket.PanelId != nil {
+		tmp, err := dbclient.Client.Panel.GetById(*ticket.PanelId)
+		if err != nil {
+			return err
+		}
+
+		if tmp.PanelId != 0 {
+			previousPanel = &tmp
+		}
+	}
+
+	// Update panel assigned to ticket in database
+	if err := dbclient.Client.Tickets.SetPanelId(ticket.GuildId, ticket.Id, panel.PanelId); err != nil {
+		return err
+	}
+
+	// Get ticket claimer
+	claimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	// Update welcome message
+	if ticket.WelcomeMessageId != nil {
+		msg, err := ctx.Worker().GetChannelMessage(*ticket.ChannelId, *ticket.WelcomeMessageId)
+
+		// Error is likely to be due to message being deleted, we want to continue further even if it is
+		if err == nil {
+			var subject string
+
+			embeds := utils.PtrElems(msg.Embeds) // TODO: Fix types
+			if len(embeds) == 0 {
+				embeds = make([]*embed.Embed, 1)
+				subject = "No subject given"
+			} else {
+				subject = embeds[0].Title // TODO: Store subjects in database
+			}
+
+			embeds[0], err = BuildWelcomeMessageEmbed(ctx, ticket, subject, &panel)
+			if err != nil {
+				return err
+			}
+
+			for i := 1; i < len(embeds); i++ {
+				embeds[i].Color = embeds[0].Color
+			}
+
+			editData := rest.EditMessageData{
+				Content:    msg.Content,
+				Embeds:     embeds,
+				Flags:      msg.Flags,
+				Components: msg.Components,
+			}
+
+			if _, err = ctx.Worker().EditMessage(*ticket.ChannelId, *ticket.WelcomeMessageId, editData); err != nil {
+				ctx.HandleWarning(err)
+			}
+		}
+	}
+
+	channelName, err := GenerateChannelName(ctx, &panel, ticket.Id, ticket.UserId, utils.NilIfZero(claimer))
+	if err != nil {
+		return err
+	}
+
+	data := rest.ModifyChannelData{
+		Name:     channelName,
+		ParentId: panel.TargetCategory,
+	}
+
+	if _, err := ctx.Worker().ModifyChannel(*ticket.ChannelId, data); err != nil {
+		return err
+	}
+
+	// Update channel permissions
+	staffOnly, err := isStaffOnly(ticket)
+	if err != nil {
+		return err
+	}
+
+	previous := OverwriteState{Panel: previousPanel, Claimer: claimer, StaffOnly: staffOnly}
+	current := OverwriteState{Panel: &panel, Claimer: claimer, StaffOnly: staffOnly}
+	return ReconcileTicketOverwrites(ctx.Worker(), ticket, previous, current)
+}
@@ -0,0 +1,228 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/rest/request"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Tag arguments are written as %arg:name% in the tag's content or embed, and supplied as name:value pairs
+var tagArgumentPattern = regexp.MustCompile(`%arg:([a-zA-Z0-9_]+)%`)
+
+// ParseTagArguments parses arguments in the form `amount:20 reason:"late delivery"`
+func ParseTagArguments(raw string) (map[string]string, bool) {
+	args := make(map[string]string)
+
+	raw = strings.TrimSpace(raw)
+	for len(raw) > 0 {
+		colon := strings.IndexRune(raw, ':')
+		if colon <= 0 {
+			return nil, false
+		}
+
+		name := strings.ToLower(raw[:colon])
+		if strings.ContainsAny(name, " \t\n") {
+			return nil, false
+		}
+
+		raw = raw[colon+1:]
+
+		var value string
+		if strings.HasPrefix(raw, `"`) {
+			end := strings.IndexRune(raw[1:], '"')
+			if end == -1 {
+				return nil, false
+			}
+
+			value = raw[1 : end+1]
+			raw = raw[end+2:]
+		} else {
+			end := strings.IndexAny(raw, " \t\n")
+			if end == -1 {
+				end = len(raw)
+			}
+
+			value = raw[:end]
+			raw = raw[end:]
+		}
+
+		args[name] = value
+		raw = strings.TrimSpace(raw)
+	}
+
+	return args, true
+}
+
+// TagArgumentNames returns the name of each argument used by the tag, in the order they first appear
+func TagArgumentNames(content string, e *embed.Embed) []string {
+	texts := []string{content}
+	if e != nil {
+		texts = append(texts, e.Title, e.Description)
+		for _, field := range e.Fields {
+			texts = append(texts, field.Name, field.Value)
+		}
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, match := range tagArgumentPattern.FindAllStringSubmatch(text, -1) {
+			name := strings.ToLower(match[1])
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// SubstituteTagArguments replaces arguments in the content and embed. All arguments used must be present in args.
+func SubstituteTagArguments(content string, e *embed.Embed, args map[string]string) string {
+	replace := func(s string) string {
+		return tagArgumentPattern.ReplaceAllStringFunc(s, func(match string) string {
+			name := strings.ToLower(tagArgumentPattern.FindStringSubmatch(match)[1])
+			return args[name]
+		})
+	}
+
+	if e != nil {
+		e.Title = replace(e.Title)
+		e.Description = replace(e.Description)
+		for i := range e.Fields {
+			e.Fields[i].Name = replace(e.Fields[i].Name)
+			e.Fields[i].Value = replace(e.Fields[i].Value)
+		}
+	}
+
+	return replace(content)
+}
+
+// CanUseTag checks the tag's panel and role restrictions. Admins are not restricted by role. If the tag cannot be used,
+// the message explaining why is returned.
+func CanUseTag(ctx registry.CommandContext, settings tables.TagSettings, ticket database.Ticket) (bool, i18n.MessageId, error) {
+	if len(settings.PanelIds) > 0 {
+		if ticket.PanelId == nil || !utils.Contains(settings.PanelIds, *ticket.PanelId) {
+			return false, i18n.MessageTagPanelRestricted, nil
+		}
+	}
+
+	if len(settings.RoleIds) > 0 {
+		permissionLevel, err := ctx.UserPermissionLevel()
+		if err != nil {
+			return false, "", err
+		}
+
+		if permissionLevel < permission.Admin {
+			member, err := ctx.Member()
+			if err != nil {
+				return false, "", err
+			}
+
+			hasRole := false
+			for _, roleId := range member.Roles {
+				if utils.Contains(settings.RoleIds, roleId) {
+					hasRole = true
+					break
+				}
+			}
+
+			if !hasRole {
+				return false, i18n.MessageTagRoleRestricted, nil
+			}
+		}
+	}
+
+	return true, "", nil
+}
+
+var tagAttachmentClient = &http.Client{
+	Timeout: time.Second * 10,
+}
+
+var ErrAttachmentTooLarge = errors.New("attachment is too large")
+
+// DownloadAttachment downloads a file from Discord, so that it can be stored or uploaded again. Discord's URLs expire,
+// so they must not be kept. Files larger than limit bytes are rejected with ErrAttachmentTooLarge.
+func DownloadAttachment(ctx context.Context, url string, limit int) (data []byte, contentType string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := tagAttachmentClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching attachment returned status %d", res.StatusCode)
+	}
+
+	data, err = io.ReadAll(io.LimitReader(res.Body, int64(limit)+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(data) > limit {
+		return nil, "", ErrAttachmentTooLarge
+	}
+
+	return data, res.Header.Get("Content-Type"), nil
+}
+
+// BuildTagAttachmentFiles returns the tag's stored attachments, ready to be uploaded with the message
+func BuildTagAttachmentFiles(attachments []tables.TagAttachment) []request.Attachment {
+	files := make([]request.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		files[i] = request.Attachment{
+			Name:        attachment.FileName,
+			ContentType: attachment.ContentType,
+			Reader:      bytes.NewReader(attachment.Data),
+		}
+	}
+
+	return files
+}
+
+// FetchMessageAttachments downloads a message's attachments so that they can be uploaded again when the message is
+// reposted. The total size is limited to tables.MaxTagAttachmentSize.
+func FetchMessageAttachments(ctx context.Context, msg message.Message) ([]request.Attachment, error) {
+	files := make([]request.Attachment, len(msg.Attachments))
+
+	remaining := tables.MaxTagAttachmentSize
+	for i, attachment := range msg.Attachments {
+		data, contentType, err := DownloadAttachment(ctx, attachment.Url, remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining -= len(data)
+
+		files[i] = request.Attachment{
+			Name:        attachment.Filename,
+			ContentType: contentType,
+			Reader:      bytes.NewReader(data),
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,140 @@
+package logic
+
+import (
+	"encoding/json"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+)
+
+// TagFromMessage converts a message into a tag, using its content and its first embed, as tags only support a
+// single embed. Returns false if the message has nothing that can be stored in a tag.
+func TagFromMessage(guildId uint64, tagId string, msg message.Message) (database.Tag, bool) {
+	tag := database.Tag{
+		Id:      tagId,
+		GuildId: guildId,
+		Content: utils.NilIfZero(msg.Content),
+	}
+
+	if len(msg.Embeds) > 0 {
+		tag.Embed = convertMessageEmbed(guildId, msg.Embeds[0])
+	}
+
+	return tag, tag.Content != nil || tag.Embed != nil
+}
+
+func convertMessageEmbed(guildId uint64, e embed.Embed) *database.CustomEmbedWithFields {
+	customEmbed := database.CustomEmbed{
+		GuildId:     guildId,
+		Title:       utils.NilIfZero(e.Title),
+		Description: utils.NilIfZero(e.Description),
+		Url:         utils.NilIfZero(e.Url),
+		Colour:      uint32(e.Color),
+		Timestamp:   e.Timestamp,
+	}
+
+	if e.Author != nil {
+		customEmbed.AuthorName = utils.NilIfZero(e.Author.Name)
+		customEmbed.AuthorUrl = utils.NilIfZero(e.Author.Url)
+		customEmbed.AuthorIconUrl = utils.NilIfZero(e.Author.IconUrl)
+	}
+
+	if e.Image != nil {
+		customEmbed.ImageUrl = utils.NilIfZero(e.Image.Url)
+	}
+
+	if e.Thumbnail != nil {
+		customEmbed.ThumbnailUrl = utils.NilIfZero(e.Thumbnail.Url)
+	}
+
+	if e.Footer != nil {
+		customEmbed.FooterText = utils.NilIfZero(e.Footer.Text)
+		customEmbed.FooterIconUrl = utils.NilIfZero(e.Footer.IconUrl)
+	}
+
+	fields := make([]database.EmbedField, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = database.EmbedField{
+			Name:   field.Name,
+			Value:  field.Value,
+			Inline: field.Inline,
+		}
+	}
+
+	// Nothing would be displayed
+	if customEmbed.Title == nil && customEmbed.Description == nil && customEmbed.ImageUrl == nil &&
+		customEmbed.AuthorName == nil && len(fields) == 0 {
+		return nil
+	}
+
+	return &database.CustomEmbedWithFields{
+		CustomEmbed: &customEmbed,
+		Fields:      fields,
+	}
+}
+
+// SaveTag creates or updates a tag, recording the new state in the tag's version history. If the tag already
+// existed without any history, e.g. it was created on the dashboard, its current state is recorded first so that
+// it can still be rolled back to. Returns the new version number.
+func SaveTag(tag database.Tag, editedBy uint64) (int, error) {
+	existing, exists, err := dbclient.Client.Tag.Get(tag.GuildId, tag.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	if exists {
+		hasHistory, err := dbclient.Tables.TagVersions.HasAny(tag.GuildId, tag.Id)
+		if err != nil {
+			return 0, err
+		}
+
+		if !hasHistory {
+			if _, err := createTagVersion(existing, nil); err != nil {
+				return 0, err
+			}
+		}
+
+		tag.UseGuildCommand = existing.UseGuildCommand
+	}
+
+	if err := dbclient.Client.Tag.Set(tag); err != nil {
+		return 0, err
+	}
+
+	return createTagVersion(tag, &editedBy)
+}
+
+func createTagVersion(tag database.Tag, editedBy *uint64) (int, error) {
+	var encodedEmbed []byte
+	if tag.Embed != nil {
+		var err error
+		if encodedEmbed, err = json.Marshal(tag.Embed); err != nil {
+			return 0, err
+		}
+	}
+
+	return dbclient.Tables.TagVersions.Create(tag.GuildId, tag.Id, tag.Content, encodedEmbed, editedBy)
+}
+
+// TagFromVersion rebuilds the tag as it was at the given version
+func TagFromVersion(guildId uint64, tagId string, version tables.TagVersion) (database.Tag, error) {
+	tag := database.Tag{
+		Id:      tagId,
+		GuildId: guildId,
+		Content: version.Content,
+	}
+
+	if version.Embed != nil {
+		var tagEmbed database.CustomEmbedWithFields
+		if err := json.Unmarshal(version.Embed, &tagEmbed); err != nil {
+			return database.Tag{}, err
+		}
+
+		tag.Embed = &tagEmbed
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,171 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/guild/emoji"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const ticketSearchPageSize = 10
+
+func TranscriptUrl(guildId uint64, ticketId int) string {
+	return fmt.Sprintf("https://panel.ticketsbot.net/manage/%d/transcripts/view/%d", guildId, ticketId)
+}
+
+// recordSearchableDetails stores the ticket's subject and form answers, which are otherwise only kept in the welcome
+// message
+func recordSearchableDetails(guildId uint64, ticketId int, subject string, formData map[database.FormInput]string) error {
+	if err := dbclient.Tables.TicketSubjects.Set(guildId, ticketId, subject); err != nil {
+		return err
+	}
+
+	if len(formData) == 0 {
+		return nil
+	}
+
+	answers := make([]tables.TicketFormAnswer, 0, len(formData))
+	for input, answer := range formData {
+		if answer == "" {
+			continue
+		}
+
+		answers = append(answers, tables.TicketFormAnswer{
+			FormInputId: input.Id,
+			Label:       input.Label,
+			Answer:      answer,
+		})
+	}
+
+	return dbclient.Tables.TicketFormAnswers.Create(guildId, ticketId, answers)
+}
+
+var ticketSearchCustomIdPattern = regexp.MustCompile(`^ticket_search_(\d+)_(\d+)$`)
+
+func TicketSearchCustomId(searchId, page int) string {
+	return fmt.Sprintf("ticket_search_%d_%d", searchId, page)
+}
+
+func ParseTicketSearchCustomId(customId string) (searchId, page int, ok bool) {
+	groups := ticketSearchCustomIdPattern.FindStringSubmatch(customId)
+	if len(groups) < 3 {
+		return
+	}
+
+	// Errors are impossible
+	searchId, _ = strconv.Atoi(groups[1])
+	page, _ = strconv.Atoi(groups[2])
+
+	return searchId, page, true
+}
+
+// BuildTicketSearchMessage returns the embed for the given page of results, and whether there is a page after it
+func BuildTicketSearchMessage(ctx registry.CommandContext, filter tables.TicketSearchFilter, page int) (*embed.Embed, bool, error) {
+	// Fetch an extra result to find out whether there is another page
+	results, err := dbclient.Tables.TicketSearch.Search(ctx.GuildId(), filter, page*ticketSearchPageSize, ticketSearchPageSize+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasNext := len(results) > ticketSearchPageSize
+	if hasNext {
+		results = results[:ticketSearchPageSize]
+	}
+
+	var description string
+	if len(results) == 0 {
+		description = "No tickets matched your search"
+	} else {
+		entries := make([]string, len(results))
+		for i, result := range results {
+			entries[i] = formatTicketSearchResult(ctx, result)
+		}
+
+		description = strings.Join(entries, "\n\n")
+	}
+
+	self, _ := ctx.Worker().Self()
+
+	msgEmbed := embed.NewEmbed().
+		SetColor(ctx.GetColour(customisation.Green)).
+		SetTitle("Ticket Search").
+		SetDescription(utils.StringMax(description, 4096)).
+		SetFooter(fmt.Sprintf("Page %d", page+1), self.AvatarUrl(256))
+
+	return msgEmbed, hasNext, nil
+}
+
+func formatTicketSearchResult(ctx registry.CommandContext, result tables.TicketSearchResult) string {
+	parts := []string{fmt.Sprintf("`#%d`", result.Id)}
+
+	if result.Open {
+		parts = append(parts, "**Open**")
+	} else {
+		parts = append(parts, "**Closed**")
+	}
+
+	if result.PanelTitle != nil {
+		parts = append(parts, utils.StringMax(*result.PanelTitle, 40, "..."))
+	}
+
+	parts = append(parts,
+		fmt.Sprintf("<@%d>", result.OpenerId),
+		message.BuildTimestamp(result.OpenTime, message.TimestampStyleShortDate),
+	)
+
+	if result.ClaimerId != nil {
+		parts = append(parts, fmt.Sprintf("claimed by <@%d>", *result.ClaimerId))
+	}
+
+	// Open tickets link to their channel, closed tickets to their transcript
+	if result.Open && result.ChannelId != nil {
+		parts = append(parts, fmt.Sprintf("<#%d>", *result.ChannelId))
+	} else if !result.Open {
+		parts = append(parts, fmt.Sprintf("[Transcript](%s)", TranscriptUrl(ctx.GuildId(), result.Id)))
+	}
+
+	line := strings.Join(parts, " • ")
+
+	if result.Subject != nil {
+		line += fmt.Sprintf("\n> %s", utils.StringMax(*result.Subject, 100, "..."))
+	}
+
+	if result.CloseReason != nil {
+		line += fmt.Sprintf("\n> Closed: %s", utils.StringMax(*result.CloseReason, 100, "..."))
+	}
+
+	return line
+}
+
+func BuildTicketSearchComponents(searchId, page int, hasNext bool) []component.Component {
+	return []component.Component{
+		component.BuildActionRow(
+			component.BuildButton(component.Button{
+				CustomId: TicketSearchCustomId(searchId, page-1),
+				Style:    component.ButtonStylePrimary,
+				Emoji: &emoji.Emoji{
+					Name: "◀️",
+				},
+				Disabled: page <= 0,
+			}),
+			component.BuildButton(component.Button{
+				CustomId: TicketSearchCustomId(searchId, page+1),
+				Style:    component.ButtonStylePrimary,
+				Emoji: &emoji.Emoji{
+					Name: "▶️",
+				},
+				Disabled: !hasNext,
+			}),
+		),
+	}
+}
@@ -0,0 +1,288 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/permission"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/objects/member"
+	"github.com/rxdn/gdl/rest"
+	"strings"
+	"time"
+)
+
+const TransferRequestTimeout = time.Hour * 12
+
+// RecordDirectTransfer adds a transfer that was made without a request to the ticket's history
+func RecordDirectTransfer(ticket database.Ticket, requesterId, targetId uint64) error {
+	now := time.Now()
+
+	_, err := dbclient.Tables.TransferRequests.Create(tables.TransferRequest{
+		GuildId:      ticket.GuildId,
+		TicketId:     ticket.Id,
+		RequesterId:  requesterId,
+		TargetUserId: &targetId,
+		Direct:       true,
+		Status:       tables.TransferRequestAccepted,
+		ResolvedBy:   &targetId,
+		ExpiresAt:    now,
+		ResolvedAt:   &now,
+	})
+
+	return err
+}
+
+// SendTransferRequest sends the request with its accept and decline buttons. Requests to a staff member are sent to
+// them in DMs, falling back to pinging them in the ticket, while requests to a team ping the team in the ticket.
+func SendTransferRequest(ctx registry.CommandContext, ticket database.Ticket, request tables.TransferRequest) error {
+	msgEmbed, err := BuildTransferRequestEmbed(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	data := rest.CreateMessageData{
+		Embeds:     utils.Slice(msgEmbed),
+		Components: utils.Slice(BuildTransferRequestButtons(ctx, request.Id)),
+	}
+
+	if request.TargetUserId != nil {
+		if dmChannel, err := ctx.Worker().CreateDM(*request.TargetUserId); err == nil {
+			if msg, err := ctx.Worker().CreateMessageComplex(dmChannel.Id, data); err == nil {
+				return dbclient.Tables.TransferRequests.SetMessage(request.Id, dmChannel.Id, msg.Id)
+			}
+		}
+
+		// The user may have DMs disabled
+		data.Content = fmt.Sprintf("<@%d>", *request.TargetUserId)
+		data.AllowedMentions = message.AllowedMention{
+			Parse: []message.AllowedMentionType{
+				message.USERS,
+			},
+		}
+	} else if request.TargetTeamId != nil {
+		content, err := getTeamMentions(*request.TargetTeamId)
+		if err != nil {
+			return err
+		}
+
+		data.Content = content
+		data.AllowedMentions = message.AllowedMention{
+			Parse: []message.AllowedMentionType{
+				message.USERS,
+				message.ROLES,
+			},
+		}
+	}
+
+	msg, err := ctx.Worker().CreateMessageComplex(*ticket.ChannelId, data)
+	if err != nil {
+		return err
+	}
+
+	return dbclient.Tables.TransferRequests.SetMessage(request.Id, *ticket.ChannelId, msg.Id)
+}
+
+func getTeamMentions(teamId int) (string, error) {
+	users, err := dbclient.Client.SupportTeamMembers.Get(teamId)
+	if err != nil {
+		return "", err
+	}
+
+	roles, err := dbclient.Client.SupportTeamRoles.Get(teamId)
+	if err != nil {
+		return "", err
+	}
+
+	mentions := make([]string, 0, len(users)+len(roles))
+	for _, roleId := range roles {
+		mentions = append(mentions, fmt.Sprintf("<@&%d>", roleId))
+	}
+
+	for _, userId := range users {
+		mentions = append(mentions, fmt.Sprintf("<@%d>", userId))
+	}
+
+	return utils.StringMax(strings.Join(mentions, " "), 2000), nil
+}
+
+// CanAcceptTransfer returns whether the member is the target of the request, or is on the team it targets
+func CanAcceptTransfer(worker *worker.Context, request tables.TransferRequest, member member.Member) (bool, error) {
+	permissionLevel, err := permission.GetPermissionLevel(utils.ToRetriever(worker), member, request.GuildId)
+	if err != nil {
+		return false, err
+	}
+
+	if permissionLevel < permission.Support {
+		return false, nil
+	}
+
+	if request.TargetUserId != nil {
+		return *request.TargetUserId == member.User.Id, nil
+	}
+
+	if request.TargetTeamId == nil {
+		return false, nil
+	}
+
+	users, err := dbclient.Client.SupportTeamMembers.Get(*request.TargetTeamId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userId := range users {
+		if userId == member.User.Id {
+			return true, nil
+		}
+	}
+
+	roles, err := dbclient.Client.SupportTeamRoles.Get(*request.TargetTeamId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, roleId := range roles {
+		for _, memberRoleId := range member.Roles {
+			if roleId == memberRoleId {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func formatTransferTarget(request tables.TransferRequest) (string, error) {
+	if request.TargetUserId != nil {
+		return fmt.Sprintf("<@%d>", *request.TargetUserId), nil
+	}
+
+	if request.TargetTeamId != nil {
+		team, ok, err := dbclient.Client.SupportTeam.GetById(request.GuildId, *request.TargetTeamId)
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			return fmt.Sprintf("the **%s** team", team.Name), nil
+		}
+	}
+
+	return "a deleted team", nil
+}
+
+func formatTransferStatus(request tables.TransferRequest) string {
+	switch request.Status {
+	case tables.TransferRequestPending:
+		return fmt.Sprintf("Pending, expires %s", message.BuildTimestamp(request.ExpiresAt, message.TimestampStyleRelativeTime))
+	case tables.TransferRequestAccepted:
+		if request.Direct {
+			return "Transferred"
+		}
+
+		return fmt.Sprintf("Accepted by <@%d>", *request.ResolvedBy)
+	case tables.TransferRequestDeclined:
+		return fmt.Sprintf("Declined by <@%d>", *request.ResolvedBy)
+	case tables.TransferRequestExpired:
+		return "Expired"
+	case tables.TransferRequestCancelled:
+		return "Cancelled"
+	default:
+		return "Unknown"
+	}
+}
+
+func BuildTransferRequestEmbed(ctx registry.CommandContext, request tables.TransferRequest) (*embed.Embed, error) {
+	target, err := formatTransferTarget(request)
+	if err != nil {
+		return nil, err
+	}
+
+	colour := customisation.Green
+	if request.Status != tables.TransferRequestPending && request.Status != tables.TransferRequestAccepted {
+		colour = customisation.Red
+	}
+
+	fields := utils.Slice(embed.EmbedField{
+		Name:   "Status",
+		Value:  formatTransferStatus(request),
+		Inline: false,
+	})
+
+	return utils.BuildEmbed(ctx, colour, i18n.TitleTransferRequest, i18n.MessageTransferRequest, fields, request.RequesterId, request.TicketId, target), nil
+}
+
+func BuildTransferRequestButtons(ctx registry.CommandContext, requestId int) component.Component {
+	return component.BuildActionRow(
+		component.BuildButton(component.Button{
+			Label:    ctx.GetMessage(i18n.MessageTransferAcceptButton),
+			CustomId: fmt.Sprintf("transfer_accept_%d", requestId),
+			Style:    component.ButtonStyleSuccess,
+		}),
+		component.BuildButton(component.Button{
+			Label:    ctx.GetMessage(i18n.MessageTransferDeclineButton),
+			CustomId: fmt.Sprintf("transfer_decline_%d", requestId),
+			Style:    component.ButtonStyleDanger,
+		}),
+	)
+}
+
+// BuildTransferHistoryField lists the ticket's transfers, keeping the most recent if there are too many to fit
+func BuildTransferHistoryField(guildId uint64, ticketId int) (embed.EmbedField, error) {
+	requests, err := dbclient.Tables.TransferRequests.GetByTicket(guildId, ticketId)
+	if err != nil {
+		return embed.EmbedField{}, err
+	}
+
+	var lines []string
+	length := 0
+	for i := len(requests) - 1; i >= 0; i-- {
+		request := requests[i]
+
+		target, err := formatTransferTarget(request)
+		if err != nil {
+			return embed.EmbedField{}, err
+		}
+
+		line := fmt.Sprintf("%s <@%d> → %s: %s",
+			message.BuildTimestamp(request.CreatedAt, message.TimestampStyleShortDateTime),
+			request.RequesterId,
+			target,
+			formatTransferStatus(request),
+		)
+
+		// Embed field values are limited to 1024 characters
+		if length+len(line)+1 > 1024 {
+			break
+		}
+
+		length += len(line) + 1
+		lines = append([]string{line}, lines...)
+	}
+
+	return embed.EmbedField{
+		Name:   "Transfer History",
+		Value:  strings.Join(lines, "\n"),
+		Inline: false,
+	}, nil
+}
+
+// SendTransferNotice posts the outcome of a transfer in the ticket, along with the ticket's transfer history
+func SendTransferNotice(ctx registry.CommandContext, ticket database.Ticket, colour customisation.Colour, title, content i18n.MessageId, format ...interface{}) error {
+	historyField, err := BuildTransferHistoryField(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	msgEmbed := utils.BuildEmbed(ctx, colour, title, content, utils.Slice(historyField), format...)
+	_, err = ctx.Worker().CreateMessageEmbed(*ticket.ChannelId, msgEmbed)
+	return err
+}
@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"errors"
+	"github.com/TicketsBot/database"
+	"github.com/TicketsBot/worker/bot/command/registry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+)
+
+// UnclaimTicket removes the ticket's claim, restoring the permissions it had before it was claimed
+func UnclaimTicket(ctx registry.CommandContext, ticket database.Ticket) error {
+	if ticket.ChannelId == nil {
+		return errors.New("channel ID is nil")
+	}
+
+	claimer, err := dbclient.Client.TicketClaims.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return err
+	}
+
+	// Set to unclaimed in DB
+	if err := dbclient.Client.TicketClaims.Delete(ticket.GuildId, ticket.Id); err != nil {
+		return err
+	}
+
+	if err := dbclient.Tables.ClaimActivity.Delete(ticket.GuildId, ticket.Id); err != nil {
+		return err
+	}
+
+	RequestQueueBoardUpdate(ctx)
+
+	// get panel
+	var panel *database.Panel
+	if ticket.PanelId != nil {
+		derefPanel, err := dbclient.Client.Panel.GetById(*ticket.PanelId)
+		if err != nil {
+			return err
+		}
+
+		if derefPanel.PanelId != 0 {
+			panel = &derefPanel
+		}
+	}
+
+	staffOnly, err := isStaffOnly(ticket)
+	if err != nil {
+		return err
+	}
+
+	previous := OverwriteState{Panel: panel, Claimer: claimer, StaffOnly: staffOnly}
+	current := OverwriteState{Panel: panel, StaffOnly: staffOnly}
+	return ReconcileTicketOverwrites(ctx.Worker(), ticket, previous, current)
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
 	"github.com/TicketsBot/worker/bot/integrations"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/channel/embed"
+	"github.com/rxdn/gdl/objects/channel/message"
 	"github.com/rxdn/gdl/objects/guild/emoji"
 	"github.com/rxdn/gdl/objects/interaction/component"
 	"github.com/rxdn/gdl/rest"
@@ -28,7 +30,7 @@ import (
 )
 
 // returns msg id
-func SendWelcomeMessage(ctx registry.CommandContext, ticket database.Ticket, subject string, panel *database.Panel, formData map[database.FormInput]string) (uint64, error) {
+func SendWelcomeMessage(ctx registry.CommandContext, ticket database.Ticket, subject string, panel *database.Panel, formData map[database.FormInput]string, outOfHours *OutOfHours) (uint64, error) {
 	settings, err := dbclient.Client.Settings.Get(ticket.GuildId)
 	if err != nil {
 		return 0, err
@@ -59,6 +61,23 @@ func SendWelcomeMessage(ctx registry.CommandContext, ticket database.Ticket, sub
 		embeds = append(embeds, formAnswersEmbed)
 	}
 
+	// Let the user know that it may take longer than usual to get a response
+	if outOfHours != nil {
+		var notice string
+		if outOfHours.NextOpen == nil {
+			notice = ctx.GetMessage(i18n.MessageOutOfHoursNoticeNoSchedule)
+		} else {
+			notice = ctx.GetMessage(i18n.MessageOutOfHoursNotice, message.BuildTimestamp(*outOfHours.NextOpen, message.TimestampStyleShortDateTime))
+		}
+
+		outOfHoursEmbed := embed.NewEmbed().
+			SetColor(ctx.GetColour(customisation.Orange)).
+			SetTitle(ctx.GetMessage(i18n.TitleOutOfHours)).
+			SetDescription(notice)
+
+		embeds = append(embeds, outOfHoursEmbed)
+	}
+
 	buttons := []component.Component{
 		component.BuildButton(component.Button{
 			Label:    ctx.GetMessage(i18n.TitleClose),
@@ -104,6 +123,8 @@ func SendWelcomeMessage(ctx registry.CommandContext, ticket database.Ticket, sub
 }
 
 func BuildWelcomeMessageEmbed(ctx registry.CommandContext, ticket database.Ticket, subject string, panel *database.Panel) (*embed.Embed, error) {
+	var e *embed.Embed
+
 	// Send welcome message
 	if panel == nil || panel.WelcomeMessageEmbed == nil {
 		welcomeMessage, err := dbclient.Client.WelcomeMessages.Get(ticket.GuildId)
@@ -118,7 +139,7 @@ func BuildWelcomeMessageEmbed(ctx registry.CommandContext, ticket database.Ticke
 		// Replace variables
 		welcomeMessage = DoPlaceholderSubstitutions(welcomeMessage, ctx.Worker(), ticket)
 
-		return utils.BuildEmbedRaw(ctx.GetColour(customisation.Green), subject, welcomeMessage, nil, ctx.PremiumTier()), nil
+		e = utils.BuildEmbedRaw(ctx.GetColour(customisation.Green), subject, welcomeMessage, nil, ctx.PremiumTier())
 	} else {
 		data, err := dbclient.Client.Embeds.GetEmbed(*panel.WelcomeMessageEmbed)
 		if err != nil {
@@ -130,9 +151,20 @@ func BuildWelcomeMessageEmbed(ctx registry.CommandContext, ticket database.Ticke
 			return nil, err
 		}
 
-		e := BuildCustomEmbed(ctx.Worker(), ticket, data, fields, ctx.PremiumTier() == premium.None)
-		return e, nil
+		e = BuildCustomEmbed(ctx.Worker(), ticket, data, fields, ctx.PremiumTier() == premium.None)
 	}
+
+	// Tickets only have a priority other than normal if a routing rule gave them one
+	priority, err := dbclient.Tables.TicketPriority.Get(ticket.GuildId, ticket.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if priority != tables.PriorityNormal {
+		e.AddField("Priority", TicketPriorityNames[priority], true)
+	}
+
+	return e, nil
 }
 
 func DoPlaceholderSubstitutions(message string, ctx *worker.Context, ticket database.Ticket) string {
@@ -250,7 +282,7 @@ func DoPlaceholderSubstitutions(message string, ctx *worker.Context, ticket data
 			integrationSecrets := secrets[integration.Id]
 
 			group.Go(func() error {
-				response, err := integrations.Fetch(integration, ticket, integrationSecrets, headers[integration.Id], placeholderMap[integration.Id])
+				response, err := integrations.Fetch(ctx.TraceContext(), integration, ticket, integrationSecrets, headers[integration.Id], placeholderMap[integration.Id])
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/rxdn/gdl/rest/request"
+	"net"
+	"strconv"
+)
+
+// ClassifyError returns a low cardinality label describing the source of an error
+func ClassifyError(err error) string {
+	var restError request.RestError
+	if errors.As(err, &restError) {
+		return "rest_" + strconv.Itoa(restError.StatusCode)
+	}
+
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) || errors.Is(err, pgx.ErrNoRows) {
+		return "database"
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) {
+		if netError.Timeout() {
+			return "timeout"
+		}
+
+		return "network"
+	}
+
+	return "other"
+}
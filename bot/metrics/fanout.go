@@ -0,0 +1,24 @@
+package metrics
+
+// FanoutBackend sends every metric to each of its backends
+type FanoutBackend []Backend
+
+var _ Backend = (*FanoutBackend)(nil)
+
+func (f FanoutBackend) Count(name string, value int, tags Tags) {
+	for _, backend := range f {
+		backend.Count(name, value, tags)
+	}
+}
+
+func (f FanoutBackend) Gauge(name string, value float64, tags Tags) {
+	for _, backend := range f {
+		backend.Gauge(name, value, tags)
+	}
+}
+
+func (f FanoutBackend) Histogram(name string, value float64, tags Tags) {
+	for _, backend := range f {
+		backend.Histogram(name, value, tags)
+	}
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tags are sent as labels to Prometheus, and as tags to statsd servers that support them. Values should have a low
+// cardinality: guild IDs should be passed through GuildTag.
+type Tags map[string]string
+
+type Backend interface {
+	Count(name string, value int, tags Tags)
+	Gauge(name string, value float64, tags Tags)
+	Histogram(name string, value float64, tags Tags)
+}
+
+// Client discards everything until Init is called
+var Client Backend = NoopBackend{}
+
+// Init creates the backends listed in the config. A backend that fails to start is skipped, so that the others are
+// still recorded.
+func Init() {
+	var backends []Backend
+	for _, name := range config.Conf.Metrics.Backends {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "prometheus":
+			backends = append(backends, NewPrometheusBackend(config.Conf.Prometheus.Address))
+		case "statsd":
+			backend, err := NewStatsdBackend(config.Conf.Statsd.Address, config.Conf.Statsd.Prefix, config.Conf.Statsd.TagsFormat)
+			if err != nil {
+				sentry.Error(err)
+				continue
+			}
+
+			go backend.StartDaemon()
+			backends = append(backends, backend)
+		case "none", "":
+		default:
+			sentry.Error(fmt.Errorf("unknown metrics backend: %s", name))
+		}
+	}
+
+	switch len(backends) {
+	case 0:
+		Client = NoopBackend{}
+	case 1:
+		Client = backends[0]
+	default:
+		Client = FanoutBackend(backends)
+	}
+}
+
+func Increment(name string, tags Tags) {
+	Client.Count(name, 1, tags)
+}
+
+func Count(name string, value int, tags Tags) {
+	Client.Count(name, value, tags)
+}
+
+func Gauge(name string, value float64, tags Tags) {
+	Client.Gauge(name, value, tags)
+}
+
+func Histogram(name string, value float64, tags Tags) {
+	Client.Histogram(name, value, tags)
+}
+
+// Timing records a duration in seconds
+func Timing(name string, duration time.Duration, tags Tags) {
+	Client.Histogram(name, duration.Seconds(), tags)
+}
+
+func LogError(err error) {
+	Increment(Errors, Tags{"type": ClassifyError(err)})
+}
+
+// GuildTag returns an empty tag value unless per-guild tags are enabled, as with a large number of guilds, the number
+// of series would be unmanageable. Prometheus treats an empty label value as the label being absent.
+func GuildTag(guildId uint64) string {
+	if config.Conf.Metrics.GuildTags {
+		return strconv.FormatUint(guildId, 10)
+	} else {
+		return ""
+	}
+}
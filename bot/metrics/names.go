@@ -0,0 +1,31 @@
+package metrics
+
+// Counters
+const (
+	Messages            = "messages"
+	Events              = "events"
+	Joins               = "joins"
+	Leaves              = "leaves"
+	AutoClose           = "autoclose"
+	DirectMessages      = "direct_message"
+	TicketsCreated      = "tickets_created"
+	Commands            = "commands"
+	IntegrationRequests = "integration_requests"
+	Errors              = "errors"
+)
+
+// Histograms, all in seconds
+const (
+	CommandDuration   = "command_duration_seconds"
+	ComponentDuration = "component_duration_seconds"
+	RestDuration      = "rest_duration_seconds"
+	DatabaseDuration  = "database_duration_seconds"
+)
+
+// Keys that statsd has always received, which differ from the names above. See legacyStatsdKeys.
+const (
+	legacyKeyTickets       = "tickets"
+	legacyKeyOpenCommand   = "open_command"
+	legacyKeySlashCommands = "slash_commands"
+	legacyKeyRest          = "rest"
+)
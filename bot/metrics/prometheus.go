@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"github.com/TicketsBot/worker/bot/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"sync"
+)
+
+// Buckets for histograms that don't suit prometheus.DefBuckets
+var histogramBuckets = map[string][]float64{
+	DatabaseDuration: prometheus.ExponentialBuckets(0.0005, 2, 14),
+}
+
+// Label names of each metric, which Prometheus requires to be fixed. Tags that aren't listed are dropped, and missing
+// tags are sent as empty values. Metrics that aren't listed have no labels.
+var metricLabels = map[string][]string{
+	Events:              {"event"},
+	AutoClose:           {"reason"},
+	DirectMessages:      {"type"},
+	TicketsCreated:      {"source", "guild_id"},
+	Commands:            {"type", "command", "guild_id"},
+	IntegrationRequests: {"integration_id", "guild_id"},
+	Errors:              {"type"},
+	CommandDuration:     {"command"},
+	ComponentDuration:   {"type", "handler"},
+	RestDuration:        {"method", "route", "status"},
+	DatabaseDuration:    {"operation"},
+}
+
+// PrometheusBackend registers each metric the first time that it is recorded, with the labels from metricLabels
+type PrometheusBackend struct {
+	mu         sync.Mutex
+	counters   map[string]*prometheus.CounterVec
+	gauges     map[string]*prometheus.GaugeVec
+	histograms map[string]*prometheus.HistogramVec
+}
+
+var _ Backend = (*PrometheusBackend)(nil)
+
+func NewPrometheusBackend(serverAddr string) *PrometheusBackend {
+	StartPrometheusServer(serverAddr)
+
+	return &PrometheusBackend{
+		counters:   make(map[string]*prometheus.CounterVec),
+		gauges:     make(map[string]*prometheus.GaugeVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+	}
+}
+
+func StartPrometheusServer(serverAddr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := http.ListenAndServe(serverAddr, mux); err != nil {
+			logging.For("bot/metrics").WithError(err).Error("Error starting prometheus server")
+		}
+	}()
+}
+
+func (b *PrometheusBackend) Count(name string, value int, tags Tags) {
+	b.mu.Lock()
+	vec, ok := b.counters[name]
+	if !ok {
+		vec = promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tickets",
+			Subsystem: "worker",
+			Name:      name,
+		}, metricLabels[name])
+		b.counters[name] = vec
+	}
+	b.mu.Unlock()
+
+	vec.With(labelValues(name, tags)).Add(float64(value))
+}
+
+func (b *PrometheusBackend) Gauge(name string, value float64, tags Tags) {
+	b.mu.Lock()
+	vec, ok := b.gauges[name]
+	if !ok {
+		vec = promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "tickets",
+			Subsystem: "worker",
+			Name:      name,
+		}, metricLabels[name])
+		b.gauges[name] = vec
+	}
+	b.mu.Unlock()
+
+	vec.With(labelValues(name, tags)).Set(value)
+}
+
+func (b *PrometheusBackend) Histogram(name string, value float64, tags Tags) {
+	b.mu.Lock()
+	vec, ok := b.histograms[name]
+	if !ok {
+		buckets, ok := histogramBuckets[name]
+		if !ok {
+			buckets = prometheus.DefBuckets
+		}
+
+		vec = promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tickets",
+			Subsystem: "worker",
+			Name:      name,
+			Buckets:   buckets,
+		}, metricLabels[name])
+		b.histograms[name] = vec
+	}
+	b.mu.Unlock()
+
+	vec.With(labelValues(name, tags)).Observe(value)
+}
+
+func labelValues(name string, tags Tags) prometheus.Labels {
+	names := metricLabels[name]
+
+	labels := make(prometheus.Labels, len(names))
+	for _, key := range names {
+		labels[key] = tags[key]
+	}
+
+	return labels
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RestTransport records the latency and status code of each request made to Discord
+type RestTransport struct {
+	http.RoundTripper
+}
+
+func NewRestTransport(transport http.RoundTripper) *RestTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &RestTransport{
+		RoundTripper: transport,
+	}
+}
+
+func (t *RestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.RoundTripper.RoundTrip(req)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(res.StatusCode)
+	}
+
+	Timing(RestDuration, time.Since(start), Tags{
+		"method": req.Method,
+		"route":  normaliseRoute(req.URL.Path),
+		"status": status,
+	})
+	return res, err
+}
+
+var (
+	apiVersionPattern = regexp.MustCompile(`^/api/v\d+`)
+	snowflakePattern  = regexp.MustCompile(`^\d{15,}$`)
+)
+
+// normaliseRoute replaces IDs, tokens and emojis in the path, so that each route has a single label value
+func normaliseRoute(path string) string {
+	path = apiVersionPattern.ReplaceAllString(path, "")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if i > 0 && segments[i-1] == "reactions" {
+			segments[i] = ":emoji"
+			continue
+		}
+
+		// /webhooks/:id/:token and /interactions/:id/:token
+		if i > 1 && (segments[i-2] == "webhooks" || segments[i-2] == "interactions") {
+			segments[i] = ":token"
+			continue
+		}
+
+		if snowflakePattern.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
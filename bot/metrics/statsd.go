@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"go.uber.org/atomic"
+	stats "gopkg.in/alexcesaro/statsd.v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatsdBackend buffers counters, as they are incremented too often to send each one individually. Tags are only sent
+// if a tags format is configured, as plain statsd does not support them.
+type StatsdBackend struct {
+	client *stats.Client
+	buffer sync.Map // string -> *bufferedCount
+}
+
+type bufferedCount struct {
+	name  string
+	tags  []string
+	count *atomic.Int64
+}
+
+var _ Backend = (*StatsdBackend)(nil)
+
+func NewStatsdBackend(address, prefix, tagsFormat string) (*StatsdBackend, error) {
+	opts := []stats.Option{
+		stats.Address(address),
+		stats.Prefix(prefix),
+	}
+
+	switch strings.ToLower(tagsFormat) {
+	case "influxdb":
+		opts = append(opts, stats.TagsFormat(stats.InfluxDB))
+	case "datadog":
+		opts = append(opts, stats.TagsFormat(stats.Datadog))
+	}
+
+	client, err := stats.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatsdBackend{
+		client: client,
+	}, nil
+}
+
+func (b *StatsdBackend) StartDaemon() {
+	ticker := time.NewTicker(time.Second * 15)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		b.buffer.Range(func(_, value interface{}) bool {
+			buffered := value.(*bufferedCount)
+			if count := buffered.count.Swap(0); count > 0 {
+				b.withTags(buffered.tags).Count(buffered.name, count)
+			}
+
+			return true
+		})
+	}
+}
+
+func (b *StatsdBackend) Count(name string, value int, tags Tags) {
+	for _, key := range legacyStatsdKeys(name, tags) {
+		b.count(key, value, tags)
+	}
+}
+
+func (b *StatsdBackend) count(name string, value int, tags Tags) {
+	flattened := flattenTags(tags)
+	key := name + "|" + strings.Join(flattened, ",")
+
+	buffered, ok := b.buffer.Load(key)
+	if !ok {
+		buffered, _ = b.buffer.LoadOrStore(key, &bufferedCount{
+			name:  name,
+			tags:  flattened,
+			count: atomic.NewInt64(0),
+		})
+	}
+
+	buffered.(*bufferedCount).count.Add(int64(value))
+}
+
+func (b *StatsdBackend) Gauge(name string, value float64, tags Tags) {
+	b.withTags(flattenTags(tags)).Gauge(name, value)
+}
+
+func (b *StatsdBackend) Histogram(name string, value float64, tags Tags) {
+	b.withTags(flattenTags(tags)).Histogram(name, value)
+
+	// Requests to Discord used to be counted, rather than timed
+	if name == RestDuration {
+		b.count(legacyKeyRest, 1, nil)
+	}
+}
+
+// legacyStatsdKeys returns the keys that a counter is sent to statsd as. Dashboards were built on the keys that the
+// worker used before Prometheus and statsd shared metric names, so counters that were renamed are sent under their old
+// key, and those that were split by tags are also sent to the old untagged keys.
+func legacyStatsdKeys(name string, tags Tags) []string {
+	switch name {
+	case TicketsCreated:
+		if tags["source"] == "command" {
+			return []string{legacyKeyTickets, legacyKeyOpenCommand}
+		}
+
+		return []string{legacyKeyTickets}
+	case Commands:
+		if tags["type"] == "slash" {
+			return []string{Commands, legacyKeySlashCommands}
+		}
+
+		return []string{Commands}
+	default:
+		return []string{name}
+	}
+}
+
+// Clones share the parent's connection, so this is cheap
+func (b *StatsdBackend) withTags(tags []string) *stats.Client {
+	if len(tags) == 0 {
+		return b.client
+	}
+
+	return b.client.Clone(stats.Tags(tags...))
+}
+
+// flattenTags returns the tags as sorted key-value pairs, skipping empty values
+func flattenTags(tags Tags) []string {
+	keys := make([]string, 0, len(tags))
+	for key, value := range tags {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	flattened := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		flattened = append(flattened, key, tags[key])
+	}
+
+	return flattened
+}
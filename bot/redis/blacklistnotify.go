@@ -0,0 +1,16 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/utils"
+	"time"
+)
+
+const blacklistNotifyInterval = time.Hour
+
+// TakeBlacklistNotification returns whether the user should be sent a DM explaining their blacklist, so that repeated
+// attempts to open a ticket don't result in repeated DMs
+func TakeBlacklistNotification(guildId, userId uint64) (bool, error) {
+	key := fmt.Sprintf("tickets:blacklistnotify:%d:%d", guildId, userId)
+	return Client.SetNX(utils.DefaultContext(), key, 1, blacklistNotifyInterval).Result()
+}
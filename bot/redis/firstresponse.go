@@ -0,0 +1,29 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/utils"
+	"time"
+)
+
+// firstResponseExpiry only needs to outlive most tickets, as a missing key just means that the response time is
+// calculated again, and then discarded by the database
+const firstResponseExpiry = time.Hour * 24 * 30
+
+// HasFirstResponse returns whether a first response has already been recorded for the ticket
+func HasFirstResponse(guildId uint64, ticketId int) (bool, error) {
+	key := fmt.Sprintf("tickets:firstresponse:%d:%d", guildId, ticketId)
+
+	exists, err := Client.Exists(utils.DefaultContext(), key).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return exists > 0, nil
+}
+
+// SetFirstResponse should be called once the ticket's first response has been recorded
+func SetFirstResponse(guildId uint64, ticketId int) error {
+	key := fmt.Sprintf("tickets:firstresponse:%d:%d", guildId, ticketId)
+	return Client.Set(utils.DefaultContext(), key, 1, firstResponseExpiry).Err()
+}
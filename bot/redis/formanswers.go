@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/TicketsBot/common/utils"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+var FormAnswersExpiry = time.Minute * 15
+
+// StoreFormAnswers keeps a user's answers to a form, so that they can be restored if the submission is rejected
+func StoreFormAnswers(guildId, userId uint64, panelId int, answers map[string]string) error {
+	key := fmt.Sprintf("form:answers:%d:%d:%d", guildId, userId, panelId)
+
+	marshalled, err := json.Marshal(answers)
+	if err != nil {
+		return err
+	}
+
+	return Client.Set(utils.DefaultContext(), key, string(marshalled), FormAnswersExpiry).Err()
+}
+
+// GetFormAnswers returns a map of input custom ID -> answer, or nil if the answers have expired
+func GetFormAnswers(guildId, userId uint64, panelId int) (map[string]string, error) {
+	key := fmt.Sprintf("form:answers:%d:%d:%d", guildId, userId, panelId)
+
+	res, err := Client.Get(utils.DefaultContext(), key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var answers map[string]string
+	if err := json.Unmarshal([]byte(res), &answers); err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+func DeleteFormAnswers(guildId, userId uint64, panelId int) error {
+	key := fmt.Sprintf("form:answers:%d:%d:%d", guildId, userId, panelId)
+	return Client.Del(utils.DefaultContext(), key).Err()
+}
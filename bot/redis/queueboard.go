@@ -0,0 +1,23 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/TicketsBot/common/utils"
+	"time"
+)
+
+// queueBoardUpdateTimeout is how long an update stays scheduled if the worker that scheduled it dies before releasing it
+const queueBoardUpdateTimeout = time.Minute
+
+// TakeQueueBoardUpdate returns whether the caller should schedule an update of the guild's queue board, so that a burst
+// of ticket activity across workers results in a single edit
+func TakeQueueBoardUpdate(guildId uint64) (bool, error) {
+	key := fmt.Sprintf("tickets:queueboard:%d", guildId)
+	return Client.SetNX(utils.DefaultContext(), key, 1, queueBoardUpdateTimeout).Result()
+}
+
+// ReleaseQueueBoardUpdate allows further activity to schedule another update
+func ReleaseQueueBoardUpdate(guildId uint64) error {
+	key := fmt.Sprintf("tickets:queueboard:%d", guildId)
+	return Client.Del(utils.DefaultContext(), key).Err()
+}
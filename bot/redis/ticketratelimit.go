@@ -31,13 +31,10 @@ end
 return success
 `)
 
-var TicketOpenLimit = 10
-var TicketOpenLimitInterval = time.Second * 30
-
-func TakeTicketRateLimitToken(client *redis.Client, guildId uint64) (bool, error) {
+func TakeTicketRateLimitToken(client *redis.Client, guildId uint64, limit int, interval time.Duration) (bool, error) {
 	key := fmt.Sprintf("tickets:openratelimit:%d", guildId)
 
-	res, err := script.Run(utils.DefaultContext(), client, []string{key}, TicketOpenLimit, TicketOpenLimitInterval.Seconds()).Result()
+	res, err := script.Run(utils.DefaultContext(), client, []string{key}, limit, int(interval.Seconds())).Result()
 	if err != nil {
 		return false, err
 	}
@@ -49,3 +46,57 @@ func TakeTicketRateLimitToken(client *redis.Client, guildId uint64) (bool, error
 
 	return i == 1, nil
 }
+
+// ReserveUserOpenCooldown starts the user's cooldown in the guild, unless it has already started, in which case it
+// returns false and how long remains. Reserving before the ticket is opened stops several opens at once all passing.
+func ReserveUserOpenCooldown(client *redis.Client, guildId, userId uint64, cooldown time.Duration) (bool, time.Duration, error) {
+	return reserveCooldown(client, userOpenCooldownKey(guildId, userId), cooldown)
+}
+
+// ReleaseUserOpenCooldown ends a cooldown started by ReserveUserOpenCooldown, if opening the ticket failed
+func ReleaseUserOpenCooldown(client *redis.Client, guildId, userId uint64) error {
+	return client.Del(utils.DefaultContext(), userOpenCooldownKey(guildId, userId)).Err()
+}
+
+// ReservePanelOpenCooldown starts the user's cooldown for the panel, unless it has already started, in which case it
+// returns false and how long remains
+func ReservePanelOpenCooldown(client *redis.Client, panelId int, userId uint64, cooldown time.Duration) (bool, time.Duration, error) {
+	return reserveCooldown(client, panelOpenCooldownKey(panelId, userId), cooldown)
+}
+
+// ReleasePanelOpenCooldown ends a cooldown started by ReservePanelOpenCooldown, if opening the ticket failed
+func ReleasePanelOpenCooldown(client *redis.Client, panelId int, userId uint64) error {
+	return client.Del(utils.DefaultContext(), panelOpenCooldownKey(panelId, userId)).Err()
+}
+
+func userOpenCooldownKey(guildId, userId uint64) string {
+	return fmt.Sprintf("tickets:opencooldown:%d:%d", guildId, userId)
+}
+
+func panelOpenCooldownKey(panelId int, userId uint64) string {
+	return fmt.Sprintf("tickets:opencooldown:panel:%d:%d", panelId, userId)
+}
+
+func reserveCooldown(client *redis.Client, key string, cooldown time.Duration) (bool, time.Duration, error) {
+	// SET NX PX, so that only one of several concurrent reservations succeeds
+	reserved, err := client.SetNX(utils.DefaultContext(), key, 1, cooldown).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if reserved {
+		return true, 0, nil
+	}
+
+	remaining, err := client.PTTL(utils.DefaultContext(), key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	// -2 if the key has expired since, -1 if it has no expiry (should be impossible)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return false, remaining, nil
+}
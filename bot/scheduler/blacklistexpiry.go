@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"github.com/TicketsBot/common/sentry"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"time"
+)
+
+const blacklistExpiryInterval = time.Minute
+
+// StartBlacklistExpiry periodically lifts blacklists that have expired. Every worker runs this, but each expired
+// entry is only lifted by one of them, as LiftExpired removes the entries and the blacklists in a single statement.
+func StartBlacklistExpiry() {
+	ticker := time.NewTicker(blacklistExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case _ = <-ticker.C:
+			if _, err := dbclient.Tables.BlacklistEntries.LiftExpired(); err != nil {
+				sentry.Error(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logging"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const claimTimeoutInterval = time.Minute * 5
+
+// StartClaimTimeouts unclaims tickets whose claimer has not replied within the guild's claim timeout. Every worker runs
+// this, but each timed out claim is only handled by one of them, as LockTimedOut locks the rows as it returns them.
+func StartClaimTimeouts() {
+	ticker := time.NewTicker(claimTimeoutInterval)
+	defer ticker.Stop()
+
+	logger := logging.For("bot/scheduler")
+
+	for {
+		select {
+		case _ = <-ticker.C:
+			timedOut, err := dbclient.Tables.ClaimActivity.LockTimedOut()
+			if err != nil {
+				logger.WithError(err).Error("Failed to fetch timed out claims")
+				continue
+			}
+
+			for _, activity := range timedOut {
+				if err := unclaimTimedOut(activity); err != nil {
+					logger.WithError(err).WithFields(logrus.Fields{
+						"guild_id":  activity.GuildId,
+						"ticket_id": activity.TicketId,
+					}).Warn("Failed to unclaim timed out ticket")
+
+					// Retry on the next tick, rather than forgetting about the claim
+					if err := dbclient.Tables.ClaimActivity.Unlock(activity.GuildId, activity.TicketId); err != nil {
+						logger.WithError(err).Error("Failed to unlock timed out claim")
+					}
+				}
+			}
+		}
+	}
+}
+
+func unclaimTimedOut(activity tables.ClaimActivity) error {
+	ticket, err := dbclient.Client.Tickets.Get(activity.TicketId, activity.GuildId)
+	if err != nil {
+		return err
+	}
+
+	// The row would otherwise be locked again each time the lock expires
+	if !ticket.Open || ticket.ChannelId == nil {
+		return dbclient.Tables.ClaimActivity.Delete(activity.GuildId, activity.TicketId)
+	}
+
+	// The ticket may have been transferred or unclaimed since the activity was read, in which case the timeout starts
+	// again for the current claimer
+	claimer, err := dbclient.Client.TicketClaims.Get(activity.GuildId, activity.TicketId)
+	if err != nil {
+		return err
+	}
+
+	if claimer == 0 {
+		return dbclient.Tables.ClaimActivity.Delete(activity.GuildId, activity.TicketId)
+	}
+
+	if claimer != activity.ClaimerId {
+		return dbclient.Tables.ClaimActivity.Set(activity.GuildId, activity.TicketId, claimer)
+	}
+
+	worker, err := buildContext(activity.GuildId)
+	if err != nil {
+		return err
+	}
+
+	worker.AddLogFields(logrus.Fields{"guild_id": activity.GuildId, "ticket_id": activity.TicketId})
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(activity.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.NewAutoCloseContext(worker, activity.GuildId, *ticket.ChannelId, worker.BotId, premiumTier)
+
+	if err := logic.UnclaimTicket(&ctx, ticket); err != nil {
+		return err
+	}
+
+	return logic.NotifyClaimTimeout(&ctx, ticket, activity.ClaimerId, activity.TimeoutHours)
+}
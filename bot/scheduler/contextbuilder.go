@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"fmt"
+	"github.com/TicketsBot/worker"
+	"github.com/TicketsBot/worker/bot/cache"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/config"
+	"github.com/rxdn/gdl/rest/ratelimit"
+)
+
+// buildContext returns a context for the bot that is in the guild, which is the whitelabel bot if the guild has one
+func buildContext(guildId uint64) (*worker.Context, error) {
+	ctx := &worker.Context{
+		Cache: cache.Client,
+	}
+
+	whitelabelBotId, isWhitelabel, err := dbclient.Client.WhitelabelGuilds.GetBotByGuild(guildId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.IsWhitelabel = isWhitelabel
+
+	var keyPrefix string
+	if isWhitelabel {
+		res, err := dbclient.Client.Whitelabel.GetByBotId(whitelabelBotId)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Token = res.Token
+		ctx.BotId = whitelabelBotId
+		keyPrefix = fmt.Sprintf("ratelimiter:%d", whitelabelBotId)
+	} else {
+		ctx.Token = config.Conf.Discord.Token
+		ctx.BotId = config.Conf.Discord.PublicBotId
+		keyPrefix = "ratelimiter:public"
+	}
+
+	ctx.RateLimiter = ratelimit.NewRateLimiter(ratelimit.NewRedisStore(redis.Client, keyPrefix), 1)
+
+	return ctx, nil
+}
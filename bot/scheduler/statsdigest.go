@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TicketsBot/common/premium"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logging"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/rxdn/gdl/rest/request"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const statsDigestInterval = time.Minute * 10
+
+var digestFrequencies = []tables.DigestFrequency{tables.DigestFrequencyWeekly, tables.DigestFrequencyMonthly}
+
+// StartStatsDigests sends each guild's digest once the period it covers has ended. Every worker runs this, but each
+// digest is claimed by only one of them before it is sent.
+func StartStatsDigests() {
+	ticker := time.NewTicker(statsDigestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case _ = <-ticker.C:
+			for _, frequency := range digestFrequencies {
+				sendDueDigests(frequency)
+			}
+
+			// Only the current period's deliveries are needed to prevent duplicates
+			cutoff := logic.PreviousDigestPeriod(tables.DigestFrequencyMonthly, logic.DigestPeriodStart(tables.DigestFrequencyMonthly, time.Now()))
+			if err := dbclient.Tables.StatsDigestDeliveries.DeleteBefore(cutoff); err != nil {
+				logging.For("bot/scheduler").WithError(err).Error("Failed to delete old digest deliveries")
+			}
+		}
+	}
+}
+
+func sendDueDigests(frequency tables.DigestFrequency) {
+	logger := logging.For("bot/scheduler")
+
+	periodStart := logic.DigestPeriodStart(frequency, time.Now())
+
+	digests, err := dbclient.Tables.StatsDigests.GetDue(frequency, periodStart)
+	if err != nil {
+		logger.WithError(err).Error("Failed to fetch due digests")
+		return
+	}
+
+	for _, digest := range digests {
+		claimed, err := dbclient.Tables.StatsDigestDeliveries.Claim(digest.GuildId, periodStart)
+		if err != nil {
+			logger.WithError(err).WithField("guild_id", digest.GuildId).Error("Failed to claim digest")
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		if err := sendDigest(digest, periodStart); err != nil {
+			logger.WithError(err).WithField("guild_id", digest.GuildId).Warn("Failed to send digest")
+
+			// Retrying won't help if the channel has been deleted or we can't access it
+			var restError request.RestError
+			if errors.As(err, &restError) && restError.IsClientError() {
+				continue
+			}
+
+			// Allow another attempt on the next tick
+			if err := dbclient.Tables.StatsDigestDeliveries.Release(digest.GuildId, periodStart); err != nil {
+				logger.WithError(err).WithField("guild_id", digest.GuildId).Error("Failed to release digest")
+			}
+		}
+	}
+}
+
+// sendDigest summarises the period that ended at periodEnd
+func sendDigest(digest tables.StatsDigest, periodEnd time.Time) error {
+	ctx, err := buildContext(digest.GuildId)
+	if err != nil {
+		return err
+	}
+
+	ctx.AddLogFields(logrus.Fields{"guild_id": digest.GuildId})
+
+	// Statistics are a premium feature. The period is still marked as delivered, so it isn't checked again.
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(digest.GuildId, true, ctx.Token, ctx.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	if premiumTier == premium.None {
+		return nil
+	}
+
+	msgEmbed, err := logic.BuildStatsDigest(digest.GuildId, digest.Frequency, periodEnd)
+	if err != nil {
+		return err
+	}
+
+	var channelId uint64
+	if digest.ChannelId != nil {
+		channelId = *digest.ChannelId
+	} else if digest.UserId != nil {
+		dmChannel, err := ctx.CreateDM(*digest.UserId)
+		if err != nil {
+			return err
+		}
+
+		channelId = dmChannel.Id
+	} else {
+		return fmt.Errorf("digest for guild %d has no destination", digest.GuildId)
+	}
+
+	_, err = ctx.CreateMessageEmbed(channelId, msgEmbed)
+	return err
+}
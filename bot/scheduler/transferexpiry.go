@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"github.com/TicketsBot/worker/bot/command/context"
+	"github.com/TicketsBot/worker/bot/customisation"
+	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/dbclient/tables"
+	"github.com/TicketsBot/worker/bot/logging"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/utils"
+	"github.com/TicketsBot/worker/i18n"
+	"github.com/rxdn/gdl/objects/interaction/component"
+	"github.com/rxdn/gdl/rest"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const transferExpiryInterval = time.Minute
+
+// StartTransferRequestExpiry removes the buttons from transfer requests that have expired, and lets the ticket know.
+// Every worker runs this, but each request is only expired by one of them.
+func StartTransferRequestExpiry() {
+	ticker := time.NewTicker(transferExpiryInterval)
+	defer ticker.Stop()
+
+	logger := logging.For("bot/scheduler")
+
+	for {
+		select {
+		case _ = <-ticker.C:
+			expired, err := dbclient.Tables.TransferRequests.ExpirePending()
+			if err != nil {
+				logger.WithError(err).Error("Failed to expire transfer requests")
+				continue
+			}
+
+			for _, request := range expired {
+				if err := notifyTransferExpired(request); err != nil {
+					logger.WithError(err).WithFields(logrus.Fields{
+						"guild_id":   request.GuildId,
+						"request_id": request.Id,
+					}).Warn("Failed to notify expired transfer request")
+				}
+			}
+		}
+	}
+}
+
+func notifyTransferExpired(request tables.TransferRequest) error {
+	ticket, err := dbclient.Client.Tickets.Get(request.TicketId, request.GuildId)
+	if err != nil {
+		return err
+	}
+
+	if !ticket.Open || ticket.ChannelId == nil {
+		return nil
+	}
+
+	worker, err := buildContext(request.GuildId)
+	if err != nil {
+		return err
+	}
+
+	premiumTier, err := utils.PremiumClient.GetTierByGuildId(request.GuildId, true, worker.Token, worker.RateLimiter)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.NewAutoCloseContext(worker, request.GuildId, *ticket.ChannelId, worker.BotId, premiumTier)
+
+	if request.ChannelId != nil && request.MessageId != nil {
+		msgEmbed, err := logic.BuildTransferRequestEmbed(&ctx, request)
+		if err != nil {
+			return err
+		}
+
+		// The message may have been deleted, which doesn't stop the ticket being told
+		_, _ = worker.EditMessage(*request.ChannelId, *request.MessageId, rest.EditMessageData{
+			Embeds:     utils.Slice(msgEmbed),
+			Components: []component.Component{},
+		})
+	}
+
+	return logic.SendTransferNotice(&ctx, ticket, customisation.Red, i18n.TitleTransferRequest, i18n.MessageTransferExpired, request.RequesterId)
+}
@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"strconv"
+)
+
+func GuildId(guildId uint64) attribute.KeyValue {
+	return attribute.String("guild_id", strconv.FormatUint(guildId, 10))
+}
+
+func ChannelId(channelId uint64) attribute.KeyValue {
+	return attribute.String("channel_id", strconv.FormatUint(channelId, 10))
+}
+
+func UserId(userId uint64) attribute.KeyValue {
+	return attribute.String("user_id", strconv.FormatUint(userId, 10))
+}
+
+func TicketId(ticketId int) attribute.KeyValue {
+	return attribute.Int("ticket_id", ticketId)
+}
+
+func InteractionId(interactionId uint64) attribute.KeyValue {
+	return attribute.String("interaction_id", strconv.FormatUint(interactionId, 10))
+}
+
+func Command(name string) attribute.KeyValue {
+	return attribute.String("command", name)
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,3 +26,46 @@ func FormatNullableTime(duration *time.Duration) string {
 		return FormatTime(*duration)
 	}
 }
+
+var durationUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': time.Hour * 24,
+	'w': time.Hour * 24 * 7,
+}
+
+// ParseDuration parses a human-readable duration such as "30m", "12h", "7d" or "1w2d"
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var total time.Duration
+	for len(s) > 0 {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+
+		if i == 0 || i == len(s) {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		// Bound the value so that multiplying by the unit can't overflow
+		value, err := strconv.Atoi(s[:i])
+		if err != nil || value > 100000 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		unit, ok := durationUnits[s[i]]
+		if !ok {
+			return 0, fmt.Errorf("unknown duration unit %q", s[i])
+		}
+
+		total += time.Duration(value) * unit
+		s = s[i+1:]
+	}
+
+	return total, nil
+}
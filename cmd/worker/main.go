@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"github.com/TicketsBot/archiverclient"
 	"github.com/TicketsBot/common/premium"
 	"github.com/TicketsBot/common/sentry"
@@ -9,9 +8,11 @@ import (
 	"github.com/TicketsBot/worker/bot/dbclient"
 	"github.com/TicketsBot/worker/bot/integrations"
 	"github.com/TicketsBot/worker/bot/listeners/messagequeue"
-	"github.com/TicketsBot/worker/bot/metrics/prometheus"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/logging"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/TicketsBot/worker/bot/redis"
+	"github.com/TicketsBot/worker/bot/scheduler"
+	"github.com/TicketsBot/worker/bot/tracing"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/config"
 	"github.com/TicketsBot/worker/event"
@@ -23,33 +24,46 @@ import (
 )
 
 func main() {
+	config.Parse()
+
+	if err := logging.Init(); err != nil {
+		panic(err)
+	}
+
+	logger := logging.For("cmd/worker")
+
 	go func() {
-		fmt.Println(http.ListenAndServe(":6060", nil))
+		logger.Error(http.ListenAndServe(":6060", nil))
 	}()
 
-	config.Parse()
-
-	fmt.Println("Connecting to Sentry...")
+	logger.Info("Connecting to Sentry...")
 	if err := sentry.Initialise(sentry.Options{
 		Dsn:     config.Conf.SentryDsn,
 		Project: "tickets-bot",
 		Debug:   config.Conf.DebugMode != "",
 	}); err != nil {
-		fmt.Println(err.Error())
+		logger.WithError(err).Error("Failed to connect to Sentry")
 	}
 
-	fmt.Println("Connected to Sentry, connect to Redis...")
+	shutdownTracing, err := tracing.Init()
+	if err != nil {
+		sentry.Error(err)
+	} else {
+		defer shutdownTracing()
+	}
+
+	logger.Info("Connected to Sentry, connect to Redis...")
 	if err := redis.Connect(); err != nil {
 		panic(err)
 	}
 
-	fmt.Println("Connected to Redis, connect to DB...")
+	logger.Info("Connected to Redis, connect to DB...")
 	dbclient.Connect()
 
 	i18n.LoadMessages()
 	i18n.SeedCoverage()
 
-	fmt.Println("Connected to DB, connect to cache...")
+	logger.Info("Connected to DB, connect to cache...")
 	pgCache, err := cache.Connect()
 	if err != nil {
 		panic(err)
@@ -58,13 +72,13 @@ func main() {
 	cache.Client = &pgCache
 
 	// Configure HTTP proxy
-	fmt.Println("Configuring proxy...")
+	logger.Info("Configuring proxy...")
 	if config.Conf.Discord.ProxyUrl != "" {
 		request.Client.Timeout = time.Second * 30
 		request.RegisterHook(utils.ProxyHook)
 	}
 
-	fmt.Println("Retrieved command list, initialising microservice clients...")
+	logger.Info("Retrieved command list, initialising microservice clients...")
 	if config.Conf.DebugMode == "" {
 		utils.PremiumClient = premium.NewPremiumLookupClient(premium.NewPatreonClient(config.Conf.PremiumProxy.Url, config.Conf.PremiumProxy.Key), redis.Client, &pgCache, dbclient.Client)
 	} else {
@@ -76,15 +90,8 @@ func main() {
 
 	utils.ArchiverClient = archiverclient.NewArchiverClient(config.Conf.Archiver.Url, []byte(config.Conf.Archiver.AesKey))
 
-	prometheus.StartServer(config.Conf.Prometheus.Address)
-
-	statsd.Client, err = statsd.NewClient(config.Conf.Statsd.Address, config.Conf.Statsd.Prefix)
-	if err != nil {
-		sentry.Error(err)
-	} else {
-		request.RegisterHook(statsd.RestHook)
-		go statsd.Client.StartDaemon()
-	}
+	metrics.Init()
+	request.Client.Transport = metrics.NewRestTransport(request.Client.Transport)
 
 	integrations.InitIntegrations()
 
@@ -92,6 +99,11 @@ func main() {
 	go messagequeue.ListenAutoClose()
 	go messagequeue.ListenCloseRequestTimer()
 
-	fmt.Println("Listening for events...")
+	go scheduler.StartBlacklistExpiry()
+	go scheduler.StartStatsDigests()
+	go scheduler.StartClaimTimeouts()
+	go scheduler.StartTransferRequestExpiry()
+
+	logger.Info("Listening for events...")
 	event.HttpListen(redis.Client, &pgCache)
 }
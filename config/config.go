@@ -8,6 +8,11 @@ type Config struct {
 	SentryDsn string `env:"WORKER_SENTRY_DSN"`
 	DebugMode string `env:"WORKER_DEBUG"`
 
+	Logging struct {
+		Level         string   `env:"WORKER_LOG_LEVEL" envDefault:"info"`
+		PackageLevels []string `env:"WORKER_LOG_PACKAGE_LEVELS"` // e.g. bot/logic=debug,event=warn
+	}
+
 	Discord struct {
 		Token       string `env:"WORKER_PUBLIC_TOKEN"`
 		PublicBotId uint64 `env:"WORKER_PUBLIC_ID"`
@@ -64,13 +69,27 @@ type Config struct {
 		Threads  int    `env:"WORKER_REDIS_THREADS"`
 	}
 
+	Metrics struct {
+		Backends  []string `env:"WORKER_METRICS_BACKENDS" envDefault:"prometheus,statsd"`
+		GuildTags bool     `env:"WORKER_METRICS_GUILD_TAGS" envDefault:"false"`
+	}
+
 	Prometheus struct {
 		Address string `env:"PROMETHEUS_SERVER_ADDR"`
 	}
 
+	Tracing struct {
+		Enabled     bool    `env:"WORKER_TRACING_ENABLED" envDefault:"false"`
+		Endpoint    string  `env:"WORKER_TRACING_OTLP_ENDPOINT" envDefault:"localhost:4318"`
+		Insecure    bool    `env:"WORKER_TRACING_OTLP_INSECURE" envDefault:"false"`
+		ServiceName string  `env:"WORKER_TRACING_SERVICE_NAME" envDefault:"worker"`
+		SampleRatio float64 `env:"WORKER_TRACING_SAMPLE_RATIO" envDefault:"1"`
+	}
+
 	Statsd struct {
-		Address string `env:"WORKER_STATSD_ADDR"`
-		Prefix  string `env:"WORKER_STATSD_PREFIX"`
+		Address    string `env:"WORKER_STATSD_ADDR"`
+		Prefix     string `env:"WORKER_STATSD_PREFIX"`
+		TagsFormat string `env:"WORKER_STATSD_TAGS_FORMAT"` // influxdb or datadog, tags are dropped if not set
 	}
 }
 
@@ -1,9 +1,12 @@
 package worker
 
 import (
+	"context"
 	"github.com/rxdn/gdl/cache"
 	"github.com/rxdn/gdl/objects/user"
 	"github.com/rxdn/gdl/rest/ratelimit"
+	"github.com/sirupsen/logrus"
+	"sync"
 )
 
 type Context struct {
@@ -13,6 +16,11 @@ type Context struct {
 	ShardId      int
 	Cache        *cache.PgCache
 	RateLimiter  *ratelimit.Ratelimiter
+
+	traceContext context.Context
+
+	logLock   sync.RWMutex
+	logFields logrus.Fields
 }
 
 func (c *Context) Self() (user.User, error) {
@@ -9,24 +9,36 @@ import (
 	commandContext "github.com/TicketsBot/worker/bot/command/context"
 	"github.com/TicketsBot/worker/bot/command/registry"
 	"github.com/TicketsBot/worker/bot/customisation"
-	"github.com/TicketsBot/worker/bot/metrics/prometheus"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/logic"
+	"github.com/TicketsBot/worker/bot/metrics"
+	"github.com/TicketsBot/worker/bot/tracing"
 	"github.com/TicketsBot/worker/bot/utils"
 	"github.com/TicketsBot/worker/i18n"
 	"github.com/rxdn/gdl/objects/interaction"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
 	"reflect"
 	"runtime/debug"
 	"strconv"
+	"time"
 )
 
 // TODO: Command not found messages
-// (defaultDefer, error)
+// (defaultDefer, error). done is called once the command has finished executing, or straight away if it isn't run.
 func executeCommand(
 	ctx *worker.Context,
 	registry registry.Registry,
 	data interaction.ApplicationCommandInteraction,
 	responseCh chan interaction.ApplicationCommandCallbackData,
+	done func(),
 ) (bool, error) {
+	started := false
+	defer func() {
+		if !started {
+			done()
+		}
+	}()
+
 	if data.GuildId.Value == 0 {
 		responseCh <- interaction.ApplicationCommandCallbackData{
 			Content: "Commands in DMs are not currently supported. Please run this command in a server.",
@@ -103,6 +115,8 @@ func executeCommand(
 				case interaction.OptionTypeRole:
 					fallthrough
 				case interaction.OptionTypeMentionable:
+					fallthrough
+				case interaction.OptionTypeAttachment:
 					raw, ok := option.Value.(string)
 					if !ok {
 						return false, fmt.Errorf("option %s of type %d was not a string", option.Name, argument.Type)
@@ -149,13 +163,41 @@ func executeCommand(
 
 	properties := cmd.Properties()
 
+	ctx.AddLogFields(logrus.Fields{
+		"command":        data.Data.Name,
+		"interaction_id": data.Id,
+	})
+
+	// REST requests made by the command are children of its span. The worker is copied rather than modified, as it
+	// may be shared with other goroutines.
+	traceCtx, span := tracing.Start(
+		ctx.TraceContext(),
+		"command "+properties.Name,
+		tracing.Command(data.Data.Name),
+		tracing.GuildId(data.GuildId.Value),
+		tracing.ChannelId(data.ChannelId),
+		tracing.InteractionId(data.Id),
+	)
+
+	if data.Member != nil {
+		span.SetAttributes(tracing.UserId(data.Member.User.Id))
+	}
+
+	ctx = ctx.WithTraceContext(traceCtx)
+
+	started = true
 	go func() {
+		defer done()
+		defer span.End()
+
 		defer func() {
 			if r := recover(); r != nil {
-				fmt.Printf("Recovering panicking goroutine while executing command %s: %v\n", properties.Name, r)
-				debug.PrintStack()
-
-				fmt.Printf("Command: %s\nArgs: %v\nData: %v\n", cmd.Properties().Name, args, data)
+				span.SetStatus(codes.Error, fmt.Sprintf("panic: %v", r))
+				ctx.Logger().WithFields(logrus.Fields{
+					"args":  fmt.Sprintf("%v", args),
+					"data":  fmt.Sprintf("%v", data),
+					"stack": string(debug.Stack()),
+				}).Errorf("Recovered panicking goroutine while executing command %s: %v", properties.Name, r)
 			}
 		}()
 
@@ -209,6 +251,12 @@ func executeCommand(
 
 		if blacklisted {
 			interactionContext.Reply(customisation.Red, i18n.TitleBlacklisted, i18n.MessageBlacklisted)
+
+			// Explain the blacklist if the user was trying to open a ticket
+			if properties.Category == command.Tickets {
+				logic.NotifyBlacklisted(&interactionContext)
+			}
+
 			return
 		}
 
@@ -243,14 +291,15 @@ func executeCommand(
 			valueArgs[i+1] = value
 		}
 
-		// Goroutine because recording metrics is blocking
-		go func() {
-			statsd.Client.IncrementKey(statsd.KeySlashCommands)
-			statsd.Client.IncrementKey(statsd.KeyCommands)
-			prometheus.LogCommand(data.GuildId.Value, data.Data.Name)
-		}()
+		metrics.Increment(metrics.Commands, metrics.Tags{
+			"type":     "slash",
+			"command":  data.Data.Name,
+			"guild_id": metrics.GuildTag(data.GuildId.Value),
+		})
 
+		start := time.Now()
 		reflect.ValueOf(cmd.GetExecutor()).Call(valueArgs)
+		metrics.Timing(metrics.CommandDuration, time.Since(start), metrics.Tags{"command": data.Data.Name})
 	}()
 
 	return properties.DefaultEphemeral, nil
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"github.com/TicketsBot/worker"
 	"github.com/TicketsBot/worker/bot/listeners"
-	"github.com/TicketsBot/worker/bot/metrics/statsd"
+	"github.com/TicketsBot/worker/bot/metrics"
 	"github.com/rxdn/gdl/gateway/payloads"
 	"github.com/rxdn/gdl/gateway/payloads/events"
 	"reflect"
@@ -37,8 +37,7 @@ func execute(ctx *worker.Context, event []byte) error {
 		}
 	}
 
-	// Goroutine because recording metrics is blocking
-	statsd.Client.IncrementKey(statsd.KeyEvents)
+	metrics.Increment(metrics.Events, metrics.Tags{"event": payload.EventName})
 
 	return nil
 }
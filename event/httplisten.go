@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"fmt"
 	"github.com/TicketsBot/common/eventforwarding"
 	"github.com/TicketsBot/common/sentry"
@@ -10,6 +11,7 @@ import (
 	"github.com/TicketsBot/worker/bot/command"
 	cmd_manager "github.com/TicketsBot/worker/bot/command/manager"
 	"github.com/TicketsBot/worker/bot/errorcontext"
+	"github.com/TicketsBot/worker/bot/tracing"
 	"github.com/TicketsBot/worker/config"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
@@ -19,6 +21,9 @@ import (
 	"github.com/rxdn/gdl/rest"
 	"github.com/rxdn/gdl/rest/ratelimit"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	"strings"
 	"time"
 )
@@ -93,8 +98,7 @@ func eventHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Context)
 		ctx.AbortWithStatusJSON(200, successResponse)
 
 		if err := execute(workerCtx, event.Event); err != nil {
-			marshalled, _ := json.Marshal(event)
-			logrus.Warnf("error executing event: %v (payload: %s)", err, string(marshalled))
+			workerCtx.Logger().WithError(err).WithField("event", string(event.Event)).Warn("Error executing event")
 		}
 	}
 }
@@ -121,28 +125,44 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 			keyPrefix = "ratelimiter:public"
 		}
 
-		worker := &worker.Context{
+		// Don't derive from the request context, as it is cancelled once the response has been sent, whereas the
+		// interaction may still be being processed
+		traceCtx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(ctx.Request.Header))
+		traceCtx, span := tracing.Start(traceCtx, "interaction", attribute.Int("interaction_type", int(payload.InteractionType)))
+
+		// The span covers the whole interaction, so it is ended by the handler once it has finished, which may be after
+		// the response has been sent
+		endSpan := func() {
+			span.End()
+		}
+
+		worker := (&worker.Context{
 			Token:        payload.BotToken,
 			BotId:        payload.BotId,
 			IsWhitelabel: payload.IsWhitelabel,
 			Cache:        cache,
 			RateLimiter:  ratelimit.NewRateLimiter(ratelimit.NewRedisStore(redis, keyPrefix), 1),
-		}
+		}).WithTraceContext(traceCtx)
+
+		worker.AddLogFields(logrus.Fields{"interaction_type": payload.InteractionType})
+
+		logger := worker.Logger()
 
 		switch payload.InteractionType {
 		case interaction.InteractionTypeApplicationCommand:
 			var interactionData interaction.ApplicationCommandInteraction
 			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
+				logger.Warnf("error parsing application payload data: %v", err)
+				endSpan()
 				return
 			}
 
 			responseCh := make(chan interaction.ApplicationCommandCallbackData, 1)
 
-			deferDefault, err := executeCommand(worker, commandManager.GetCommands(), interactionData, responseCh)
+			deferDefault, err := executeCommand(worker, commandManager.GetCommands(), interactionData, responseCh, endSpan)
 			if err != nil {
-				marshalled, _ := json.Marshal(payload)
-				logrus.Warnf("error executing payload: %v (payload: %s)", err, string(marshalled))
+				// Don't include the bot token
+				logger.WithError(err).WithField("event", string(payload.Event)).Warn("Error executing payload")
 				return
 			}
 
@@ -169,12 +189,13 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 		case interaction.InteractionTypeMessageComponent:
 			var interactionData interaction.MessageComponentInteraction
 			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
+				logger.Warnf("error parsing application payload data: %v", err)
+				endSpan()
 				return
 			}
 
 			responseCh := make(chan button.Response, 1)
-			btn_manager.HandleInteraction(buttonManager, worker, interactionData, responseCh)
+			btn_manager.HandleInteraction(buttonManager, worker, interactionData, responseCh, endSpan)
 
 			timeout := time.NewTimer(time.Millisecond * 1500)
 
@@ -190,15 +211,18 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 			}
 
 		case interaction.InteractionTypeApplicationCommandAutoComplete:
+			// Autocomplete handlers run synchronously
+			defer endSpan()
+
 			var interactionData interaction.ApplicationCommandAutoCompleteInteraction
 			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
+				logger.Warnf("error parsing application payload data: %v", err)
 				return
 			}
 
 			cmd, ok := commandManager.GetCommands()[interactionData.Data.Name]
 			if !ok {
-				logrus.Warnf("autocomplete for invalid command: %s", interactionData.Data.Name)
+				logger.Warnf("autocomplete for invalid command: %s", interactionData.Data.Name)
 				return
 			}
 
@@ -216,7 +240,7 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 				}
 
 				if !found {
-					logrus.Warnf("subcommand %s does not exist for command %s", subCommand.Name, cmd.Properties().Name)
+					logger.Warnf("subcommand %s does not exist for command %s", subCommand.Name, cmd.Properties().Name)
 					return
 				}
 
@@ -225,7 +249,7 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 
 			value, path, found := findFocusedPath(interactionData.Data.Options, nil)
 			if !found {
-				logrus.Warnf("focused option not found")
+				logger.Warnf("focused option not found")
 				return
 			}
 
@@ -239,7 +263,7 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 						}
 					}
 
-					logrus.Warnf("subcommand %s does not exist for command %s", path[i], cmd.Properties().Name)
+					logger.Warnf("subcommand %s does not exist for command %s", path[i], cmd.Properties().Name)
 					return
 				}
 			}
@@ -252,7 +276,7 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 			}
 
 			if handler == nil {
-				logrus.Warnf("autocomplete for argument without handler: %s", path)
+				logger.Warnf("autocomplete for argument without handler: %s", path)
 				return
 			}
 
@@ -264,16 +288,20 @@ func interactionHandler(redis *redis.Client, cache *cache.PgCache) func(*gin.Con
 		case interaction.InteractionTypeModalSubmit:
 			var interactionData interaction.ModalSubmitInteraction
 			if err := json.Unmarshal(payload.Event, &interactionData); err != nil {
-				logrus.Warnf("error parsing application payload data: %v", err)
+				logger.Warnf("error parsing application payload data: %v", err)
+				endSpan()
 				return
 			}
 
 			responseCh := make(chan button.Response, 1)
-			btn_manager.HandleModalInteraction(buttonManager, worker, interactionData, responseCh)
+			btn_manager.HandleModalInteraction(buttonManager, worker, interactionData, responseCh, endSpan)
 
 			// Can't defer a modal submit response
 			data := <-responseCh
 			ctx.JSON(200, data.Build())
+
+		default:
+			endSpan()
 		}
 	}
 }
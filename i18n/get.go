@@ -7,11 +7,14 @@ import (
 	"github.com/TicketsBot/common/sentry"
 	"github.com/TicketsBot/worker/bot/cache"
 	"github.com/TicketsBot/worker/bot/dbclient"
+	"github.com/TicketsBot/worker/bot/logging"
 	"github.com/jackc/pgx/v4"
 	"io/ioutil"
 	"strings"
 )
 
+var logger = logging.For("i18n")
+
 var messages map[Language]map[MessageId]string
 var coverage map[Language]int
 
@@ -23,7 +26,7 @@ func LoadMessages() {
 
 		data, err := ioutil.ReadFile(path)
 		if err != nil {
-			fmt.Printf("Failed to read locale %s: %s\n", locale, err.Error())
+			logger.WithError(err).Errorf("Failed to read locale %s", locale)
 
 			if locale == "en-GB" { // Required
 				panic(err)
@@ -117,7 +120,7 @@ func getPreferredLocale(guildId uint64) (locale *string, err error) {
 func parseCrowdInFile(data []byte) map[MessageId]string {
 	var parsed map[string]interface{}
 	if err := json.Unmarshal(data, &parsed); err != nil {
-		fmt.Printf("Failed to parse locale: %s\n", err.Error())
+		logger.WithError(err).Error("Failed to parse locale")
 		return nil
 	}
 
@@ -34,6 +34,7 @@ var (
 	TitleClaim             MessageId = "generic.title.claim"
 	TitleBlacklist         MessageId = "generic.title.blacklist"
 	TitleBlacklisted       MessageId = "generic.title.blacklisted"
+	TitleOutOfHours        MessageId = "generic.title.out_of_hours"
 	TitleAddAdmin          MessageId = "generic.title.add_admin"
 	TitleAddSupport        MessageId = "generic.title.add_support"
 	TitleRemoveAdmin       MessageId = "generic.title.remove_admin"
@@ -52,6 +53,21 @@ var (
 	TitleCloseRequest      MessageId = "generic.title.close_request"
 	TitlePanelSwitched     MessageId = "generic.title.panel_switched"
 	TitleJumpToTop         MessageId = "generic.title.jump_to_top"
+	TitleStatsDigest       MessageId = "generic.title.stats_digest"
+	TitleExport            MessageId = "generic.title.export"
+	TitleRatingFeedback    MessageId = "generic.title.rating_feedback"
+	TitleSaveAsTag         MessageId = "generic.title.save_as_tag"
+	TitleMoveMessagesRange MessageId = "generic.title.move_messages_range"
+	TitleModmail           MessageId = "generic.title.modmail"
+	TitleReply             MessageId = "generic.title.reply"
+	TitleAnonymise         MessageId = "generic.title.anonymise"
+	TitleBulk              MessageId = "generic.title.bulk"
+	TitleQueueBoard        MessageId = "generic.title.queue_board"
+	TitleClaimSettings     MessageId = "generic.title.claim_settings"
+	TitleTransferRequest   MessageId = "generic.title.transfer_request"
+	TitleRouting           MessageId = "generic.title.routing"
+	TitleOpenLimits        MessageId = "generic.title.open_limits"
+	TitleBusinessHours     MessageId = "generic.title.business_hours"
 
 	MessageUnknownArgumentType MessageId = "generic.unknown_argument_type"
 
@@ -84,8 +100,13 @@ var (
 	MessageTagInvalidArguments MessageId = "commands.tags.get.invalid_arguments"
 	MessageTagInvalidTag       MessageId = "commands.tags.get.invalid_tag"
 
-	MessageOpenRatelimited MessageId = "open.ratelimited"
-	MessageTicketOpened    MessageId = "open.success"
+	MessageOpenRatelimited            MessageId = "open.ratelimited"
+	MessageOpenCooldown               MessageId = "open.cooldown"
+	MessageOutOfHours                 MessageId = "open.out_of_hours"
+	MessageOutOfHoursNoSchedule       MessageId = "open.out_of_hours.no_schedule"
+	MessageOutOfHoursNotice           MessageId = "open.out_of_hours.notice"
+	MessageOutOfHoursNoticeNoSchedule MessageId = "open.out_of_hours.notice_no_schedule"
+	MessageTicketOpened               MessageId = "open.success"
 
 	MessageAddAdminNoMembers   MessageId = "commands.addadmin.no_members"
 	MessageAddAdminConfirm     MessageId = "commands.addadmin.confirm"
@@ -100,16 +121,24 @@ var (
 	MessageAddNoPermission     MessageId = "commands.add.no_permission"
 	MessageAddSuccess          MessageId = "commands.add.success"
 
-	MessageBlacklisted         MessageId = "generic.error.blacklisted"
-	MessageBlacklistNoMembers  MessageId = "commands.blacklist.no_members"
-	MessageBlacklistSelf       MessageId = "commands.blacklist.self"
-	MessageBlacklistStaff      MessageId = "commands.blacklist.staff"
-	MessageBlacklistLimit      MessageId = "commands.blacklist.add.limit"
-	MessageBlacklistAdd        MessageId = "commands.blacklist.add.success"
-	MessageBlacklistRoleLimit  MessageId = "commands.blacklist.add_role.limit"
-	MessageBlacklistAddRole    MessageId = "commands.blacklist.add_role.success"
-	MessageBlacklistRemove     MessageId = "commands.blacklist.remove.success"
-	MessageBlacklistRemoveRole MessageId = "commands.blacklist.remove_role.success"
+	MessageBlacklisted               MessageId = "generic.error.blacklisted"
+	MessageBlacklistedDm             MessageId = "generic.error.blacklisted_dm"
+	MessageBlacklistNoMembers        MessageId = "commands.blacklist.no_members"
+	MessageBlacklistSelf             MessageId = "commands.blacklist.self"
+	MessageBlacklistStaff            MessageId = "commands.blacklist.staff"
+	MessageBlacklistNoReason         MessageId = "commands.blacklist.no_reason"
+	MessageBlacklistPermanent        MessageId = "commands.blacklist.permanent"
+	MessageBlacklistLimit            MessageId = "commands.blacklist.add.limit"
+	MessageBlacklistInvalidDuration  MessageId = "commands.blacklist.add.invalid_duration"
+	MessageBlacklistAdd              MessageId = "commands.blacklist.add.success"
+	MessageBlacklistAddTemporary     MessageId = "commands.blacklist.add.success_temporary"
+	MessageBlacklistRoleLimit        MessageId = "commands.blacklist.add_role.limit"
+	MessageBlacklistAddRole          MessageId = "commands.blacklist.add_role.success"
+	MessageBlacklistAddRoleTemporary MessageId = "commands.blacklist.add_role.success_temporary"
+	MessageBlacklistNotBlacklisted   MessageId = "commands.blacklist.remove.not_blacklisted"
+	MessageBlacklistRemove           MessageId = "commands.blacklist.remove.success"
+	MessageBlacklistRemoveRole       MessageId = "commands.blacklist.remove_role.success"
+	MessageBlacklistListEmpty        MessageId = "commands.blacklist.list.empty"
 
 	MessageClaimed           MessageId = "commands.claim.success"
 	MessageClaimNoPermission MessageId = "commands.claim.no_permission"
@@ -153,6 +182,18 @@ var (
 	MessageFormMissingInput         MessageId = "commands.open.missing_form_answer"
 	MessageOpenCommandDisabled      MessageId = "commands.open.disabled"
 
+	MessageFormValidationTooShort MessageId = "commands.open.form_validation.too_short"
+	MessageFormValidationTooLong  MessageId = "commands.open.form_validation.too_long"
+	MessageFormValidationInteger  MessageId = "commands.open.form_validation.integer"
+	MessageFormValidationDecimal  MessageId = "commands.open.form_validation.decimal"
+	MessageFormValidationTooSmall MessageId = "commands.open.form_validation.too_small"
+	MessageFormValidationTooLarge MessageId = "commands.open.form_validation.too_large"
+	MessageFormValidationEmail    MessageId = "commands.open.form_validation.email"
+	MessageFormValidationUrl      MessageId = "commands.open.form_validation.url"
+	MessageFormValidationPattern  MessageId = "commands.open.form_validation.pattern"
+	MessageFormValidationChoice   MessageId = "commands.open.form_validation.choice"
+	MessageFormRetry              MessageId = "commands.open.form_retry"
+
 	MessageCloseRequestNoReason     MessageId = "commands.close_request.no_reason"
 	MessageCloseRequestWithReason   MessageId = "commands.close_request.with_reason"
 	MessageCloseRequestNoPermission MessageId = "commands.close_request.no_permission"
@@ -169,6 +210,185 @@ var (
 	MessageJumpToTopNoWelcomeMessage MessageId = "commands.jump_to_top.no_welcome_message"
 	MessageJumpToTopContent          MessageId = "commands.jump_to_top.content"
 
+	MessageStatsDigestInvalidFrequency MessageId = "commands.stats.digest.invalid_frequency"
+	MessageStatsDigestInvalidChannel   MessageId = "commands.stats.digest.invalid_channel"
+	MessageStatsDigestChannel          MessageId = "commands.stats.digest.success_channel"
+	MessageStatsDigestDm               MessageId = "commands.stats.digest.success_dm"
+	MessageStatsDigestDisabled         MessageId = "commands.stats.digest.disabled"
+
+	MessageExportInvalidFormat MessageId = "commands.export.invalid_format"
+	MessageExportInvalidDate   MessageId = "commands.export.invalid_date"
+	MessageExportGenerating    MessageId = "commands.export.generating"
+	MessageExportComplete      MessageId = "commands.export.complete"
+	MessageExportEmpty         MessageId = "commands.export.empty"
+	MessageExportTooLarge      MessageId = "commands.export.too_large"
+
+	MessageLeaderboardInvalidMetric MessageId = "commands.stats.leaderboard.invalid_metric"
+	MessageLeaderboardInvalidPeriod MessageId = "commands.stats.leaderboard.invalid_period"
+	MessageLeaderboardInvalidPanel  MessageId = "commands.stats.panel.invalid_panel"
+
+	MessageRatingFeedbackYesNoPlaceholder MessageId = "generic.rating_feedback.yes_no_placeholder"
+	MessageRatingFeedbackInvalidYesNo     MessageId = "generic.rating_feedback.invalid_yes_no"
+	MessageFeedbackQuestionInvalidLabel   MessageId = "commands.feedback.question.invalid_label"
+	MessageFeedbackQuestionInvalidType    MessageId = "commands.feedback.question.invalid_type"
+	MessageFeedbackQuestionLimit          MessageId = "commands.feedback.question.limit"
+	MessageFeedbackQuestionAdded          MessageId = "commands.feedback.question.added"
+	MessageFeedbackQuestionNotFound       MessageId = "commands.feedback.question.not_found"
+	MessageFeedbackQuestionRemoved        MessageId = "commands.feedback.question.removed"
+	MessageFeedbackInvalidChannel         MessageId = "commands.feedback.channel.invalid_channel"
+	MessageFeedbackChannelSet             MessageId = "commands.feedback.channel.set"
+	MessageFeedbackChannelDisabled        MessageId = "commands.feedback.channel.disabled"
+	MessageFeedbackAlertsInvalidThreshold MessageId = "commands.feedback.alerts.invalid_threshold"
+	MessageFeedbackAlertsDisabled         MessageId = "commands.feedback.alerts.disabled"
+	MessageFeedbackAlertsClaimer          MessageId = "commands.feedback.alerts.claimer"
+	MessageFeedbackAlertsRole             MessageId = "commands.feedback.alerts.role"
+
+	MessageTagSent                 MessageId = "commands.tag.sent"
+	MessageTagArgumentsInvalid     MessageId = "commands.tag.arguments_invalid"
+	MessageTagArgumentsMissing     MessageId = "commands.tag.arguments_missing"
+	MessageTagPanelRestricted      MessageId = "commands.tag.panel_restricted"
+	MessageTagRoleRestricted       MessageId = "commands.tag.role_restricted"
+	MessageTagAttachSuccess        MessageId = "commands.managetags.attach.success"
+	MessageTagAttachmentLimit      MessageId = "commands.managetags.attach.limit"
+	MessageTagAttachmentTooLarge   MessageId = "commands.managetags.attach.too_large"
+	MessageTagDetachSuccess        MessageId = "commands.managetags.detach.success"
+	MessageTagRestrictMissing      MessageId = "commands.managetags.restrict.missing"
+	MessageTagRestrictInvalidPanel MessageId = "commands.managetags.restrict.invalid_panel"
+	MessageTagRestrictSuccess      MessageId = "commands.managetags.restrict.success"
+	MessageTagUnrestrictSuccess    MessageId = "commands.managetags.unrestrict.success"
+	MessageTagActionInvalid        MessageId = "commands.managetags.action.invalid"
+	MessageTagActionSuccess        MessageId = "commands.managetags.action.success"
+	MessageTagHistory              MessageId = "commands.managetags.history.list"
+	MessageTagHistoryEmpty         MessageId = "commands.managetags.history.empty"
+	MessageTagRollbackSuccess      MessageId = "commands.managetags.rollback.success"
+
+	MessageTagRollbackInvalidVersion MessageId = "commands.managetags.rollback.invalid_version"
+	MessageSaveAsTagEmpty            MessageId = "commands.save_as_tag.empty"
+	MessageSaveAsTagPrompt           MessageId = "commands.save_as_tag.prompt"
+	MessageSaveAsTagIdLabel          MessageId = "commands.save_as_tag.id_label"
+	MessageSaveAsTagIdPlaceholder    MessageId = "commands.save_as_tag.id_placeholder"
+	MessageSaveAsTagUpdated          MessageId = "commands.save_as_tag.updated"
+
+	MessageModmailSelectServer     MessageId = "modmail.select_server"
+	MessageModmailSelectPanel      MessageId = "modmail.select_panel"
+	MessageModmailUnavailable      MessageId = "modmail.unavailable"
+	MessageModmailAlreadyOpen      MessageId = "modmail.already_open"
+	MessageModmailOpened           MessageId = "modmail.opened"
+	MessageModmailUndeliverable    MessageId = "modmail.undeliverable"
+	MessageModmailStaffHint        MessageId = "modmail.staff_hint"
+	MessageModmailEnabled          MessageId = "commands.modmail.enabled"
+	MessageModmailEnabledAnonymous MessageId = "commands.modmail.enabled_anonymous"
+	MessageModmailDisabled         MessageId = "commands.modmail.disabled"
+
+	MessageReplySent              MessageId = "commands.reply.sent"
+	MessageReplyNoWebhook         MessageId = "commands.reply.no_webhook"
+	MessageAnonymiseInvalidPanel  MessageId = "commands.anonymise.invalid_panel"
+	MessageAnonymiseInvalidName   MessageId = "commands.anonymise.invalid_name"
+	MessageAnonymiseInvalidAvatar MessageId = "commands.anonymise.invalid_avatar"
+	MessageAnonymiseEnabled       MessageId = "commands.anonymise.enabled"
+	MessageAnonymiseDisabled      MessageId = "commands.anonymise.disabled"
+
+	MessageMoveMessagesPrompt           MessageId = "commands.open.from.move_prompt"
+	MessageMoveMessagesInProgress       MessageId = "commands.open.from.move_in_progress"
+	MessageMoveMessagesCopied           MessageId = "commands.open.from.copied"
+	MessageMoveMessagesMoved            MessageId = "commands.open.from.moved_messages"
+	MessageMoveMessagesDeleteFailed     MessageId = "commands.open.from.delete_failed"
+	MessageMoveMessagesTicketClosed     MessageId = "commands.open.from.ticket_closed"
+	MessageMoveMessagesRangeLabel       MessageId = "commands.open.from.range_label"
+	MessageMoveMessagesRangePlaceholder MessageId = "commands.open.from.range_placeholder"
+	MessageMoveMessagesRangeInvalid     MessageId = "commands.open.from.range_invalid"
+	MessageMoveMessagesRangeTooLarge    MessageId = "commands.open.from.range_too_large"
+	MessageMoveMessagesRangeInProgress  MessageId = "commands.open.from.range_in_progress"
+
+	MessageBulkNoTickets     MessageId = "commands.tickets.bulk.no_tickets"
+	MessageBulkInvalidPanel  MessageId = "commands.tickets.bulk.invalid_panel"
+	MessageBulkInvalidLabel  MessageId = "commands.tickets.bulk.invalid_label"
+	MessageBulkPreviewClose  MessageId = "commands.tickets.bulk.preview.close"
+	MessageBulkPreviewClaim  MessageId = "commands.tickets.bulk.preview.claim"
+	MessageBulkPreviewLabel  MessageId = "commands.tickets.bulk.preview.label"
+	MessageBulkPreviewMove   MessageId = "commands.tickets.bulk.preview.move"
+	MessageBulkExpired       MessageId = "commands.tickets.bulk.expired"
+	MessageBulkProgress      MessageId = "commands.tickets.bulk.progress"
+	MessageBulkComplete      MessageId = "commands.tickets.bulk.complete"
+	MessageBulkCancelled     MessageId = "commands.tickets.bulk.cancelled"
+	MessageBulkCancelling    MessageId = "commands.tickets.bulk.cancelling"
+	MessageBulkConfirmButton MessageId = "commands.tickets.bulk.confirm_button"
+	MessageBulkCancelButton  MessageId = "commands.tickets.bulk.cancel_button"
+	MessageBulkTruncated     MessageId = "commands.tickets.bulk.truncated"
+
+	MessageTicketSearchInvalidPanel  MessageId = "commands.tickets.search.invalid_panel"
+	MessageTicketSearchInvalidDate   MessageId = "commands.tickets.search.invalid_date"
+	MessageTicketSearchInvalidStatus MessageId = "commands.tickets.search.invalid_status"
+
+	MessageOpenTicketsNotOpen        MessageId = "commands.tickets.open.not_open"
+	MessageOpenTicketsSummary        MessageId = "commands.tickets.open.summary"
+	MessageOpenTicketsAlreadyClaimed MessageId = "commands.tickets.open.already_claimed"
+	MessageOpenTicketsClaimed        MessageId = "commands.tickets.open.claimed"
+	MessageOpenTicketsClosed         MessageId = "commands.tickets.open.closed"
+	MessageOpenTicketsJumpButton     MessageId = "commands.tickets.open.jump_button"
+	MessageOpenTicketsClaimButton    MessageId = "commands.tickets.open.claim_button"
+	MessageOpenTicketsCloseButton    MessageId = "commands.tickets.open.close_button"
+
+	MessageQueueBoardInvalidChannel MessageId = "commands.queueboard.setup.invalid_channel"
+	MessageQueueBoardCreated        MessageId = "commands.queueboard.setup.created"
+	MessageQueueBoardShiftStarted   MessageId = "commands.queueboard.shift.started"
+	MessageQueueBoardShiftEnded     MessageId = "commands.queueboard.shift.ended"
+
+	MessageClaimLimitReached         MessageId = "commands.claim.limit_reached"
+	MessageTransferClaimLimitReached MessageId = "commands.transfer.limit_reached"
+	MessageClaimTimedOut             MessageId = "commands.claim.timed_out"
+	MessageClaimSettingsInvalidLimit MessageId = "commands.claimsettings.invalid_limit"
+	MessageClaimSettingsInvalidHours MessageId = "commands.claimsettings.invalid_hours"
+	MessageClaimSettingsUpdated      MessageId = "commands.claimsettings.updated"
+
+	MessageRoutingInvalidPanel        MessageId = "commands.routing.invalid_panel"
+	MessageRoutingInvalidOperator     MessageId = "commands.routing.invalid_operator"
+	MessageRoutingMissingCondition    MessageId = "commands.routing.missing_condition"
+	MessageRoutingValueTooLong        MessageId = "commands.routing.value_too_long"
+	MessageRoutingInvalidPattern      MessageId = "commands.routing.invalid_pattern"
+	MessageRoutingInvalidQuestion     MessageId = "commands.routing.invalid_question"
+	MessageRoutingInvalidCategory     MessageId = "commands.routing.invalid_category"
+	MessageRoutingInvalidTeam         MessageId = "commands.routing.invalid_team"
+	MessageRoutingInvalidNamingScheme MessageId = "commands.routing.invalid_naming_scheme"
+	MessageRoutingInvalidPriority     MessageId = "commands.routing.invalid_priority"
+	MessageRoutingNoOutcome           MessageId = "commands.routing.no_outcome"
+	MessageRoutingTooManyRules        MessageId = "commands.routing.too_many_rules"
+	MessageRoutingAdded               MessageId = "commands.routing.added"
+	MessageRoutingNoRules             MessageId = "commands.routing.no_rules"
+	MessageRoutingList                MessageId = "commands.routing.list"
+	MessageRoutingInvalidRule         MessageId = "commands.routing.invalid_rule"
+	MessageRoutingRemoved             MessageId = "commands.routing.removed"
+
+	MessageOpenLimitsInvalidLimit    MessageId = "commands.openlimits.invalid_limit"
+	MessageOpenLimitsInvalidInterval MessageId = "commands.openlimits.invalid_interval"
+	MessageOpenLimitsInvalidCooldown MessageId = "commands.openlimits.invalid_cooldown"
+	MessageOpenLimitsInvalidPanel    MessageId = "commands.openlimits.invalid_panel"
+	MessageOpenLimitsGuild           MessageId = "commands.openlimits.guild"
+	MessageOpenLimitsPanel           MessageId = "commands.openlimits.panel"
+
+	MessageBusinessHoursInvalidPanel    MessageId = "commands.businesshours.invalid_panel"
+	MessageBusinessHoursInvalidTimezone MessageId = "commands.businesshours.invalid_timezone"
+	MessageBusinessHoursInvalidMode     MessageId = "commands.businesshours.invalid_mode"
+	MessageBusinessHoursInvalidDay      MessageId = "commands.businesshours.invalid_day"
+	MessageBusinessHoursInvalidTime     MessageId = "commands.businesshours.invalid_time"
+	MessageBusinessHoursSameTime        MessageId = "commands.businesshours.same_time"
+	MessageBusinessHoursInvalidDate     MessageId = "commands.businesshours.invalid_date"
+	MessageBusinessHoursView            MessageId = "commands.businesshours.view"
+
+	MessageTransferNoTarget        MessageId = "commands.transfer.no_target"
+	MessageTransferInvalidTeam     MessageId = "commands.transfer.invalid_team"
+	MessageTransferAlreadyPending  MessageId = "commands.transfer.already_pending"
+	MessageTransferRequestSent     MessageId = "commands.transfer.request_sent"
+	MessageTransferRequest         MessageId = "commands.transfer.request"
+	MessageTransferAcceptButton    MessageId = "commands.transfer.accept_button"
+	MessageTransferDeclineButton   MessageId = "commands.transfer.decline_button"
+	MessageTransferNoLongerPending MessageId = "commands.transfer.no_longer_pending"
+	MessageTransferNotTarget       MessageId = "commands.transfer.not_target"
+	MessageTransferTicketClosed    MessageId = "commands.transfer.ticket_closed"
+	MessageTransferAccepted        MessageId = "commands.transfer.accepted"
+	MessageTransferDeclined        MessageId = "commands.transfer.declined"
+	MessageTransferExpired         MessageId = "commands.transfer.expired"
+
 	SetupArchiveChannel  MessageId = "setup.info.archive_channel"
 	SetupChannelCategory MessageId = "setup.info.category"
 	SetupPrefix          MessageId = "setup.info.prefix"
@@ -227,43 +447,94 @@ var (
 	MessageButtonGuildOnly MessageId = "button.guild_only"
 	MessageButtonDMOnly    MessageId = "button.dms_only"
 
-	HelpAdmin              MessageId = "help.admin"
-	HelpAdminForceClose    MessageId = "help.admin.force_close"
-	HelpAdminGenPremium    MessageId = "help.admin.generate_premium"
-	HelpAdminGetOwner      MessageId = "help.admin.get_owner"
-	HelpAdminUpdateSchema  MessageId = "help.admin.update_schema"
-	HelpAbout              MessageId = "help.about"
-	HelpAutoClose          MessageId = "help.autoclose"
-	HelpAutoCloseExclude   MessageId = "help.autoclose.exclude"
-	HelpAutoCloseConfigure MessageId = "help.autoclose.configure"
-	HelpVote               MessageId = "help.vote"
-	HelpAddAdmin           MessageId = "help.addadmin"
-	HelpAddSupport         MessageId = "help.addsupport"
-	HelpBlacklist          MessageId = "help.blacklist"
-	HelpPanel              MessageId = "help.panel"
-	HelpPremium            MessageId = "help.premium"
-	HelpRemoveSupport      MessageId = "help.removesupport"
-	HelpSetup              MessageId = "help.setup"
-	HelpViewStaff          MessageId = "help.viewstaff"
-	HelpStats              MessageId = "help.stats"
-	HelpStatsServer        MessageId = "help.statsserver"
-	HelpManageTags         MessageId = "help.managetags"
-	HelpTagAdd             MessageId = "help.taggadd"
-	HelpTagDelete          MessageId = "help.tagdelete"
-	HelpTagList            MessageId = "help.taglist"
-	HelpTag                MessageId = "help.tag"
-	HelpAdd                MessageId = "help.add"
-	HelpClaim              MessageId = "help.claim"
-	HelpClose              MessageId = "help.close"
-	HelpCloseRequest       MessageId = "help.close_request"
-	HelpOpen               MessageId = "help.open"
-	HelpRemove             MessageId = "help.remove"
-	HelpRename             MessageId = "help.rename"
-	HelpTransfer           MessageId = "help.transfer"
-	HelpUnclaim            MessageId = "help.unclaim"
-	HelpHelp               MessageId = "help.help"
-	HelpRemoveAdmin        MessageId = "help.removeadmin"
-	HelpLanguage           MessageId = "help.language"
-	HelpSwitchPanel        MessageId = "help.switch_panel"
-	HelpJumpToTop          MessageId = "help.jump_to_top"
+	HelpAdmin                 MessageId = "help.admin"
+	HelpAdminForceClose       MessageId = "help.admin.force_close"
+	HelpAdminGenPremium       MessageId = "help.admin.generate_premium"
+	HelpAdminGetOwner         MessageId = "help.admin.get_owner"
+	HelpAdminUpdateSchema     MessageId = "help.admin.update_schema"
+	HelpAbout                 MessageId = "help.about"
+	HelpAutoClose             MessageId = "help.autoclose"
+	HelpAutoCloseExclude      MessageId = "help.autoclose.exclude"
+	HelpAutoCloseConfigure    MessageId = "help.autoclose.configure"
+	HelpVote                  MessageId = "help.vote"
+	HelpAddAdmin              MessageId = "help.addadmin"
+	HelpAddSupport            MessageId = "help.addsupport"
+	HelpBlacklist             MessageId = "help.blacklist"
+	HelpBlacklistAdd          MessageId = "help.blacklist.add"
+	HelpBlacklistRemove       MessageId = "help.blacklist.remove"
+	HelpBlacklistList         MessageId = "help.blacklist.list"
+	HelpPanel                 MessageId = "help.panel"
+	HelpPremium               MessageId = "help.premium"
+	HelpRemoveSupport         MessageId = "help.removesupport"
+	HelpSetup                 MessageId = "help.setup"
+	HelpViewStaff             MessageId = "help.viewstaff"
+	HelpStats                 MessageId = "help.stats"
+	HelpStatsServer           MessageId = "help.statsserver"
+	HelpStatsDigest           MessageId = "help.stats.digest"
+	HelpStatsLeaderboard      MessageId = "help.stats.leaderboard"
+	HelpStatsPanel            MessageId = "help.stats.panel"
+	HelpFeedback              MessageId = "help.feedback"
+	HelpModmail               MessageId = "help.modmail"
+	HelpReply                 MessageId = "help.reply"
+	HelpAnonymise             MessageId = "help.anonymise"
+	HelpTickets               MessageId = "help.tickets"
+	HelpTicketsBulk           MessageId = "help.tickets.bulk"
+	HelpTicketsBulkClose      MessageId = "help.tickets.bulk.close"
+	HelpTicketsBulkClaim      MessageId = "help.tickets.bulk.claim"
+	HelpTicketsBulkLabel      MessageId = "help.tickets.bulk.label"
+	HelpTicketsBulkMove       MessageId = "help.tickets.bulk.move"
+	HelpTicketsSearch         MessageId = "help.tickets.search"
+	HelpTicketsOpen           MessageId = "help.tickets.open"
+	HelpTicketsMine           MessageId = "help.tickets.mine"
+	HelpQueueBoard            MessageId = "help.queueboard"
+	HelpQueueBoardSetup       MessageId = "help.queueboard.setup"
+	HelpQueueBoardShift       MessageId = "help.queueboard.shift"
+	HelpClaimSettings         MessageId = "help.claimsettings"
+	HelpRouting               MessageId = "help.routing"
+	HelpRoutingAdd            MessageId = "help.routing.add"
+	HelpRoutingList           MessageId = "help.routing.list"
+	HelpRoutingRemove         MessageId = "help.routing.remove"
+	HelpOpenLimits            MessageId = "help.openlimits"
+	HelpOpenLimitsGuild       MessageId = "help.openlimits.guild"
+	HelpOpenLimitsPanel       MessageId = "help.openlimits.panel"
+	HelpBusinessHours         MessageId = "help.businesshours"
+	HelpBusinessHoursSchedule MessageId = "help.businesshours.schedule"
+	HelpBusinessHoursHours    MessageId = "help.businesshours.hours"
+	HelpBusinessHoursHoliday  MessageId = "help.businesshours.holiday"
+	HelpBusinessHoursView     MessageId = "help.businesshours.view"
+	HelpExport                MessageId = "help.export"
+	HelpExportTickets         MessageId = "help.export.tickets"
+	HelpExportRatings         MessageId = "help.export.ratings"
+	HelpExportStaff           MessageId = "help.export.staff"
+	HelpManageTags            MessageId = "help.managetags"
+	HelpTagAdd                MessageId = "help.taggadd"
+	HelpTagDelete             MessageId = "help.tagdelete"
+	HelpTagList               MessageId = "help.taglist"
+	HelpTag                   MessageId = "help.tag"
+	HelpAdd                   MessageId = "help.add"
+	HelpClaim                 MessageId = "help.claim"
+	HelpClose                 MessageId = "help.close"
+	HelpCloseRequest          MessageId = "help.close_request"
+	HelpOpen                  MessageId = "help.open"
+	HelpRemove                MessageId = "help.remove"
+	HelpRename                MessageId = "help.rename"
+	HelpTransfer              MessageId = "help.transfer"
+	HelpUnclaim               MessageId = "help.unclaim"
+	HelpHelp                  MessageId = "help.help"
+	HelpRemoveAdmin           MessageId = "help.removeadmin"
+	HelpLanguage              MessageId = "help.language"
+	HelpSwitchPanel           MessageId = "help.switch_panel"
+	HelpJumpToTop             MessageId = "help.jump_to_top"
+
+	HelpFeedbackAddQuestion    MessageId = "help.feedback.add_question"
+	HelpFeedbackRemoveQuestion MessageId = "help.feedback.remove_question"
+	HelpFeedbackChannel        MessageId = "help.feedback.channel"
+	HelpFeedbackAlerts         MessageId = "help.feedback.alerts"
+	HelpTagAttach              MessageId = "help.managetags.attach"
+	HelpTagDetach              MessageId = "help.managetags.detach"
+	HelpTagRestrict            MessageId = "help.managetags.restrict"
+	HelpTagUnrestrict          MessageId = "help.managetags.unrestrict"
+	HelpTagAction              MessageId = "help.managetags.action"
+	HelpTagHistory             MessageId = "help.managetags.history"
+	HelpTagRollback            MessageId = "help.managetags.rollback"
 )
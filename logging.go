@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"github.com/TicketsBot/worker/bot/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// Logger returns a logger carrying the fields of the request that the worker is handling
+func (c *Context) Logger() *logrus.Entry {
+	return logging.WithCaller(1, c.LogFields())
+}
+
+// LogFields returns a copy of the fields that are attached to every log entry for the request
+func (c *Context) LogFields() logrus.Fields {
+	c.logLock.RLock()
+	fields := make(logrus.Fields, len(c.logFields)+2)
+	for key, value := range c.logFields {
+		fields[key] = value
+	}
+	c.logLock.RUnlock()
+
+	if c.BotId != 0 {
+		fields["bot_id"] = c.BotId
+	}
+
+	if traceId := c.TraceId(); traceId != "" {
+		fields["trace_id"] = traceId
+	}
+
+	return fields
+}
+
+func (c *Context) AddLogFields(fields logrus.Fields) {
+	c.logLock.Lock()
+	defer c.logLock.Unlock()
+
+	if c.logFields == nil {
+		c.logFields = make(logrus.Fields)
+	}
+
+	for key, value := range fields {
+		c.logFields[key] = value
+	}
+}
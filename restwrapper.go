@@ -16,6 +16,8 @@ import (
 )
 
 func (ctx *Context) GetChannel(channelId uint64) (channel.Channel, error) {
+	defer ctx.restSpan("GetChannel")()
+
 	shouldCache := ctx.Cache.GetOptions().Channels
 	if shouldCache {
 		if cached, found := ctx.Cache.GetChannel(channelId); found {
@@ -33,6 +35,8 @@ func (ctx *Context) GetChannel(channelId uint64) (channel.Channel, error) {
 }
 
 func (ctx *Context) ModifyChannel(channelId uint64, data rest.ModifyChannelData) (channel.Channel, error) {
+	defer ctx.restSpan("ModifyChannel")()
+
 	channel, err := rest.ModifyChannel(ctx.Token, ctx.RateLimiter, channelId, data)
 
 	if ctx.Cache.GetOptions().Channels && err != nil {
@@ -43,24 +47,34 @@ func (ctx *Context) ModifyChannel(channelId uint64, data rest.ModifyChannelData)
 }
 
 func (ctx *Context) DeleteChannel(channelId uint64) (channel.Channel, error) {
+	defer ctx.restSpan("DeleteChannel")()
+
 	return rest.DeleteChannel(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) GetChannelMessages(channelId uint64, options rest.GetChannelMessagesData) ([]message.Message, error) {
+	defer ctx.restSpan("GetChannelMessages")()
+
 	return rest.GetChannelMessages(ctx.Token, ctx.RateLimiter, channelId, options)
 }
 
 func (ctx *Context) GetChannelMessage(channelId, messageId uint64) (message.Message, error) {
+	defer ctx.restSpan("GetChannelMessage")()
+
 	return rest.GetChannelMessage(ctx.Token, ctx.RateLimiter, channelId, messageId)
 }
 
 func (ctx *Context) CreateMessage(channelId uint64, content string) (message.Message, error) {
+	defer ctx.restSpan("CreateMessage")()
+
 	return ctx.CreateMessageComplex(channelId, rest.CreateMessageData{
 		Content: content,
 	})
 }
 
 func (ctx *Context) CreateMessageReply(channelId uint64, content string, reference *message.MessageReference) (message.Message, error) {
+	defer ctx.restSpan("CreateMessageReply")()
+
 	return ctx.CreateMessageComplex(channelId, rest.CreateMessageData{
 		Content:          content,
 		MessageReference: reference,
@@ -68,12 +82,16 @@ func (ctx *Context) CreateMessageReply(channelId uint64, content string, referen
 }
 
 func (ctx *Context) CreateMessageEmbed(channelId uint64, embed ...*embed.Embed) (message.Message, error) {
+	defer ctx.restSpan("CreateMessageEmbed")()
+
 	return ctx.CreateMessageComplex(channelId, rest.CreateMessageData{
 		Embeds: embed,
 	})
 }
 
 func (ctx *Context) CreateMessageEmbedReply(channelId uint64, e *embed.Embed, reference *message.MessageReference) (message.Message, error) {
+	defer ctx.restSpan("CreateMessageEmbedReply")()
+
 	return ctx.CreateMessageComplex(channelId, rest.CreateMessageData{
 		Embeds:           []*embed.Embed{e},
 		MessageReference: reference,
@@ -81,106 +99,158 @@ func (ctx *Context) CreateMessageEmbedReply(channelId uint64, e *embed.Embed, re
 }
 
 func (ctx *Context) CreateMessageComplex(channelId uint64, data rest.CreateMessageData) (message.Message, error) {
+	defer ctx.restSpan("CreateMessageComplex")()
+
 	return rest.CreateMessage(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) CreateReaction(channelId, messageId uint64, emoji string) error {
+	defer ctx.restSpan("CreateReaction")()
+
 	return rest.CreateReaction(ctx.Token, ctx.RateLimiter, channelId, messageId, emoji)
 }
 
 func (ctx *Context) DeleteOwnReaction(channelId, messageId uint64, emoji string) error {
+	defer ctx.restSpan("DeleteOwnReaction")()
+
 	return rest.DeleteOwnReaction(ctx.Token, ctx.RateLimiter, channelId, messageId, emoji)
 }
 
 func (ctx *Context) DeleteUserReaction(channelId, messageId, userId uint64, emoji string) error {
+	defer ctx.restSpan("DeleteUserReaction")()
+
 	return rest.DeleteUserReaction(ctx.Token, ctx.RateLimiter, channelId, messageId, userId, emoji)
 }
 
 func (ctx *Context) GetReactions(channelId, messageId uint64, emoji string, options rest.GetReactionsData) ([]user.User, error) {
+	defer ctx.restSpan("GetReactions")()
+
 	return rest.GetReactions(ctx.Token, ctx.RateLimiter, channelId, messageId, emoji, options)
 }
 
 func (ctx *Context) DeleteAllReactions(channelId, messageId uint64) error {
+	defer ctx.restSpan("DeleteAllReactions")()
+
 	return rest.DeleteAllReactions(ctx.Token, ctx.RateLimiter, channelId, messageId)
 }
 
 func (ctx *Context) DeleteAllReactionsEmoji(channelId, messageId uint64, emoji string) error {
+	defer ctx.restSpan("DeleteAllReactionsEmoji")()
+
 	return rest.DeleteAllReactionsEmoji(ctx.Token, ctx.RateLimiter, channelId, messageId, emoji)
 }
 
 func (ctx *Context) EditMessage(channelId, messageId uint64, data rest.EditMessageData) (message.Message, error) {
+	defer ctx.restSpan("EditMessage")()
+
 	return rest.EditMessage(ctx.Token, ctx.RateLimiter, channelId, messageId, data)
 }
 
 func (ctx *Context) DeleteMessage(channelId, messageId uint64) error {
+	defer ctx.restSpan("DeleteMessage")()
+
 	return rest.DeleteMessage(ctx.Token, ctx.RateLimiter, channelId, messageId)
 }
 
 func (ctx *Context) BulkDeleteMessages(channelId uint64, messages []uint64) error {
+	defer ctx.restSpan("BulkDeleteMessages")()
+
 	return rest.BulkDeleteMessages(ctx.Token, ctx.RateLimiter, channelId, messages)
 }
 
 func (ctx *Context) EditChannelPermissions(channelId uint64, updated channel.PermissionOverwrite) error {
+	defer ctx.restSpan("EditChannelPermissions")()
+
 	return rest.EditChannelPermissions(ctx.Token, ctx.RateLimiter, channelId, updated)
 }
 
 func (ctx *Context) GetChannelInvites(channelId uint64) ([]invite.InviteMetadata, error) {
+	defer ctx.restSpan("GetChannelInvites")()
+
 	return rest.GetChannelInvites(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) CreateChannelInvite(channelId uint64, data rest.CreateInviteData) (invite.Invite, error) {
+	defer ctx.restSpan("CreateChannelInvite")()
+
 	return rest.CreateChannelInvite(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) DeleteChannelPermissions(channelId, overwriteId uint64) error {
+	defer ctx.restSpan("DeleteChannelPermissions")()
+
 	return rest.DeleteChannelPermissions(ctx.Token, ctx.RateLimiter, channelId, overwriteId)
 }
 
 func (ctx *Context) TriggerTypingIndicator(channelId uint64) error {
+	defer ctx.restSpan("TriggerTypingIndicator")()
+
 	return rest.TriggerTypingIndicator(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) GetPinnedMessages(channelId uint64) ([]message.Message, error) {
+	defer ctx.restSpan("GetPinnedMessages")()
+
 	return rest.GetPinnedMessages(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) AddPinnedChannelMessage(channelId, messageId uint64) error {
+	defer ctx.restSpan("AddPinnedChannelMessage")()
+
 	return rest.AddPinnedChannelMessage(ctx.Token, ctx.RateLimiter, channelId, messageId)
 }
 
 func (ctx *Context) DeletePinnedChannelMessage(channelId, messageId uint64) error {
+	defer ctx.restSpan("DeletePinnedChannelMessage")()
+
 	return rest.DeletePinnedChannelMessage(ctx.Token, ctx.RateLimiter, channelId, messageId)
 }
 
 func (ctx *Context) ListThreadMembers(channelId uint64) ([]channel.ThreadMember, error) {
+	defer ctx.restSpan("ListThreadMembers")()
+
 	return rest.ListThreadMembers(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) ListActiveThreads(channelId uint64) (rest.ThreadsResponse, error) {
+	defer ctx.restSpan("ListActiveThreads")()
+
 	return rest.ListActiveThreads(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) ListPublicArchivedThreads(channelId uint64, data rest.ListThreadsData) (rest.ThreadsResponse, error) {
+	defer ctx.restSpan("ListPublicArchivedThreads")()
+
 	return rest.ListPublicArchivedThreads(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) ListPrivateArchivedThreads(channelId uint64, data rest.ListThreadsData) (rest.ThreadsResponse, error) {
+	defer ctx.restSpan("ListPrivateArchivedThreads")()
+
 	return rest.ListPrivateArchivedThreads(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) ListJoinedPrivateArchivedThreads(channelId uint64, data rest.ListThreadsData) (rest.ThreadsResponse, error) {
+	defer ctx.restSpan("ListJoinedPrivateArchivedThreads")()
+
 	return rest.ListPrivateArchivedThreads(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) StartThreadWithMessage(channelId, messageId uint64, data rest.StartThreadWithMessageData) (channel.Channel, error) {
+	defer ctx.restSpan("StartThreadWithMessage")()
+
 	return rest.StartThreadWithMessage(ctx.Token, ctx.RateLimiter, channelId, messageId, data)
 }
 
 func (ctx *Context) StartThreadWithoutMessage(channelId, messageId uint64, data rest.StartThreadWithoutMessageData) (channel.Channel, error) {
+	defer ctx.restSpan("StartThreadWithoutMessage")()
+
 	return rest.StartThreadWithoutMessage(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) CreatePublicThread(channelId uint64, name string, autoArchiveDuration uint16) (channel.Channel, error) {
+	defer ctx.restSpan("CreatePublicThread")()
+
 	data := rest.StartThreadWithoutMessageData{
 		Name:                name,
 		AutoArchiveDuration: autoArchiveDuration,
@@ -191,6 +261,8 @@ func (ctx *Context) CreatePublicThread(channelId uint64, name string, autoArchiv
 }
 
 func (ctx *Context) CreatePrivateThread(channelId uint64, name string, autoArchiveDuration uint16, invitable bool) (channel.Channel, error) {
+	defer ctx.restSpan("CreatePrivateThread")()
+
 	data := rest.StartThreadWithoutMessageData{
 		Name:                name,
 		AutoArchiveDuration: autoArchiveDuration,
@@ -202,6 +274,8 @@ func (ctx *Context) CreatePrivateThread(channelId uint64, name string, autoArchi
 }
 
 func (ctx *Context) ListGuildEmojis(guildId uint64) ([]emoji.Emoji, error) {
+	defer ctx.restSpan("ListGuildEmojis")()
+
 	shouldCacheEmoji := ctx.Cache.GetOptions().Emojis
 	shouldCacheGuild := ctx.Cache.GetOptions().Guilds
 
@@ -221,6 +295,8 @@ func (ctx *Context) ListGuildEmojis(guildId uint64) ([]emoji.Emoji, error) {
 }
 
 func (ctx *Context) GetGuildEmoji(guildId uint64, emojiId uint64) (emoji.Emoji, error) {
+	defer ctx.restSpan("GetGuildEmoji")()
+
 	shouldCache := ctx.Cache.GetOptions().Emojis
 	if shouldCache {
 		if emoji, found := ctx.Cache.GetEmoji(emojiId); found {
@@ -238,19 +314,27 @@ func (ctx *Context) GetGuildEmoji(guildId uint64, emojiId uint64) (emoji.Emoji,
 }
 
 func (ctx *Context) CreateGuildEmoji(guildId uint64, data rest.CreateEmojiData) (emoji.Emoji, error) {
+	defer ctx.restSpan("CreateGuildEmoji")()
+
 	return rest.CreateGuildEmoji(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 // updating Image is not permitted
 func (ctx *Context) ModifyGuildEmoji(guildId, emojiId uint64, data rest.CreateEmojiData) (emoji.Emoji, error) {
+	defer ctx.restSpan("ModifyGuildEmoji")()
+
 	return rest.ModifyGuildEmoji(ctx.Token, ctx.RateLimiter, guildId, emojiId, data)
 }
 
 func (ctx *Context) CreateGuild(data rest.CreateGuildData) (guild.Guild, error) {
+	defer ctx.restSpan("CreateGuild")()
+
 	return rest.CreateGuild(ctx.Token, data)
 }
 
 func (ctx *Context) GetGuild(guildId uint64) (guild.Guild, error) {
+	defer ctx.restSpan("GetGuild")()
+
 	shouldCache := ctx.Cache.GetOptions().Guilds
 
 	if shouldCache {
@@ -268,18 +352,26 @@ func (ctx *Context) GetGuild(guildId uint64) (guild.Guild, error) {
 }
 
 func (ctx *Context) GetGuildPreview(guildId uint64) (guild.GuildPreview, error) {
+	defer ctx.restSpan("GetGuildPreview")()
+
 	return rest.GetGuildPreview(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) ModifyGuild(guildId uint64, data rest.ModifyGuildData) (guild.Guild, error) {
+	defer ctx.restSpan("ModifyGuild")()
+
 	return rest.ModifyGuild(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 func (ctx *Context) DeleteGuild(guildId uint64) error {
+	defer ctx.restSpan("DeleteGuild")()
+
 	return rest.DeleteGuild(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) GetGuildChannels(guildId uint64) ([]channel.Channel, error) {
+	defer ctx.restSpan("GetGuildChannels")()
+
 	shouldCache := ctx.Cache.GetOptions().Guilds && ctx.Cache.GetOptions().Channels
 
 	if shouldCache {
@@ -301,14 +393,20 @@ func (ctx *Context) GetGuildChannels(guildId uint64) ([]channel.Channel, error)
 }
 
 func (ctx *Context) CreateGuildChannel(guildId uint64, data rest.CreateChannelData) (channel.Channel, error) {
+	defer ctx.restSpan("CreateGuildChannel")()
+
 	return rest.CreateGuildChannel(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 func (ctx *Context) ModifyGuildChannelPositions(guildId uint64, positions []rest.Position) error {
+	defer ctx.restSpan("ModifyGuildChannelPositions")()
+
 	return rest.ModifyGuildChannelPositions(ctx.Token, ctx.RateLimiter, guildId, positions)
 }
 
 func (ctx *Context) GetGuildMember(guildId, userId uint64) (member.Member, error) {
+	defer ctx.restSpan("GetGuildMember")()
+
 	cacheGuilds := ctx.Cache.GetOptions().Guilds
 	cacheUsers := ctx.Cache.GetOptions().Users
 
@@ -328,6 +426,8 @@ func (ctx *Context) GetGuildMember(guildId, userId uint64) (member.Member, error
 }
 
 func (ctx *Context) SearchGuildMembers(guildId uint64, data rest.SearchGuildMembersData) ([]member.Member, error) {
+	defer ctx.restSpan("SearchGuildMembers")()
+
 	members, err := rest.SearchGuildMembers(ctx.Token, ctx.RateLimiter, guildId, data)
 	if err == nil {
 		go ctx.Cache.StoreMembers(members, guildId)
@@ -337,6 +437,8 @@ func (ctx *Context) SearchGuildMembers(guildId uint64, data rest.SearchGuildMemb
 }
 
 func (ctx *Context) ListGuildMembers(guildId uint64, data rest.ListGuildMembersData) ([]member.Member, error) {
+	defer ctx.restSpan("ListGuildMembers")()
+
 	members, err := rest.ListGuildMembers(ctx.Token, ctx.RateLimiter, guildId, data)
 	if err == nil {
 		go ctx.Cache.StoreMembers(members, guildId)
@@ -346,42 +448,62 @@ func (ctx *Context) ListGuildMembers(guildId uint64, data rest.ListGuildMembersD
 }
 
 func (ctx *Context) ModifyGuildMember(guildId, userId uint64, data rest.ModifyGuildMemberData) error {
+	defer ctx.restSpan("ModifyGuildMember")()
+
 	return rest.ModifyGuildMember(ctx.Token, ctx.RateLimiter, guildId, userId, data)
 }
 
 func (ctx *Context) ModifyCurrentUserNick(guildId uint64, nick string) error {
+	defer ctx.restSpan("ModifyCurrentUserNick")()
+
 	return rest.ModifyCurrentUserNick(ctx.Token, ctx.RateLimiter, guildId, nick)
 }
 
 func (ctx *Context) AddGuildMemberRole(guildId, userId, roleId uint64) error {
+	defer ctx.restSpan("AddGuildMemberRole")()
+
 	return rest.AddGuildMemberRole(ctx.Token, ctx.RateLimiter, guildId, userId, roleId)
 }
 
 func (ctx *Context) RemoveGuildMemberRole(guildId, userId, roleId uint64) error {
+	defer ctx.restSpan("RemoveGuildMemberRole")()
+
 	return rest.RemoveGuildMemberRole(ctx.Token, ctx.RateLimiter, guildId, userId, roleId)
 }
 
 func (ctx *Context) RemoveGuildMember(guildId, userId uint64) error {
+	defer ctx.restSpan("RemoveGuildMember")()
+
 	return rest.RemoveGuildMember(ctx.Token, ctx.RateLimiter, guildId, userId)
 }
 
 func (ctx *Context) GetGuildBans(guildId uint64, data rest.GetGuildBansData) ([]guild.Ban, error) {
+	defer ctx.restSpan("GetGuildBans")()
+
 	return rest.GetGuildBans(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 func (ctx *Context) GetGuildBan(guildId, userId uint64) (guild.Ban, error) {
+	defer ctx.restSpan("GetGuildBan")()
+
 	return rest.GetGuildBan(ctx.Token, ctx.RateLimiter, guildId, userId)
 }
 
 func (ctx *Context) CreateGuildBan(guildId, userId uint64, data rest.CreateGuildBanData) error {
+	defer ctx.restSpan("CreateGuildBan")()
+
 	return rest.CreateGuildBan(ctx.Token, ctx.RateLimiter, guildId, userId, data)
 }
 
 func (ctx *Context) RemoveGuildBan(guildId, userId uint64) error {
+	defer ctx.restSpan("RemoveGuildBan")()
+
 	return rest.RemoveGuildBan(ctx.Token, ctx.RateLimiter, guildId, userId)
 }
 
 func (ctx *Context) GetGuildRoles(guildId uint64) ([]guild.Role, error) {
+	defer ctx.restSpan("GetGuildRoles")()
+
 	shouldCache := ctx.Cache.GetOptions().Guilds
 	if shouldCache {
 		cached := ctx.Cache.GetGuildRoles(guildId)
@@ -402,80 +524,118 @@ func (ctx *Context) GetGuildRoles(guildId uint64) ([]guild.Role, error) {
 }
 
 func (ctx *Context) CreateGuildRole(guildId uint64, data rest.GuildRoleData) (guild.Role, error) {
+	defer ctx.restSpan("CreateGuildRole")()
+
 	return rest.CreateGuildRole(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 func (ctx *Context) ModifyGuildRolePositions(guildId uint64, positions []rest.Position) ([]guild.Role, error) {
+	defer ctx.restSpan("ModifyGuildRolePositions")()
+
 	return rest.ModifyGuildRolePositions(ctx.Token, ctx.RateLimiter, guildId, positions)
 }
 
 func (ctx *Context) ModifyGuildRole(guildId, roleId uint64, data rest.GuildRoleData) (guild.Role, error) {
+	defer ctx.restSpan("ModifyGuildRole")()
+
 	return rest.ModifyGuildRole(ctx.Token, ctx.RateLimiter, guildId, roleId, data)
 }
 
 func (ctx *Context) DeleteGuildRole(guildId, roleId uint64) error {
+	defer ctx.restSpan("DeleteGuildRole")()
+
 	return rest.DeleteGuildRole(ctx.Token, ctx.RateLimiter, guildId, roleId)
 }
 
 func (ctx *Context) GetGuildPruneCount(guildId uint64, days int) (int, error) {
+	defer ctx.restSpan("GetGuildPruneCount")()
+
 	return rest.GetGuildPruneCount(ctx.Token, ctx.RateLimiter, guildId, days)
 }
 
 // computePruneCount = whether 'pruned' is returned, discouraged for large guilds
 func (ctx *Context) BeginGuildPrune(guildId uint64, days int, computePruneCount bool) error {
+	defer ctx.restSpan("BeginGuildPrune")()
+
 	return rest.BeginGuildPrune(ctx.Token, ctx.RateLimiter, guildId, days, computePruneCount)
 }
 
 func (ctx *Context) GetGuildVoiceRegions(guildId uint64) ([]guild.VoiceRegion, error) {
+	defer ctx.restSpan("GetGuildVoiceRegions")()
+
 	return rest.GetGuildVoiceRegions(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) GetGuildInvites(guildId uint64) ([]invite.InviteMetadata, error) {
+	defer ctx.restSpan("GetGuildInvites")()
+
 	return rest.GetGuildInvites(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) GetGuildIntegrations(guildId uint64) ([]integration.Integration, error) {
+	defer ctx.restSpan("GetGuildIntegrations")()
+
 	return rest.GetGuildIntegrations(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) CreateGuildIntegration(guildId uint64, data rest.CreateIntegrationData) error {
+	defer ctx.restSpan("CreateGuildIntegration")()
+
 	return rest.CreateGuildIntegration(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 func (ctx *Context) ModifyGuildIntegration(guildId, integrationId uint64, data rest.ModifyIntegrationData) error {
+	defer ctx.restSpan("ModifyGuildIntegration")()
+
 	return rest.ModifyGuildIntegration(ctx.Token, ctx.RateLimiter, guildId, integrationId, data)
 }
 
 func (ctx *Context) DeleteGuildIntegration(guildId, integrationId uint64) error {
+	defer ctx.restSpan("DeleteGuildIntegration")()
+
 	return rest.DeleteGuildIntegration(ctx.Token, ctx.RateLimiter, guildId, integrationId)
 }
 
 func (ctx *Context) SyncGuildIntegration(guildId, integrationId uint64) error {
+	defer ctx.restSpan("SyncGuildIntegration")()
+
 	return rest.SyncGuildIntegration(ctx.Token, ctx.RateLimiter, guildId, integrationId)
 }
 
 func (ctx *Context) GetGuildEmbed(guildId uint64) (guild.GuildWidget, error) {
+	defer ctx.restSpan("GetGuildEmbed")()
+
 	return rest.GetGuildWidget(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) ModifyGuildEmbed(guildId uint64, data guild.GuildEmbed) (guild.GuildEmbed, error) {
+	defer ctx.restSpan("ModifyGuildEmbed")()
+
 	return rest.ModifyGuildEmbed(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 // returns invite object with only "code" and "uses" fields
 func (ctx *Context) GetGuildVanityUrl(guildId uint64) (invite.Invite, error) {
+	defer ctx.restSpan("GetGuildVanityUrl")()
+
 	return rest.GetGuildVanityURL(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) GetInvite(inviteCode string, withCounts bool) (invite.Invite, error) {
+	defer ctx.restSpan("GetInvite")()
+
 	return rest.GetInvite(ctx.Token, ctx.RateLimiter, inviteCode, withCounts)
 }
 
 func (ctx *Context) DeleteInvite(inviteCode string) (invite.Invite, error) {
+	defer ctx.restSpan("DeleteInvite")()
+
 	return rest.DeleteInvite(ctx.Token, ctx.RateLimiter, inviteCode)
 }
 
 func (ctx *Context) GetCurrentUser() (user.User, error) {
+	defer ctx.restSpan("GetCurrentUser")()
+
 	if cached, found := ctx.Cache.GetSelf(); found {
 		return cached, nil
 	}
@@ -490,6 +650,8 @@ func (ctx *Context) GetCurrentUser() (user.User, error) {
 }
 
 func (ctx *Context) GetUser(userId uint64) (user.User, error) {
+	defer ctx.restSpan("GetUser")()
+
 	shouldCache := ctx.Cache.GetOptions().Users
 
 	if shouldCache {
@@ -508,107 +670,159 @@ func (ctx *Context) GetUser(userId uint64) (user.User, error) {
 }
 
 func (ctx *Context) ModifyCurrentUser(data rest.ModifyUserData) (user.User, error) {
+	defer ctx.restSpan("ModifyCurrentUser")()
+
 	return rest.ModifyCurrentUser(ctx.Token, ctx.RateLimiter, data)
 }
 
 func (ctx *Context) GetCurrentUserGuilds(data rest.CurrentUserGuildsData) ([]guild.Guild, error) {
+	defer ctx.restSpan("GetCurrentUserGuilds")()
+
 	return rest.GetCurrentUserGuilds(ctx.Token, ctx.RateLimiter, data)
 }
 
 func (ctx *Context) LeaveGuild(guildId uint64) error {
+	defer ctx.restSpan("LeaveGuild")()
+
 	return rest.LeaveGuild(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) CreateDM(recipientId uint64) (channel.Channel, error) {
+	defer ctx.restSpan("CreateDM")()
+
 	return rest.CreateDM(ctx.Token, ctx.RateLimiter, recipientId)
 }
 
 func (ctx *Context) GetUserConnections() ([]integration.Connection, error) {
+	defer ctx.restSpan("GetUserConnections")()
+
 	return rest.GetUserConnections(ctx.Token, ctx.RateLimiter)
 }
 
 // GetGuildVoiceRegions should be preferred, as it returns VIP servers if available to the guild
 func (ctx *Context) ListVoiceRegions() ([]guild.VoiceRegion, error) {
+	defer ctx.restSpan("ListVoiceRegions")()
+
 	return rest.ListVoiceRegions(ctx.Token)
 }
 
 func (ctx *Context) CreateWebhook(channelId uint64, data rest.WebhookData) (guild.Webhook, error) {
+	defer ctx.restSpan("CreateWebhook")()
+
 	return rest.CreateWebhook(ctx.Token, ctx.RateLimiter, channelId, data)
 }
 
 func (ctx *Context) GetChannelWebhooks(channelId uint64) ([]guild.Webhook, error) {
+	defer ctx.restSpan("GetChannelWebhooks")()
+
 	return rest.GetChannelWebhooks(ctx.Token, ctx.RateLimiter, channelId)
 }
 
 func (ctx *Context) GetGuildWebhooks(guildId uint64) ([]guild.Webhook, error) {
+	defer ctx.restSpan("GetGuildWebhooks")()
+
 	return rest.GetGuildWebhooks(ctx.Token, ctx.RateLimiter, guildId)
 }
 
 func (ctx *Context) GetWebhook(webhookId uint64) (guild.Webhook, error) {
+	defer ctx.restSpan("GetWebhook")()
+
 	return rest.GetWebhook(ctx.Token, ctx.RateLimiter, webhookId)
 }
 
 func (ctx *Context) ModifyWebhook(webhookId uint64, data rest.ModifyWebhookData) (guild.Webhook, error) {
+	defer ctx.restSpan("ModifyWebhook")()
+
 	return rest.ModifyWebhook(ctx.Token, ctx.RateLimiter, webhookId, data)
 }
 
 func (ctx *Context) DeleteWebhook(webhookId uint64) error {
+	defer ctx.restSpan("DeleteWebhook")()
+
 	return rest.DeleteWebhook(ctx.Token, ctx.RateLimiter, webhookId)
 }
 
 // if wait=true, a message object will be returned
 func (ctx *Context) ExecuteWebhook(webhookId uint64, webhookToken string, wait bool, data rest.WebhookBody) (*message.Message, error) {
+	defer ctx.restSpan("ExecuteWebhook")()
+
 	return rest.ExecuteWebhook(webhookToken, ctx.RateLimiter, webhookId, wait, data)
 }
 
 func (ctx *Context) GetGuildAuditLog(guildId uint64, data rest.GetGuildAuditLogData) (auditlog.AuditLog, error) {
+	defer ctx.restSpan("GetGuildAuditLog")()
+
 	return rest.GetGuildAuditLog(ctx.Token, ctx.RateLimiter, guildId, data)
 }
 
 func (ctx *Context) GetGlobalCommands(applicationId uint64) ([]interaction.ApplicationCommand, error) {
+	defer ctx.restSpan("GetGlobalCommands")()
+
 	return rest.GetGlobalCommands(ctx.Token, ctx.RateLimiter, applicationId)
 }
 
 func (ctx *Context) CreateGlobalCommand(applicationId uint64, data rest.CreateCommandData) (interaction.ApplicationCommand, error) {
+	defer ctx.restSpan("CreateGlobalCommand")()
+
 	return rest.CreateGlobalCommand(ctx.Token, ctx.RateLimiter, applicationId, data)
 }
 
 func (ctx *Context) ModifyGlobalCommand(applicationId, commandId uint64, data rest.CreateCommandData) (interaction.ApplicationCommand, error) {
+	defer ctx.restSpan("ModifyGlobalCommand")()
+
 	return rest.ModifyGlobalCommand(ctx.Token, ctx.RateLimiter, applicationId, commandId, data)
 }
 
 func (ctx *Context) DeleteGlobalCommand(applicationId, commandId uint64) error {
+	defer ctx.restSpan("DeleteGlobalCommand")()
+
 	return rest.DeleteGlobalCommand(ctx.Token, ctx.RateLimiter, applicationId, commandId)
 }
 
 func (ctx *Context) GetGuildCommands(applicationId, guildId uint64) ([]interaction.ApplicationCommand, error) {
+	defer ctx.restSpan("GetGuildCommands")()
+
 	return rest.GetGuildCommands(ctx.Token, ctx.RateLimiter, applicationId, guildId)
 }
 
 func (ctx *Context) CreateGuildCommand(applicationId, guildId uint64, data rest.CreateCommandData) (interaction.ApplicationCommand, error) {
+	defer ctx.restSpan("CreateGuildCommand")()
+
 	return rest.CreateGuildCommand(ctx.Token, ctx.RateLimiter, applicationId, guildId, data)
 }
 
 func (ctx *Context) ModifyGuildCommand(applicationId, guildId, commandId uint64, data rest.CreateCommandData) (interaction.ApplicationCommand, error) {
+	defer ctx.restSpan("ModifyGuildCommand")()
+
 	return rest.ModifyGuildCommand(ctx.Token, ctx.RateLimiter, applicationId, guildId, commandId, data)
 }
 
 func (ctx *Context) DeleteGuildCommand(applicationId, guildId, commandId uint64) error {
+	defer ctx.restSpan("DeleteGuildCommand")()
+
 	return rest.DeleteGuildCommand(ctx.Token, ctx.RateLimiter, applicationId, guildId, commandId)
 }
 
 func (ctx *Context) GetCommandPermissions(applicationId, guildId, commandId uint64) (rest.CommandWithPermissionsData, error) {
+	defer ctx.restSpan("GetCommandPermissions")()
+
 	return rest.GetCommandPermissions(ctx.Token, ctx.RateLimiter, applicationId, guildId, commandId)
 }
 
 func (ctx *Context) GetBulkCommandPermissions(applicationId, guildId uint64) ([]rest.CommandWithPermissionsData, error) {
+	defer ctx.restSpan("GetBulkCommandPermissions")()
+
 	return rest.GetBulkCommandPermissions(ctx.Token, ctx.RateLimiter, applicationId, guildId)
 }
 
 func (ctx *Context) EditCommandPermissions(applicationId, guildId, commandId uint64, data rest.CommandWithPermissionsData) (rest.CommandWithPermissionsData, error) {
+	defer ctx.restSpan("EditCommandPermissions")()
+
 	return rest.EditCommandPermissions(ctx.Token, ctx.RateLimiter, applicationId, guildId, commandId, data)
 }
 
 func (ctx *Context) EditBulkCommandPermissions(applicationId, guildId uint64, data []rest.CommandWithPermissionsData) ([]rest.CommandWithPermissionsData, error) {
+	defer ctx.restSpan("EditBulkCommandPermissions")()
+
 	return rest.EditBulkCommandPermissions(ctx.Token, ctx.RateLimiter, applicationId, guildId, data)
 }
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"github.com/TicketsBot/worker/bot/tracing"
+	"github.com/sirupsen/logrus"
+)
+
+// TraceContext returns the context that spans created by this worker should be children of. It is fixed when the
+// worker is created, as goroutines sharing the worker would otherwise swap it under each other.
+func (c *Context) TraceContext() context.Context {
+	if c.traceContext == nil {
+		return context.Background()
+	}
+
+	return c.traceContext
+}
+
+// WithTraceContext returns a copy of the worker whose spans (e.g. REST requests) are children of ctx. The original
+// worker is left unchanged, and the copy's log fields are separate from the original's.
+func (c *Context) WithTraceContext(ctx context.Context) *Context {
+	c.logLock.RLock()
+	logFields := make(logrus.Fields, len(c.logFields))
+	for key, value := range c.logFields {
+		logFields[key] = value
+	}
+	c.logLock.RUnlock()
+
+	return &Context{
+		Token:        c.Token,
+		BotId:        c.BotId,
+		IsWhitelabel: c.IsWhitelabel,
+		ShardId:      c.ShardId,
+		Cache:        c.Cache,
+		RateLimiter:  c.RateLimiter,
+		traceContext: ctx,
+		logFields:    logFields,
+	}
+}
+
+// TraceId returns the ID of the trace that the worker is part of, or an empty string if it is not sampled
+func (c *Context) TraceId() string {
+	return tracing.TraceId(c.TraceContext())
+}
+
+// restSpan starts a span for a REST request, which is ended by calling the returned function
+func (c *Context) restSpan(name string) func() {
+	_, span := tracing.Start(c.TraceContext(), "rest."+name)
+	return func() {
+		span.End()
+	}
+}